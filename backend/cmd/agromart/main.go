@@ -5,12 +5,14 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"time"
 
 	"github.com/C0deNe0/agromart/internal/config"
 	"github.com/C0deNe0/agromart/internal/database"
 	"github.com/C0deNe0/agromart/internal/handler"
 	"github.com/C0deNe0/agromart/internal/lib/aws"
+	"github.com/C0deNe0/agromart/internal/lib/notify"
 	"github.com/C0deNe0/agromart/internal/lib/utils"
 	"github.com/C0deNe0/agromart/internal/logger"
 	"github.com/C0deNe0/agromart/internal/repository"
@@ -32,12 +34,6 @@ func main() {
 		panic("failed to load the config: " + err.Error())
 	}
 
-	// googleOAuth := utils.NewGoogleOAuth(
-	// 	cfg.OAuth.GoogleClientID,
-	// 	cfg.OAuth.GoogleClientSecret,
-	// 	cfg.OAuth.GoogleRedirectURI,
-	// )
-
 	//new custom logger
 	log := logger.New(cfg.Primary.Env)
 
@@ -67,14 +63,62 @@ func main() {
 		cfg.StorageS3.BucketName,
 		cfg.StorageS3.Region)
 
-	services := service.NewServices(repos, tokenManager, refreshTokenRepo, s3Service)
+	oidcProviders := []utils.OIDCProvider{{
+		Name:      service.AuthProviderGoogle,
+		Issuers:   utils.GoogleIssuers,
+		Audiences: append([]string{cfg.OAuth.GoogleClientID}, cfg.OAuth.GoogleMobileClientIDs...),
+		JWKSURL:   utils.GoogleJWKSURL,
+	}}
+	for name, p := range cfg.OAuth.Providers {
+		oidcProviders = append(oidcProviders, utils.OIDCProvider{
+			Name:      name,
+			Issuers:   []string{p.Issuer},
+			Audiences: p.Audiences,
+			JWKSURL:   p.JWKSURL,
+		})
+	}
+	oidcRegistry := utils.NewOIDCRegistry(oidcProviders...)
+
+	googleWebOAuth := utils.NewGoogleWebOAuth(
+		cfg.OAuth.GoogleClientID,
+		cfg.OAuth.GoogleClientSecret,
+		cfg.OAuth.GoogleRedirectURI,
+		cfg.OAuth.WebRedirectURL,
+	)
+
+	//only logs for now, plug email/push notifiers in here
+	notifier := notify.MultiNotifier{notify.NewLogNotifier(log)}
+
+	moderation := service.ModerationConfig{
+		BannedKeywords:           cfg.Moderation.BannedKeywords,
+		PriceOutlierFactor:       cfg.Moderation.PriceOutlierFactor,
+		TrustedSellerMinApproved: cfg.Moderation.TrustedSellerMinApproved,
+		ReportHideThreshold:      cfg.Moderation.ReportHideThreshold,
+	}
+
+	services := service.NewServices(repos, tokenManager, refreshTokenRepo, s3Service, oidcRegistry, googleWebOAuth, moderation, notifier, log)
 	handlers := handler.NewHandlers(services)
-	r := router.NewRouter(&handlers, tokenManager)
+	r := router.NewRouter(&handlers, tokenManager, services.Impersonation, services.APIKey)
 
 	srv.SetupHTTPServer(r)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 
+	// lift suspensions whose end date has passed
+	go services.Suspension.Run(ctx, service.SuspensionSweepInterval)
+
+	// end vacations whose end date has passed
+	go services.Availability.Run(ctx, service.VacationSweepInterval)
+
+	// batch product views and impressions into the daily analytics tables,
+	// waited on below so the last batch is written before the pool closes
+	var viewsDone sync.WaitGroup
+	viewsDone.Add(1)
+	go func() {
+		defer viewsDone.Done()
+		services.ProductViews.Run(ctx, service.ProductViewFlushInterval)
+	}()
+
 	// start server
 	go func() {
 		if err := srv.Start(); err != nil && err != http.ErrServerClosed {
@@ -84,6 +128,8 @@ func main() {
 
 	// wait for signal
 	<-ctx.Done()
+	// the server closes the database on shutdown
+	viewsDone.Wait()
 	ctx, cancel := context.WithTimeout(context.Background(), DefaultContextTimeout*time.Second)
 	defer cancel()
 	// stop server
@@ -13,11 +13,12 @@ import (
 )
 
 type Config struct {
-	Primary   Primary        `koanf:"primary" validate:"required"`
-	Server    Server         `koanf:"server" validate:"required"`
-	Database  DatabaseConfig `koanf:"database" validate:"required"`
-	OAuth     OAuthConfig    `koanf:"oauth" validate:"required"`
-	StorageS3 StorageS3      `koanf:"storages3" `
+	Primary    Primary          `koanf:"primary" validate:"required"`
+	Server     Server           `koanf:"server" validate:"required"`
+	Database   DatabaseConfig   `koanf:"database" validate:"required"`
+	OAuth      OAuthConfig      `koanf:"oauth" validate:"required"`
+	StorageS3  StorageS3        `koanf:"storages3" `
+	Moderation ModerationConfig `koanf:"moderation"`
 }
 
 type Primary struct {
@@ -51,6 +52,19 @@ type OAuthConfig struct {
 	GoogleClientID     string `koanf:"google_client_id" validate:"required"`
 	GoogleClientSecret string `koanf:"google_client_secret" validate:"required"`
 	GoogleRedirectURI  string `koanf:"google_redirect_uri" validate:"required"`
+	// extra client IDs (android/ios) accepted as audience for google ID tokens
+	GoogleMobileClientIDs []string `koanf:"google_mobile_client_ids"`
+	// web admin panel page the browser lands on after the OAuth callback
+	WebRedirectURL string `koanf:"web_redirect_url" validate:"omitempty,url"`
+
+	// additional OIDC providers keyed by name, e.g. AGROMART_OAUTH.PROVIDERS.APPLE.ISSUER
+	Providers map[string]OIDCProviderConfig `koanf:"providers" validate:"dive"`
+}
+
+type OIDCProviderConfig struct {
+	Issuer    string   `koanf:"issuer" validate:"required,url"`
+	Audiences []string `koanf:"audiences" validate:"required,min=1"`
+	JWKSURL   string   `koanf:"jwks_url" validate:"required,url"`
 }
 
 type StorageS3 struct {
@@ -58,6 +72,19 @@ type StorageS3 struct {
 	Region     string `koanf:"region" `
 }
 
+// ModerationConfig drives the automated product checks that run before
+// the manual review queue and the handling of user reports.
+type ModerationConfig struct {
+	// rejected outright when found in a name, description or variant label (comma separated)
+	BannedKeywords []string `koanf:"banned_keywords"`
+	// prices this many times above or below the category median are flagged, defaults to 3
+	PriceOutlierFactor float64 `koanf:"price_outlier_factor" validate:"omitempty,gt=1"`
+	// companies with this many approved products and no recent rejections skip review, 0 disables
+	TrustedSellerMinApproved int `koanf:"trusted_seller_min_approved" validate:"min=0"`
+	// open reports after which the reported entity is hidden, defaults to 3
+	ReportHideThreshold int `koanf:"report_hide_threshold" validate:"min=0"`
+}
+
 func LoadConfig() (*Config, error) {
 	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stdout}).With().Timestamp().Logger()
 
@@ -0,0 +1,21 @@
+-- UP: 00008_oidc_auth_providers
+
+-- =============================================
+-- USER AUTH METHODS: GENERIC OIDC PROVIDERS
+-- =============================================
+
+-- providers are configured at runtime (GOOGLE, APPLE, MICROSOFT, ...),
+-- so only enforce the naming format instead of a fixed list
+ALTER TABLE user_auth_methods
+    DROP CONSTRAINT IF EXISTS user_auth_methods_auth_provider_check;
+
+ALTER TABLE user_auth_methods
+    ADD CONSTRAINT user_auth_methods_auth_provider_check
+    CHECK (auth_provider ~ '^[A-Z][A-Z0-9_]*$');
+
+-- a subject is only unique within its issuer
+ALTER TABLE user_auth_methods
+    DROP CONSTRAINT IF EXISTS oauth_sub_unique;
+
+ALTER TABLE user_auth_methods
+    ADD CONSTRAINT provider_oauth_sub_unique UNIQUE (auth_provider, oauth_sub);
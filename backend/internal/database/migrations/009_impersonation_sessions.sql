@@ -0,0 +1,31 @@
+-- UP: 00009_impersonation_sessions
+
+-- =============================================
+-- ADMIN IMPERSONATION (SUPPORT)
+-- =============================================
+
+-- every impersonation is kept as its own row, start and stop are
+-- the audit trail (who, whom, why, from where, until when)
+CREATE TABLE impersonation_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    reason TEXT NOT NULL,
+    read_only BOOLEAN NOT NULL DEFAULT TRUE,
+
+    ip_address TEXT,
+    user_agent TEXT,
+
+    expires_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    ended_by UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT impersonation_not_self CHECK (admin_id <> target_user_id)
+);
+
+CREATE INDEX idx_impersonation_admin ON impersonation_sessions(admin_id, created_at DESC);
+CREATE INDEX idx_impersonation_target ON impersonation_sessions(target_user_id, created_at DESC);
@@ -0,0 +1,35 @@
+-- UP: 00010_company_api_keys
+
+-- =============================================
+-- COMPANY API KEYS (ERP / CATALOG SYNC)
+-- =============================================
+
+-- only the sha256 of the key is stored, key_prefix is kept so sellers
+-- can tell their keys apart in the dashboard
+CREATE TABLE company_api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    created_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    name TEXT NOT NULL,
+    key_prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL,
+
+    last_used_at TIMESTAMPTZ,
+    last_used_ip TEXT,
+
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    rotated_from_id UUID REFERENCES company_api_keys(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT api_key_scopes_valid CHECK (
+        cardinality(scopes) > 0
+        AND scopes <@ ARRAY['products:write', 'variants:stock', 'images:write']::TEXT[]
+    )
+);
+
+CREATE INDEX idx_company_api_keys_company ON company_api_keys(company_id, created_at DESC);
@@ -0,0 +1,32 @@
+-- UP: 00011_login_events
+
+-- =============================================
+-- LOGIN AUDIT TRAIL
+-- =============================================
+
+-- user_id is NULL when the attempted account does not exist
+CREATE TABLE login_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    email TEXT,
+
+    auth_provider TEXT NOT NULL,
+    success BOOLEAN NOT NULL,
+    failure_reason TEXT,
+
+    ip_address TEXT,
+    user_agent TEXT,
+    device_fingerprint TEXT,
+    country TEXT,
+    region TEXT,
+
+    -- set by the suspicious login rules
+    is_new_device BOOLEAN NOT NULL DEFAULT FALSE,
+    is_new_location BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_events_user ON login_events(user_id, created_at DESC);
+CREATE INDEX idx_login_events_user_device ON login_events(user_id, device_fingerprint) WHERE success;
+CREATE INDEX idx_login_events_user_location ON login_events(user_id, country, region) WHERE success;
@@ -0,0 +1,32 @@
+-- UP: 00012_company_kyc
+
+-- =============================================
+-- NORMALISE TAX IDS
+-- =============================================
+
+-- GSTIN / PAN are validated in the service layer, stored upper-cased
+UPDATE companies SET gst_number = UPPER(TRIM(gst_number)) WHERE gst_number IS NOT NULL;
+UPDATE companies SET pan_number = UPPER(TRIM(pan_number)) WHERE pan_number IS NOT NULL;
+
+-- =============================================
+-- KYC DOCUMENTS
+-- =============================================
+
+-- files live in a private S3 prefix, admins get short lived download URLs
+CREATE TABLE company_kyc_documents (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    uploaded_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    document_type TEXT NOT NULL CHECK (document_type IN ('GST_CERTIFICATE', 'PAN_CARD', 'FSSAI_LICENCE')),
+    document_number TEXT,
+
+    s3_key TEXT NOT NULL UNIQUE,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_company_kyc_documents_company ON company_kyc_documents(company_id, created_at DESC);
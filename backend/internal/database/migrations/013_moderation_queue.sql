@@ -0,0 +1,44 @@
+-- UP: 00013_moderation_queue
+
+-- =============================================
+-- MODERATION CLAIMS
+-- =============================================
+
+-- one row per company/product that a reviewer is currently working on.
+-- a claim is only a soft lock: once expires_at passes anyone can take it
+-- over, and the row is removed when the item is approved or rejected.
+CREATE TABLE moderation_claims (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type TEXT NOT NULL CHECK (entity_type IN ('COMPANY', 'PRODUCT')),
+    entity_id UUID NOT NULL,
+
+    reviewer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE(entity_type, entity_id)
+);
+
+CREATE INDEX idx_moderation_claims_reviewer ON moderation_claims(reviewer_id, expires_at);
+
+COMMENT ON TABLE moderation_claims IS 'Reviewer locks on pending companies/products so two admins do not review the same item';
+COMMENT ON COLUMN moderation_claims.assigned_by_id IS 'Set when another admin assigned the item, NULL when the reviewer claimed it';
+
+
+-- =============================================
+-- QUEUE INDEXES
+-- =============================================
+
+-- the queues read pending rows oldest first
+CREATE INDEX idx_companies_pending_queue ON companies(submitted_at)
+WHERE approval_status = 'PENDING' AND is_active = TRUE;
+
+CREATE INDEX idx_products_pending_queue ON products(submitted_at)
+WHERE approval_status = 'PENDING' AND is_active = TRUE;
+
+CREATE INDEX idx_approval_history_company_action ON company_approval_history(company_id, action);
+CREATE INDEX idx_product_approval_history_product_action ON product_approval_history(product_id, action);
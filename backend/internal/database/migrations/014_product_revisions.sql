@@ -0,0 +1,59 @@
+-- UP: 00014_product_revisions
+
+-- =============================================
+-- PRODUCT REVISIONS
+-- =============================================
+
+-- edits to an APPROVED product are kept here as a draft until an admin
+-- approves them, the live row in products is left untouched meanwhile.
+-- the draft carries the full proposed values plus the field level diff
+-- against the live version at the time it was submitted.
+CREATE TABLE product_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    submitted_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    category_id UUID REFERENCES categories(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    description TEXT,
+    unit TEXT NOT NULL,
+    origin TEXT,
+    base_price NUMERIC(10,2) NOT NULL CHECK (base_price >= 0),
+
+    changes JSONB NOT NULL,
+
+    status approval_status NOT NULL DEFAULT 'PENDING',
+    reviewed_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    rejection_reason TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CHECK (length(name) >= 3)
+);
+
+CREATE INDEX idx_product_revisions_product_id ON product_revisions(product_id, created_at DESC);
+
+-- a product has at most one open draft, editing again replaces it
+CREATE UNIQUE INDEX idx_product_revisions_one_pending
+ON product_revisions(product_id)
+WHERE status = 'PENDING';
+
+
+-- =============================================
+-- APPROVAL HISTORY: REVISIONS
+-- =============================================
+
+ALTER TABLE product_approval_history
+    ADD COLUMN revision_id UUID REFERENCES product_revisions(id) ON DELETE SET NULL,
+    ADD COLUMN changes JSONB;
+
+ALTER TABLE product_approval_history DROP CONSTRAINT product_approval_history_action_check;
+ALTER TABLE product_approval_history ADD CONSTRAINT product_approval_history_action_check
+    CHECK (action IN (
+        'SUBMITTED', 'APPROVED', 'REJECTED', 'RESUBMITTED',
+        'REVISION_SUBMITTED', 'REVISION_APPROVED', 'REVISION_REJECTED'
+    ));
+
+COMMENT ON COLUMN product_approval_history.changes IS 'Field level diff {"field": {"from": .., "to": ..}} for revision events';
@@ -0,0 +1,69 @@
+-- UP: 00015_approval_snapshots
+
+-- =============================================
+-- SUBMISSION SNAPSHOTS
+-- =============================================
+
+-- SUBMITTED / RESUBMITTED history rows keep a copy of what the seller sent,
+-- the API diffs consecutive snapshots so admins can see what was fixed
+-- after a rejection. keys match the json field names of the models.
+ALTER TABLE company_approval_history ADD COLUMN snapshot JSONB;
+ALTER TABLE product_approval_history ADD COLUMN snapshot JSONB;
+
+
+CREATE OR REPLACE FUNCTION company_snapshot(c companies)
+RETURNS JSONB AS $$
+    SELECT jsonb_build_object(
+        'name', c.name,
+        'description', c.description,
+        'logoUrl', c.logo_url,
+        'businessEmail', c.business_email,
+        'businessPhone', c.business_phone,
+        'city', c.city,
+        'state', c.state,
+        'pincode', c.pincode,
+        'gstNumber', c.gst_number,
+        'panNumber', c.pan_number,
+        'productVisibility', c.product_visibility
+    );
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION product_snapshot(p products)
+RETURNS JSONB AS $$
+    SELECT jsonb_build_object(
+        'categoryId', p.category_id,
+        'name', p.name,
+        'description', p.description,
+        'unit', p.unit,
+        'origin', p.origin,
+        'price', p.base_price
+    );
+$$ LANGUAGE sql STABLE;
+
+
+-- =============================================
+-- SUBMISSION TRIGGERS
+-- =============================================
+
+CREATE OR REPLACE FUNCTION log_company_submission()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (TG_OP = 'INSERT') THEN
+        INSERT INTO company_approval_history (company_id, action, performed_by_id, notes, snapshot)
+        VALUES (NEW.id, 'SUBMITTED', NEW.owner_id, 'Initial submission', company_snapshot(NEW));
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION log_product_submission()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (TG_OP = 'INSERT') THEN
+        INSERT INTO product_approval_history (product_id, action, performed_by_id, notes, snapshot)
+        SELECT NEW.id, 'SUBMITTED', c.owner_id, 'Initial product submission', product_snapshot(NEW)
+        FROM companies c WHERE c.id = NEW.company_id;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,39 @@
+-- UP: 00016_product_moderation_rules
+
+-- =============================================
+-- APPROVAL HISTORY: AUTOMATED RULES
+-- =============================================
+
+-- rule decisions are made by the system, not by a user
+ALTER TABLE product_approval_history ALTER COLUMN performed_by_id DROP NOT NULL;
+
+ALTER TABLE product_approval_history
+    ADD COLUMN rule TEXT;
+
+ALTER TABLE product_approval_history DROP CONSTRAINT product_approval_history_action_check;
+ALTER TABLE product_approval_history ADD CONSTRAINT product_approval_history_action_check
+    CHECK (action IN (
+        'SUBMITTED', 'APPROVED', 'REJECTED', 'RESUBMITTED',
+        'REVISION_SUBMITTED', 'REVISION_APPROVED', 'REVISION_REJECTED',
+        'AUTO_APPROVED', 'AUTO_REJECTED', 'AUTO_FLAGGED'
+    ));
+
+COMMENT ON COLUMN product_approval_history.performed_by_id IS 'NULL for decisions taken by the pre-moderation rules';
+COMMENT ON COLUMN product_approval_history.rule IS 'Name of the pre-moderation rule behind an AUTO_* action';
+
+
+-- =============================================
+-- RULE LOOKUPS
+-- =============================================
+
+-- category median used by the price outlier rule
+CREATE INDEX idx_products_category_price ON products(category_id, base_price)
+WHERE approval_status = 'APPROVED' AND is_active = TRUE;
+
+-- duplicate name detection within a company
+CREATE INDEX idx_products_company_lower_name ON products(company_id, lower(name))
+WHERE is_active = TRUE;
+
+-- flagged items in the moderation queue
+CREATE INDEX idx_product_approval_history_flagged ON product_approval_history(product_id, created_at)
+WHERE action = 'AUTO_FLAGGED';
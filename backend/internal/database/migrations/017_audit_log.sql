@@ -0,0 +1,33 @@
+-- UP: 00017_audit_log
+
+-- =============================================
+-- AUDIT LOG
+-- =============================================
+
+-- every state-changing action taken through the service layer.
+-- actor_id is NULL for system actions (pre-moderation rules, jobs),
+-- impersonator_id is the admin behind an impersonated request.
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    impersonator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id UUID NOT NULL,
+
+    before JSONB,
+    after JSONB,
+
+    request_id TEXT,
+    ip_address TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_actor ON audit_log(actor_id, created_at DESC);
+CREATE INDEX idx_audit_log_entity ON audit_log(entity_type, entity_id, created_at DESC);
+CREATE INDEX idx_audit_log_created ON audit_log(created_at DESC);
+
+COMMENT ON TABLE audit_log IS 'Append-only record of who changed what, written by the services';
+COMMENT ON COLUMN audit_log.request_id IS 'X-Request-ID of the HTTP request, to correlate with access logs';
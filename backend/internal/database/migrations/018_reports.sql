@@ -0,0 +1,82 @@
+-- UP: 00018_reports
+
+-- =============================================
+-- REPORT HIDING
+-- =============================================
+
+-- set when user reports cross the threshold, the entity disappears from
+-- public views until an admin dismisses the reports or restores it
+ALTER TABLE products ADD COLUMN hidden_at TIMESTAMPTZ;
+ALTER TABLE companies ADD COLUMN hidden_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN products.hidden_at IS 'Hidden pending review of user reports, NULL when visible';
+COMMENT ON COLUMN companies.hidden_at IS 'Hidden pending review of user reports, NULL when visible';
+
+
+-- =============================================
+-- REPORTS
+-- =============================================
+
+CREATE TABLE reports (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    entity_type TEXT NOT NULL CHECK (entity_type IN ('PRODUCT', 'COMPANY')),
+    entity_id UUID NOT NULL,
+
+    reason TEXT NOT NULL CHECK (reason IN (
+        'FAKE_LISTING', 'FRAUD', 'COUNTERFEIT', 'PROHIBITED_ITEM',
+        'MISLEADING_INFO', 'OFFENSIVE', 'SPAM', 'OTHER'
+    )),
+    comment TEXT,
+
+    status TEXT NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'RESOLVED', 'DISMISSED')),
+    resolved_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at TIMESTAMPTZ,
+    resolution_note TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- one open report per user and entity, reporting again updates it
+CREATE UNIQUE INDEX idx_reports_open_per_reporter ON reports(reporter_id, entity_type, entity_id)
+WHERE status = 'OPEN';
+
+CREATE INDEX idx_reports_entity_open ON reports(entity_type, entity_id) WHERE status = 'OPEN';
+CREATE INDEX idx_reports_reporter ON reports(reporter_id, created_at DESC);
+
+COMMENT ON TABLE reports IS 'User reports against products and companies, triaged by admins';
+
+
+-- =============================================
+-- FOLLOWING HIDDEN COMPANIES
+-- =============================================
+
+CREATE OR REPLACE FUNCTION check_company_followable()
+RETURNS TRIGGER AS $$
+DECLARE
+    comp_status approval_status;
+    comp_active BOOLEAN;
+    comp_hidden_at TIMESTAMPTZ;
+BEGIN
+    SELECT approval_status, is_active, hidden_at
+    INTO comp_status, comp_active, comp_hidden_at
+    FROM companies
+    WHERE id = NEW.company_id;
+
+    IF comp_status != 'APPROVED' THEN
+        RAISE EXCEPTION 'Cannot follow unapproved company';
+    END IF;
+
+    IF NOT comp_active THEN
+        RAISE EXCEPTION 'Cannot follow inactive company';
+    END IF;
+
+    IF comp_hidden_at IS NOT NULL THEN
+        RAISE EXCEPTION 'Cannot follow company under review';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,52 @@
+-- UP: 00019_suspensions
+
+-- =============================================
+-- SUSPENSIONS
+-- =============================================
+
+-- an admin can take an approved company or product offline. is_active is
+-- cleared while suspended, suspended_until NULL means until reinstated
+ALTER TABLE companies
+    ADD COLUMN suspended_at TIMESTAMPTZ,
+    ADD COLUMN suspended_until TIMESTAMPTZ,
+    ADD COLUMN suspension_reason TEXT;
+
+ALTER TABLE products
+    ADD COLUMN suspended_at TIMESTAMPTZ,
+    ADD COLUMN suspended_until TIMESTAMPTZ,
+    ADD COLUMN suspension_reason TEXT;
+
+COMMENT ON COLUMN companies.suspended_until IS 'Automatically reinstated after this time, NULL for an open-ended suspension';
+COMMENT ON COLUMN products.suspended_until IS 'Automatically reinstated after this time, NULL for an open-ended suspension';
+
+-- the reinstatement sweep only looks at suspensions with an end date
+CREATE INDEX idx_companies_suspended_until ON companies(suspended_until)
+WHERE suspended_at IS NOT NULL AND suspended_until IS NOT NULL;
+CREATE INDEX idx_products_suspended_until ON products(suspended_until)
+WHERE suspended_at IS NOT NULL AND suspended_until IS NOT NULL;
+
+
+-- =============================================
+-- APPROVAL HISTORY
+-- =============================================
+
+-- expired suspensions are reinstated by the system, not by a user
+ALTER TABLE company_approval_history ALTER COLUMN performed_by_id DROP NOT NULL;
+
+ALTER TABLE company_approval_history DROP CONSTRAINT company_approval_history_action_check;
+ALTER TABLE company_approval_history ADD CONSTRAINT company_approval_history_action_check
+    CHECK (action IN (
+        'SUBMITTED', 'APPROVED', 'REJECTED', 'RESUBMITTED',
+        'SUSPENDED', 'REINSTATED'
+    ));
+
+ALTER TABLE product_approval_history DROP CONSTRAINT product_approval_history_action_check;
+ALTER TABLE product_approval_history ADD CONSTRAINT product_approval_history_action_check
+    CHECK (action IN (
+        'SUBMITTED', 'APPROVED', 'REJECTED', 'RESUBMITTED',
+        'REVISION_SUBMITTED', 'REVISION_APPROVED', 'REVISION_REJECTED',
+        'AUTO_APPROVED', 'AUTO_REJECTED', 'AUTO_FLAGGED',
+        'SUSPENDED', 'REINSTATED'
+    ));
+
+COMMENT ON COLUMN company_approval_history.performed_by_id IS 'NULL when a suspension ran out and the system reinstated the company';
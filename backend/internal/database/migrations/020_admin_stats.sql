@@ -0,0 +1,54 @@
+-- UP: 00020_admin_stats
+
+-- =============================================
+-- FOLLOWER EVENTS
+-- =============================================
+
+-- company_followers only holds current follows, unfollowing deletes the
+-- row. the event log keeps both directions so growth can be charted.
+CREATE TABLE company_follower_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event TEXT NOT NULL CHECK (event IN ('FOLLOW', 'UNFOLLOW')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_company_follower_events_company ON company_follower_events(company_id, created_at);
+CREATE INDEX idx_company_follower_events_created ON company_follower_events(created_at);
+
+-- existing follows, their unfollows are lost
+INSERT INTO company_follower_events (company_id, user_id, event, created_at)
+SELECT company_id, user_id, 'FOLLOW', followed_at FROM company_followers;
+
+
+CREATE OR REPLACE FUNCTION log_company_follower_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (TG_OP = 'INSERT') THEN
+        INSERT INTO company_follower_events (company_id, user_id, event)
+        VALUES (NEW.company_id, NEW.user_id, 'FOLLOW');
+    ELSIF (TG_OP = 'DELETE') THEN
+        -- skipped when the company or user is being deleted along with the follow
+        INSERT INTO company_follower_events (company_id, user_id, event)
+        SELECT OLD.company_id, OLD.user_id, 'UNFOLLOW'
+        WHERE EXISTS (SELECT 1 FROM companies WHERE id = OLD.company_id)
+            AND EXISTS (SELECT 1 FROM users WHERE id = OLD.user_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_log_company_follower_event ON company_followers;
+CREATE TRIGGER trigger_log_company_follower_event
+AFTER INSERT OR DELETE ON company_followers
+FOR EACH ROW
+EXECUTE FUNCTION log_company_follower_event();
+
+
+-- =============================================
+-- STATS LOOKUPS
+-- =============================================
+
+CREATE INDEX idx_users_created_at ON users(created_at);
+CREATE INDEX idx_companies_created_at ON companies(created_at);
@@ -0,0 +1,44 @@
+-- UP: 00021_seller_analytics
+
+-- =============================================
+-- PRODUCT VIEW STATS
+-- =============================================
+
+-- views and list impressions are counted in memory and flushed here in
+-- batches, one row per product and day. company_id is copied over so the
+-- company dashboard does not have to join products.
+CREATE TABLE product_stats_daily (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+
+    views INT NOT NULL DEFAULT 0,
+    impressions INT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (product_id, day)
+);
+
+CREATE INDEX idx_product_stats_daily_company ON product_stats_daily(company_id, day);
+
+-- distinct detail viewers per product and day, viewer_key is the user id
+-- or a hash of the client for requests without a user
+CREATE TABLE product_viewers_daily (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    viewer_key TEXT NOT NULL,
+
+    PRIMARY KEY (product_id, day, viewer_key)
+);
+
+CREATE INDEX idx_product_viewers_daily_company ON product_viewers_daily(company_id, day);
+
+COMMENT ON TABLE product_stats_daily IS 'Daily product detail views and list impressions for seller analytics';
+COMMENT ON TABLE product_viewers_daily IS 'Distinct product detail viewers per day for seller analytics';
+
+
+-- =============================================
+-- ANALYTICS LOOKUPS
+-- =============================================
+
+CREATE INDEX idx_favorites_product_created ON favorites(product_id, created_at);
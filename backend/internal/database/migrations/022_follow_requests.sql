@@ -0,0 +1,101 @@
+-- UP: 00022_follow_requests
+
+-- =============================================
+-- FOLLOW REQUESTS
+-- =============================================
+
+-- with approve_followers set, new follows wait as PENDING until the owner
+-- accepts them. followed_at is the request time while pending and the
+-- acceptance time afterwards.
+ALTER TABLE companies
+    ADD COLUMN approve_followers BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE company_followers
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'ACCEPTED'
+        CHECK (status IN ('PENDING', 'ACCEPTED'));
+
+CREATE INDEX idx_company_followers_pending ON company_followers(company_id, followed_at)
+    WHERE status = 'PENDING';
+
+COMMENT ON COLUMN companies.approve_followers IS 'New follows stay pending until the owner accepts them';
+
+
+-- =============================================
+-- BLOCKED FOLLOWERS
+-- =============================================
+
+CREATE TABLE company_blocked_users (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (company_id, user_id)
+);
+
+CREATE INDEX idx_company_blocked_users_user ON company_blocked_users(user_id);
+
+
+-- =============================================
+-- COUNTS AND EVENTS ONLY TRACK ACCEPTED FOLLOWS
+-- =============================================
+
+CREATE OR REPLACE FUNCTION update_company_follower_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (TG_OP = 'INSERT') THEN
+        IF NEW.status = 'ACCEPTED' THEN
+            UPDATE companies
+            SET follower_count = follower_count + 1
+            WHERE id = NEW.company_id;
+        END IF;
+        RETURN NEW;
+    ELSIF (TG_OP = 'UPDATE') THEN
+        IF OLD.status <> 'ACCEPTED' AND NEW.status = 'ACCEPTED' THEN
+            UPDATE companies
+            SET follower_count = follower_count + 1
+            WHERE id = NEW.company_id;
+        END IF;
+        RETURN NEW;
+    ELSIF (TG_OP = 'DELETE') THEN
+        IF OLD.status = 'ACCEPTED' THEN
+            UPDATE companies
+            SET follower_count = GREATEST(follower_count - 1, 0)
+            WHERE id = OLD.company_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_company_follower_count ON company_followers;
+CREATE TRIGGER trigger_update_company_follower_count
+AFTER INSERT OR UPDATE OF status OR DELETE ON company_followers
+FOR EACH ROW
+EXECUTE FUNCTION update_company_follower_count();
+
+
+CREATE OR REPLACE FUNCTION log_company_follower_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF (TG_OP = 'INSERT' AND NEW.status = 'ACCEPTED')
+        OR (TG_OP = 'UPDATE' AND OLD.status <> 'ACCEPTED' AND NEW.status = 'ACCEPTED') THEN
+        INSERT INTO company_follower_events (company_id, user_id, event)
+        VALUES (NEW.company_id, NEW.user_id, 'FOLLOW');
+    ELSIF (TG_OP = 'DELETE' AND OLD.status = 'ACCEPTED') THEN
+        -- skipped when the company or user is being deleted along with the follow
+        INSERT INTO company_follower_events (company_id, user_id, event)
+        SELECT OLD.company_id, OLD.user_id, 'UNFOLLOW'
+        WHERE EXISTS (SELECT 1 FROM companies WHERE id = OLD.company_id)
+            AND EXISTS (SELECT 1 FROM users WHERE id = OLD.user_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_log_company_follower_event ON company_followers;
+CREATE TRIGGER trigger_log_company_follower_event
+AFTER INSERT OR UPDATE OF status OR DELETE ON company_followers
+FOR EACH ROW
+EXECUTE FUNCTION log_company_follower_event();
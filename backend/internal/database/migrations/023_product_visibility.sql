@@ -0,0 +1,16 @@
+-- UP: 00023_product_visibility
+
+-- =============================================
+-- PER-PRODUCT VISIBILITY
+-- =============================================
+
+-- NULL follows the company's product_visibility, a value overrides it for
+-- this product only. changing it does not go through re-approval.
+ALTER TABLE products
+    ADD COLUMN visibility TEXT
+        CHECK (visibility IN ('PUBLIC', 'FOLLOWERS_ONLY', 'PRIVATE'));
+
+CREATE INDEX idx_products_visibility ON products(company_id, visibility)
+    WHERE visibility IS NOT NULL;
+
+COMMENT ON COLUMN products.visibility IS 'Overrides companies.product_visibility when set';
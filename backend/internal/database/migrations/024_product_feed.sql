@@ -0,0 +1,101 @@
+-- UP: 00024_product_feed
+
+-- =============================================
+-- PRODUCT FEED EVENTS
+-- =============================================
+
+-- what the followed-companies feed is built from. rows are written by the
+-- triggers below so every path that approves a product or changes a price
+-- or stock level is covered.
+CREATE TABLE product_feed_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    -- set for variant price drops and back in stock
+    variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+
+    event TEXT NOT NULL CHECK (event IN ('NEW_PRODUCT', 'PRICE_DROP', 'BACK_IN_STOCK')),
+    old_price NUMERIC(10,2),
+    new_price NUMERIC(10,2),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_product_feed_events_company ON product_feed_events(company_id, created_at DESC, id DESC);
+CREATE INDEX idx_product_feed_events_product ON product_feed_events(product_id, created_at DESC, id DESC);
+
+-- products approved before the feed existed
+INSERT INTO product_feed_events (product_id, company_id, event, new_price, created_at)
+SELECT id, company_id, 'NEW_PRODUCT', base_price, COALESCE(reviewed_at, created_at)
+FROM products
+WHERE approval_status = 'APPROVED';
+
+COMMENT ON TABLE product_feed_events IS 'New products, price drops and restocks shown in the followed-companies feed';
+
+
+-- =============================================
+-- TRIGGERS
+-- =============================================
+
+CREATE OR REPLACE FUNCTION log_product_feed_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.approval_status <> 'APPROVED' AND NEW.approval_status = 'APPROVED' THEN
+        -- only the first approval is news, not a later re-approval
+        IF NOT EXISTS (
+            SELECT 1 FROM product_feed_events
+            WHERE product_id = NEW.id AND event = 'NEW_PRODUCT'
+        ) THEN
+            INSERT INTO product_feed_events (product_id, company_id, event, new_price)
+            VALUES (NEW.id, NEW.company_id, 'NEW_PRODUCT', NEW.base_price);
+        END IF;
+    ELSIF NEW.approval_status = 'APPROVED' AND NEW.base_price < OLD.base_price THEN
+        INSERT INTO product_feed_events (product_id, company_id, event, old_price, new_price)
+        VALUES (NEW.id, NEW.company_id, 'PRICE_DROP', OLD.base_price, NEW.base_price);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_log_product_feed_event ON products;
+CREATE TRIGGER trigger_log_product_feed_event
+AFTER UPDATE OF approval_status, base_price ON products
+FOR EACH ROW
+EXECUTE FUNCTION log_product_feed_event();
+
+
+-- a variant without stock tracking (NULL stock_quantity) counts as in
+-- stock while it is available
+CREATE OR REPLACE FUNCTION log_variant_feed_event()
+RETURNS TRIGGER AS $$
+DECLARE
+    prod_company_id UUID;
+BEGIN
+    SELECT company_id INTO prod_company_id
+    FROM products
+    WHERE id = NEW.product_id AND approval_status = 'APPROVED';
+
+    IF prod_company_id IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    IF NEW.price < OLD.price THEN
+        INSERT INTO product_feed_events (product_id, company_id, variant_id, event, old_price, new_price)
+        VALUES (NEW.product_id, prod_company_id, NEW.id, 'PRICE_DROP', OLD.price, NEW.price);
+    END IF;
+
+    IF NOT (OLD.is_available AND COALESCE(OLD.stock_quantity, 1) > 0)
+        AND (NEW.is_available AND COALESCE(NEW.stock_quantity, 1) > 0) THEN
+        INSERT INTO product_feed_events (product_id, company_id, variant_id, event, new_price)
+        VALUES (NEW.product_id, prod_company_id, NEW.id, 'BACK_IN_STOCK', NEW.price);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_log_variant_feed_event ON product_variants;
+CREATE TRIGGER trigger_log_variant_feed_event
+AFTER UPDATE OF price, stock_quantity, is_available ON product_variants
+FOR EACH ROW
+EXECUTE FUNCTION log_variant_feed_event();
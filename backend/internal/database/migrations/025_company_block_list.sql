@@ -0,0 +1,54 @@
+-- UP: 00025_company_block_list
+
+-- =============================================
+-- BLOCK LIST
+-- =============================================
+
+-- company_blocked_users started out for blocking followers, owners can now
+-- block any user and note why
+ALTER TABLE company_blocked_users
+    ADD COLUMN reason TEXT;
+
+CREATE INDEX idx_company_blocked_users_company ON company_blocked_users(company_id, created_at DESC);
+
+
+-- =============================================
+-- FOLLOW GUARD
+-- =============================================
+
+-- blocking deletes the follow row, update_company_follower_count takes the
+-- follower off the count. this keeps the user from following again.
+CREATE OR REPLACE FUNCTION check_company_followable()
+RETURNS TRIGGER AS $$
+DECLARE
+    comp_status approval_status;
+    comp_active BOOLEAN;
+    comp_hidden_at TIMESTAMPTZ;
+BEGIN
+    SELECT approval_status, is_active, hidden_at
+    INTO comp_status, comp_active, comp_hidden_at
+    FROM companies
+    WHERE id = NEW.company_id;
+
+    IF comp_status != 'APPROVED' THEN
+        RAISE EXCEPTION 'Cannot follow unapproved company';
+    END IF;
+
+    IF NOT comp_active THEN
+        RAISE EXCEPTION 'Cannot follow inactive company';
+    END IF;
+
+    IF comp_hidden_at IS NOT NULL THEN
+        RAISE EXCEPTION 'Cannot follow company under review';
+    END IF;
+
+    IF EXISTS (
+        SELECT 1 FROM company_blocked_users
+        WHERE company_id = NEW.company_id AND user_id = NEW.user_id
+    ) THEN
+        RAISE EXCEPTION 'Cannot follow this company';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
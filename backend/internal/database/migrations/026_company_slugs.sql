@@ -0,0 +1,48 @@
+-- UP: 00026_company_slugs
+
+-- =============================================
+-- COMPANY SLUGS
+-- =============================================
+
+ALTER TABLE companies
+    ADD COLUMN slug TEXT,
+    ADD COLUMN banner_url TEXT;
+
+-- existing companies get a slug from their name, the id prefix keeps
+-- duplicate names apart
+UPDATE companies
+SET slug = left(
+    trim(both '-' from regexp_replace(lower(name), '[^a-z0-9]+', '-', 'g')),
+    50
+);
+
+UPDATE companies c
+SET slug = CASE WHEN c.slug = '' THEN 'company' ELSE c.slug END || '-' || left(c.id::text, 8)
+WHERE c.slug = ''
+    OR length(c.slug) < 3
+    OR EXISTS (
+        SELECT 1 FROM companies o
+        WHERE o.slug = c.slug AND o.id <> c.id
+    );
+
+ALTER TABLE companies
+    ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX idx_companies_slug ON companies(slug);
+
+COMMENT ON COLUMN companies.slug IS 'Public vanity identifier, /companies/by-slug/:slug';
+
+
+-- =============================================
+-- SLUG HISTORY
+-- =============================================
+
+-- slugs a company used before, looked up to redirect old links. a slug
+-- stays reserved for its company until that company takes it back.
+CREATE TABLE company_slug_history (
+    slug TEXT PRIMARY KEY,
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    retired_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_company_slug_history_company ON company_slug_history(company_id);
@@ -0,0 +1,54 @@
+-- UP: 00027_company_images
+
+-- =============================================
+-- COMPANY IMAGES
+-- =============================================
+
+-- logos and banners are uploaded straight to S3 with a presigned URL and
+-- only shown on the company once the server has checked the object.
+--   UPLOADING  URL issued, object not checked yet
+--   PENDING    logo of an approved company waiting on an admin
+--   ACTIVE     the image the company currently shows
+--   REJECTED   turned down by an admin, object deleted
+--   REPLACED   superseded by a newer image, object deleted
+CREATE TABLE company_images (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    uploaded_by_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    kind TEXT NOT NULL CHECK (kind IN ('LOGO', 'BANNER')),
+    status TEXT NOT NULL DEFAULT 'UPLOADING'
+        CHECK (status IN ('UPLOADING', 'PENDING', 'ACTIVE', 'REJECTED', 'REPLACED')),
+
+    s3_key TEXT NOT NULL UNIQUE,
+    image_url TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    -- set once the upload is confirmed
+    size_bytes BIGINT,
+    width INT,
+    height INT,
+
+    reviewed_by_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    rejection_reason TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_company_images_company ON company_images(company_id, kind, created_at DESC);
+
+-- one live image and at most one waiting for review per kind
+CREATE UNIQUE INDEX idx_company_images_one_active
+ON company_images(company_id, kind)
+WHERE status = 'ACTIVE';
+
+CREATE UNIQUE INDEX idx_company_images_one_pending
+ON company_images(company_id, kind)
+WHERE status = 'PENDING';
+
+CREATE INDEX idx_company_images_review_queue
+ON company_images(created_at)
+WHERE status = 'PENDING';
+
+COMMENT ON TABLE company_images IS 'Logo and banner uploads, companies.logo_url and banner_url point at the ACTIVE one';
@@ -0,0 +1,33 @@
+-- UP: 00028_company_service_areas
+
+-- =============================================
+-- COMPANY SERVICE AREAS
+-- =============================================
+
+-- where a company delivers. a pincode matches an area when it is the
+-- exact PINCODE, starts with a PINCODE_PREFIX, or falls in the STATE
+-- (stored as its GST state code, resolved from the pincode in the app).
+-- a company without any rows delivers everywhere.
+CREATE TABLE company_service_areas (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+
+    area_type TEXT NOT NULL CHECK (area_type IN ('PINCODE', 'PINCODE_PREFIX', 'STATE')),
+    value TEXT NOT NULL,
+    -- state name as the seller entered it
+    label TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    UNIQUE (company_id, area_type, value),
+    CHECK (
+        (area_type = 'PINCODE' AND value ~ '^[1-9][0-9]{5}$')
+        OR (area_type = 'PINCODE_PREFIX' AND value ~ '^[1-9][0-9]{0,4}$')
+        OR (area_type = 'STATE' AND value ~ '^[0-9]{2}$')
+    )
+);
+
+-- which companies serve a pincode, used by the deliversTo product filter
+CREATE INDEX idx_company_service_areas_lookup ON company_service_areas(area_type, value, company_id);
+
+COMMENT ON TABLE company_service_areas IS 'Pincodes, pincode prefixes and states a company delivers to';
@@ -0,0 +1,36 @@
+-- UP: 00029_business_hours_vacation
+
+-- =============================================
+-- BUSINESS HOURS AND VACATION
+-- =============================================
+
+-- weekly opening hours, {"MON": [{"open": "09:00", "close": "18:00"}], ...}
+-- in India Standard Time. a day without an entry is closed, an empty
+-- object means no hours were given.
+ALTER TABLE companies
+    ADD COLUMN business_hours JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN vacation_started_at TIMESTAMPTZ,
+    ADD COLUMN vacation_until TIMESTAMPTZ,
+    ADD COLUMN vacation_message TEXT,
+    ADD CONSTRAINT companies_vacation_check
+        CHECK ((vacation_started_at IS NULL) = (vacation_until IS NULL));
+
+-- the sweep that ends vacations looks these up
+CREATE INDEX idx_companies_vacation_until ON companies(vacation_until)
+WHERE vacation_until IS NOT NULL;
+
+COMMENT ON COLUMN companies.vacation_until IS 'Products show as unavailable until then, approval state is left alone';
+
+
+-- =============================================
+-- HOLIDAYS
+-- =============================================
+
+CREATE TABLE company_holidays (
+    company_id UUID NOT NULL REFERENCES companies(id) ON DELETE CASCADE,
+    holiday_date DATE NOT NULL,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (company_id, holiday_date)
+);
@@ -0,0 +1,21 @@
+-- UP: 00030_kyc_document_status
+
+-- =============================================
+-- KYC UPLOAD CONFIRMATION
+-- =============================================
+
+-- the row is created with the presigned URL, it only counts as a document
+-- once the owner confirms and the object is found in S3.
+--   UPLOADING  URL issued, object not checked yet
+--   UPLOADED   object checked, shown to reviewers
+ALTER TABLE company_kyc_documents
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'UPLOADED'
+        CHECK (status IN ('UPLOADING', 'UPLOADED')),
+    ADD COLUMN size_bytes BIGINT;
+
+-- rows from before this migration keep counting, new ones start unconfirmed
+ALTER TABLE company_kyc_documents ALTER COLUMN status SET DEFAULT 'UPLOADING';
+
+DROP INDEX idx_company_kyc_documents_company;
+CREATE INDEX idx_company_kyc_documents_company ON company_kyc_documents(company_id, created_at DESC)
+WHERE status = 'UPLOADED';
@@ -2,10 +2,15 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/audit"
 	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/model/moderation"
 	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/C0deNe0/agromart/internal/repository"
 	"github.com/C0deNe0/agromart/internal/service"
 	"github.com/labstack/echo/v4"
 )
@@ -14,15 +19,41 @@ type AdminHandler struct {
 	Handler
 	companyService *service.CompanyService
 	productService *service.ProductService
+	kycService     *service.CompanyKYCService
+	moderation     *service.ModerationService
+	audit          *service.AuditService
+	suspension     *service.SuspensionService
+	images         *service.CompanyImageService
 }
 
-func NewAdminHandler(companyService *service.CompanyService, productService *service.ProductService) *AdminHandler {
+func NewAdminHandler(companyService *service.CompanyService, productService *service.ProductService, kycService *service.CompanyKYCService, moderationService *service.ModerationService, auditService *service.AuditService, suspensionService *service.SuspensionService, imageService *service.CompanyImageService) *AdminHandler {
 	return &AdminHandler{
 		companyService: companyService,
 		productService: productService,
+		kycService:     kycService,
+		moderation:     moderationService,
+		audit:          auditService,
+		suspension:     suspensionService,
+		images:         imageService,
 	}
 }
 
+// GetCompanyReview returns the company with its tax id checks and KYC
+// documents, this is what the admin looks at before ApproveCompany.
+func (h *AdminHandler) GetCompanyReview() echo.HandlerFunc {
+	return Handle(
+		&company.GetCompanyReviewRequest{},
+		func(c echo.Context, req *company.GetCompanyReviewRequest) (*company.CompanyReviewResponse, error) {
+			review, err := h.kycService.GetReview(c.Request().Context(), req.CompanyID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return review, nil
+		},
+		http.StatusOK,
+	)
+}
+
 func (h *AdminHandler) ApproveCompany() echo.HandlerFunc {
 	return Handle(
 		&company.ApproveCompanyRequest{},
@@ -61,6 +92,34 @@ func (h *AdminHandler) RejectCompany() echo.HandlerFunc {
 	)
 }
 
+func (h *AdminHandler) BulkApproveCompanies() echo.HandlerFunc {
+	return Handle(
+		&moderation.BulkApproveRequest{},
+		func(c echo.Context, req *moderation.BulkApproveRequest) (*moderation.BulkResult, error) {
+			result, err := h.companyService.BulkApprove(c.Request().Context(), req.IDs, middleware.GetUserID(c), req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) BulkRejectCompanies() echo.HandlerFunc {
+	return Handle(
+		&moderation.BulkRejectRequest{},
+		func(c echo.Context, req *moderation.BulkRejectRequest) (*moderation.BulkResult, error) {
+			result, err := h.companyService.BulkReject(c.Request().Context(), req.IDs, middleware.GetUserID(c), req.Reason, req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
 func (h *AdminHandler) CountPendingCompanyApprovals() echo.HandlerFunc {
 	return Handle(
 		&company.CountPendingApprovalsRequest{},
@@ -120,6 +179,134 @@ func (h *AdminHandler) RejectProduct() echo.HandlerFunc {
 	)
 }
 
+func (h *AdminHandler) BulkApproveProducts() echo.HandlerFunc {
+	return Handle(
+		&moderation.BulkApproveRequest{},
+		func(c echo.Context, req *moderation.BulkApproveRequest) (*moderation.BulkResult, error) {
+			result, err := h.productService.BulkApprove(c.Request().Context(), req.IDs, middleware.GetUserID(c), req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) BulkRejectProducts() echo.HandlerFunc {
+	return Handle(
+		&moderation.BulkRejectRequest{},
+		func(c echo.Context, req *moderation.BulkRejectRequest) (*moderation.BulkResult, error) {
+			result, err := h.productService.BulkReject(c.Request().Context(), req.IDs, middleware.GetUserID(c), req.Reason, req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) ListPendingRevisions() echo.HandlerFunc {
+	return Handle(
+		&product.ListPendingRevisionsRequest{},
+		func(c echo.Context, req *product.ListPendingRevisionsRequest) (interface{}, error) {
+			result, err := h.productService.ListPendingRevisions(c.Request().Context(), req.Page, req.Limit)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) ApproveRevision() echo.HandlerFunc {
+	return Handle(
+		&product.ApproveRevisionRequest{},
+		func(c echo.Context, req *product.ApproveRevisionRequest) (interface{}, error) {
+			err := h.productService.ApproveRevision(c.Request().Context(), req.RevisionID, middleware.GetUserID(c), req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Revision approved and applied successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) RejectRevision() echo.HandlerFunc {
+	return Handle(
+		&product.RejectRevisionRequest{},
+		func(c echo.Context, req *product.RejectRevisionRequest) (interface{}, error) {
+			err := h.productService.RejectRevision(c.Request().Context(), req.RevisionID, middleware.GetUserID(c), req.Reason, req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Revision rejected successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+// =============================================
+// COMPANY LOGO REVIEW
+// =============================================
+
+func (h *AdminHandler) ListPendingCompanyImages() echo.HandlerFunc {
+	return Handle(
+		&company.ListPendingCompanyImagesRequest{},
+		func(c echo.Context, req *company.ListPendingCompanyImagesRequest) (interface{}, error) {
+			result, err := h.images.ListPending(c.Request().Context(), req.Page, req.Limit)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) ApproveCompanyImage() echo.HandlerFunc {
+	return Handle(
+		&company.ApproveCompanyImageRequest{},
+		func(c echo.Context, req *company.ApproveCompanyImageRequest) (interface{}, error) {
+			err := h.images.Approve(c.Request().Context(), middleware.GetUserID(c), req.ImageID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Image approved and applied successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) RejectCompanyImage() echo.HandlerFunc {
+	return Handle(
+		&company.RejectCompanyImageRequest{},
+		func(c echo.Context, req *company.RejectCompanyImageRequest) (interface{}, error) {
+			err := h.images.Reject(c.Request().Context(), middleware.GetUserID(c), req.ImageID, req.Reason)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Image rejected successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
 func (h *AdminHandler) CountPendingProducts() echo.HandlerFunc {
 	return Handle(
 		&product.CountPendingApprovalsRequest{},
@@ -136,3 +323,249 @@ func (h *AdminHandler) CountPendingProducts() echo.HandlerFunc {
 		http.StatusOK,
 	)
 }
+
+// =============================================
+// MODERATION QUEUE
+// =============================================
+
+func (h *AdminHandler) CompanyQueue() echo.HandlerFunc {
+	return Handle(
+		&moderation.ListQueueRequest{},
+		func(c echo.Context, req *moderation.ListQueueRequest) (*moderation.QueueResponse[moderation.CompanyQueueItem], error) {
+			result, err := h.moderation.ListCompanyQueue(c.Request().Context(), queueFilter(req))
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) ProductQueue() echo.HandlerFunc {
+	return Handle(
+		&moderation.ListQueueRequest{},
+		func(c echo.Context, req *moderation.ListQueueRequest) (*moderation.QueueResponse[moderation.ProductQueueItem], error) {
+			result, err := h.moderation.ListProductQueue(c.Request().Context(), queueFilter(req))
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) ClaimCompany() echo.HandlerFunc {
+	return h.claim(moderation.EntityCompany)
+}
+
+func (h *AdminHandler) ReleaseCompany() echo.HandlerFunc {
+	return h.release(moderation.EntityCompany)
+}
+
+func (h *AdminHandler) AssignCompany() echo.HandlerFunc {
+	return h.assign(moderation.EntityCompany)
+}
+
+func (h *AdminHandler) ClaimProduct() echo.HandlerFunc {
+	return h.claim(moderation.EntityProduct)
+}
+
+func (h *AdminHandler) ReleaseProduct() echo.HandlerFunc {
+	return h.release(moderation.EntityProduct)
+}
+
+func (h *AdminHandler) AssignProduct() echo.HandlerFunc {
+	return h.assign(moderation.EntityProduct)
+}
+
+func (h *AdminHandler) claim(entityType moderation.EntityType) echo.HandlerFunc {
+	return Handle(
+		&moderation.ClaimRequest{},
+		func(c echo.Context, req *moderation.ClaimRequest) (*moderation.Claim, error) {
+			claim, err := h.moderation.Claim(
+				c.Request().Context(),
+				entityType,
+				req.ID,
+				middleware.GetUserID(c),
+				time.Duration(req.TTLMinutes)*time.Minute,
+			)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusConflict, err.Error())
+			}
+			return claim, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) assign(entityType moderation.EntityType) echo.HandlerFunc {
+	return Handle(
+		&moderation.AssignRequest{},
+		func(c echo.Context, req *moderation.AssignRequest) (*moderation.Claim, error) {
+			claim, err := h.moderation.Assign(
+				c.Request().Context(),
+				entityType,
+				req.ID,
+				req.ReviewerID,
+				middleware.GetUserID(c),
+				time.Duration(req.TTLMinutes)*time.Minute,
+			)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return claim, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) release(entityType moderation.EntityType) echo.HandlerFunc {
+	return HandleNoContent(
+		&moderation.ReleaseClaimRequest{},
+		func(c echo.Context, req *moderation.ReleaseClaimRequest) error {
+			err := h.moderation.Release(c.Request().Context(), entityType, req.ID, middleware.GetUserID(c))
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return nil
+		},
+		http.StatusNoContent,
+	)
+}
+
+// =============================================
+// SUSPENSIONS
+// =============================================
+
+func (h *AdminHandler) SuspendCompany() echo.HandlerFunc {
+	return Handle(
+		&company.SuspendCompanyRequest{},
+		func(c echo.Context, req *company.SuspendCompanyRequest) (interface{}, error) {
+			adminID := middleware.GetUserID(c)
+
+			err := h.suspension.SuspendCompany(c.Request().Context(), req.CompanyID, adminID, req.Reason, req.Until, req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Company suspended successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) ReinstateCompany() echo.HandlerFunc {
+	return Handle(
+		&company.ReinstateCompanyRequest{},
+		func(c echo.Context, req *company.ReinstateCompanyRequest) (interface{}, error) {
+			adminID := middleware.GetUserID(c)
+
+			err := h.suspension.ReinstateCompany(c.Request().Context(), req.CompanyID, adminID, req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Company reinstated successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) SuspendProduct() echo.HandlerFunc {
+	return Handle(
+		&product.SuspendProductRequest{},
+		func(c echo.Context, req *product.SuspendProductRequest) (interface{}, error) {
+			adminID := middleware.GetUserID(c)
+
+			err := h.suspension.SuspendProduct(c.Request().Context(), req.ProductID, adminID, req.Reason, req.Until, req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Product suspended successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AdminHandler) ReinstateProduct() echo.HandlerFunc {
+	return Handle(
+		&product.ReinstateProductRequest{},
+		func(c echo.Context, req *product.ReinstateProductRequest) (interface{}, error) {
+			adminID := middleware.GetUserID(c)
+
+			err := h.suspension.ReinstateProduct(c.Request().Context(), req.ProductID, adminID, req.Notes)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Product reinstated successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+// ListAuditLog searches the audit log. actorId also matches the admin
+// behind impersonated actions.
+func (h *AdminHandler) ListAuditLog() echo.HandlerFunc {
+	return Handle(
+		&audit.ListAuditLogRequest{},
+		func(c echo.Context, req *audit.ListAuditLogRequest) (*model.PaginatedResponse[audit.Entry], error) {
+			if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, "from must be before to")
+			}
+			result, err := h.audit.List(c.Request().Context(), repository.AuditLogFilter{
+				ActorID:    req.ActorID,
+				EntityType: req.EntityType,
+				EntityID:   req.EntityID,
+				Action:     req.Action,
+				RequestID:  req.RequestID,
+				From:       req.From,
+				To:         req.To,
+				Page:       req.Page,
+				Limit:      req.Limit,
+			})
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+// queueFilter turns the age bounds (hours) into submitted_at cutoffs.
+func queueFilter(req *moderation.ListQueueRequest) repository.ModerationQueueFilter {
+	filter := repository.ModerationQueueFilter{
+		CategoryID:       req.CategoryID,
+		Flagged:          req.Flagged,
+		State:            req.State,
+		MinResubmissions: req.MinResubmissions,
+		MaxResubmissions: req.MaxResubmissions,
+		ReviewerID:       req.ReviewerID,
+		Unclaimed:        req.Unclaimed,
+		Page:             req.Page,
+		Limit:            req.Limit,
+	}
+
+	now := time.Now()
+	if req.MinAgeHours != nil {
+		before := now.Add(-time.Duration(*req.MinAgeHours) * time.Hour)
+		filter.SubmittedBefore = &before
+	}
+	if req.MaxAgeHours != nil {
+		after := now.Add(-time.Duration(*req.MaxAgeHours) * time.Hour)
+		filter.SubmittedAfter = &after
+	}
+	return filter
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model/analytics"
+	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type AnalyticsHandler struct {
+	Handler
+	analyticsService *service.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService *service.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+func (h *AnalyticsHandler) GetCompanyAnalytics() echo.HandlerFunc {
+	return Handle(
+		&analytics.GetCompanyAnalyticsRequest{},
+		func(c echo.Context, req *analytics.GetCompanyAnalyticsRequest) (*analytics.CompanyAnalyticsResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			result, err := h.analyticsService.Company(c.Request().Context(), userID, req.CompanyID, req.From, req.To, req.GroupBy)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AnalyticsHandler) GetProductAnalytics() echo.HandlerFunc {
+	return Handle(
+		&analytics.GetProductAnalyticsRequest{},
+		func(c echo.Context, req *analytics.GetProductAnalyticsRequest) (*analytics.ProductAnalyticsResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			result, err := h.analyticsService.Product(c.Request().Context(), userID, req.CompanyID, req.ProductID, req.From, req.To, req.GroupBy)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
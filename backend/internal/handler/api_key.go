@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyHandler struct {
+	Handler
+	apiKeyService *service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+func (h *APIKeyHandler) CreateAPIKey() echo.HandlerFunc {
+	return Handle(
+		&company.CreateAPIKeyRequest{},
+		func(c echo.Context, req *company.CreateAPIKeyRequest) (*company.CreatedAPIKeyResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			created, err := h.apiKeyService.Create(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return created, nil
+		},
+		http.StatusCreated,
+	)
+}
+
+func (h *APIKeyHandler) ListAPIKeys() echo.HandlerFunc {
+	return Handle(
+		&company.ListAPIKeysRequest{},
+		func(c echo.Context, req *company.ListAPIKeysRequest) ([]company.APIKey, error) {
+			userID := middleware.GetUserID(c)
+
+			keys, err := h.apiKeyService.List(c.Request().Context(), userID, req.CompanyID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return keys, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *APIKeyHandler) RotateAPIKey() echo.HandlerFunc {
+	return Handle(
+		&company.RotateAPIKeyRequest{},
+		func(c echo.Context, req *company.RotateAPIKeyRequest) (*company.CreatedAPIKeyResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			rotated, err := h.apiKeyService.Rotate(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return rotated, nil
+		},
+		http.StatusCreated,
+	)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey() echo.HandlerFunc {
+	return HandleNoContent(
+		&company.RevokeAPIKeyRequest{},
+		func(c echo.Context, req *company.RevokeAPIKeyRequest) error {
+			userID := middleware.GetUserID(c)
+
+			if err := h.apiKeyService.Revoke(c.Request().Context(), userID, req.CompanyID, req.KeyID); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return nil
+		},
+		http.StatusNoContent,
+	)
+}
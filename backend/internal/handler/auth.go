@@ -1,9 +1,10 @@
 package handler
 
 import (
+	"crypto/subtle"
 	"net/http"
 
-	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/middleware"
 	"github.com/C0deNe0/agromart/internal/model/auth"
 	"github.com/C0deNe0/agromart/internal/model/user"
 	"github.com/C0deNe0/agromart/internal/service"
@@ -89,25 +90,32 @@ func (h *AuthHandler) LoginWithGoogleIDToken() echo.HandlerFunc {
 	return Handle(
 		&auth.GoogleIDTokenRequest{},
 		func(c echo.Context, req *auth.GoogleIDTokenRequest) (*user.AuthResponse, error) {
-
-			googleUserClaims, err := utils.VerifyGoogleIDToken(
+			resp, err := h.authService.LoginWithOIDC(
 				c.Request().Context(),
+				service.AuthProviderGoogle,
 				req.IDToken,
 			)
-			if err != nil || googleUserClaims.Email == "" {
-				return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid Google ID Token"+err.Error())
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid Google ID Token: "+err.Error())
 			}
 
-			// 2. Call the service layer to handle login/registration using the verified claims
-			resp, err := h.authService.LoginWithGoogle(
+			return resp, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AuthHandler) LoginWithOIDC() echo.HandlerFunc {
+	return Handle(
+		&auth.OIDCLoginRequest{},
+		func(c echo.Context, req *auth.OIDCLoginRequest) (*user.AuthResponse, error) {
+			resp, err := h.authService.LoginWithOIDC(
 				c.Request().Context(),
-				googleUserClaims.Sub,
-				googleUserClaims.Email,
-				googleUserClaims.Name,
-				&googleUserClaims.Picture,
+				req.Provider,
+				req.IDToken,
 			)
 			if err != nil {
-				return nil, echo.NewHTTPError(http.StatusInternalServerError, "authentication failed")
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, err.Error())
 			}
 
 			return resp, nil
@@ -116,6 +124,62 @@ func (h *AuthHandler) LoginWithGoogleIDToken() echo.HandlerFunc {
 	)
 }
 
+// =============================================
+// ACCOUNT LINKING (PROFILE)
+// =============================================
+
+func (h *AuthHandler) ListAuthMethods() echo.HandlerFunc {
+	return Handle(
+		&auth.ListAuthMethodsRequest{},
+		func(c echo.Context, req *auth.ListAuthMethodsRequest) ([]auth.AuthMethodResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			methods, err := h.authService.ListAuthMethods(c.Request().Context(), userID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return methods, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AuthHandler) LinkProvider() echo.HandlerFunc {
+	return Handle(
+		&auth.OIDCLoginRequest{},
+		func(c echo.Context, req *auth.OIDCLoginRequest) (interface{}, error) {
+			userID := middleware.GetUserID(c)
+
+			if err := h.authService.LinkOIDC(c.Request().Context(), userID, req.Provider, req.IDToken); err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Account linked successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *AuthHandler) UnlinkProvider() echo.HandlerFunc {
+	return Handle(
+		&auth.UnlinkProviderRequest{},
+		func(c echo.Context, req *auth.UnlinkProviderRequest) (interface{}, error) {
+			userID := middleware.GetUserID(c)
+
+			if err := h.authService.UnlinkProvider(c.Request().Context(), userID, req.Provider); err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Account unlinked successfully",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
 //REGISTER----------
 
 // HTTP → Handler → AuthService
@@ -132,90 +196,170 @@ func (h *AuthHandler) LoginWithGoogleIDToken() echo.HandlerFunc {
 //      → TokenManager.Generate
 //      → Response
 
-// GOOGLE AUTH
+// GOOGLE AUTH (WEB)
+// HTTP → GoogleLogin (state, nonce, PKCE cookies) → Google consent
+//      → GoogleCallback → AuthService.CompleteGoogleWebLogin
+//      → OAuthWebClient.Exchange (code + verifier)
+//      → OIDCVerifier.Verify + nonce check
+//      → refresh token in HttpOnly cookie
+
+// GOOGLE AUTH (MOBILE)
 // HTTP → Handler → AuthService
-//      → GoogleOAuth.Exchange
-//      → FetchGoogleUser
-//      → AuthService.LoginWithGoogle
+//      → OIDCVerifier.Verify (per provider JWKS)
+//      → AuthService.LoginWithOIDC
 //      → TokenManager.Generate
 //      → Response
 
-// func (h *AuthHandler) GoogleLogin() echo.HandlerFunc {
-// 	return func(c echo.Context) error {
-// 		state := uuid.New().String()
-
-// 		//store state in HTTP only cookie for 5 min
-
-// 		c.SetCookie(&http.Cookie{
-// 			Name:     "oauth_state",
-// 			Value:    state,
-// 			Path:     "/",
-// 			HttpOnly: true,
-// 			Secure:   true,
-// 			MaxAge:   300,
-// 			// Expires:  time.Now().Add(5 * time.Minute),
-// 			SameSite: http.SameSiteLaxMode,
-// 		})
-
-// 		return c.Redirect(
-// 			http.StatusTemporaryRedirect,
-// 			h.googleOAuth.AuthURL(state),
-// 		)
-// 	}
-// }
-
-// func (h *AuthHandler) GoogleCallback() echo.HandlerFunc {
-// 	return func(c echo.Context) error {
-// 		state := c.QueryParam("state")
-// 		if state == "" {
-// 			return echo.NewHTTPError(http.StatusBadRequest, "state is required")
-// 		}
-// 		code := c.QueryParam("code")
-// 		if code == "" {
-// 			return echo.NewHTTPError(http.StatusBadRequest, "code is required")
-// 		}
-
-// 		//validate state
-// 		cookie, err := c.Cookie("oauth_state")
-// 		if err != nil {
-// 			return echo.NewHTTPError(http.StatusBadRequest, "state is required")
-// 		}
-// 		if cookie.Value != state {
-// 			return echo.NewHTTPError(http.StatusBadRequest, "state does not match")
-// 		}
-
-// 		//clearing after use
-// 		c.SetCookie(&http.Cookie{
-// 			Name:     "oauth_state",
-// 			Value:    "",
-// 			Path:     "/",
-// 			HttpOnly: true,
-// 			Secure:   true,
-// 			MaxAge:   -1,
-// 			SameSite: http.SameSiteLaxMode,
-// 		})
-
-// 		token, err := h.googleOAuth.Exchange(c.Request().Context(), code)
-// 		if err != nil {
-// 			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
-// 		}
-
-// 		googleUser, err := utils.FetchGoogleUser(token.AccessToken)
-// 		if err != nil {
-// 			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
-// 		}
-
-// 		resp, err := h.authService.LoginWithGoogle(c.Request().Context(),
-// 			googleUser.Sub,
-// 			googleUser.Email,
-// 			googleUser.Name,
-// 			&googleUser.Picture,
-// 		)
-// 		if err != nil {
-// 			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
-// 		}
-
-// 		return c.JSON(http.StatusOK, resp)
-
-// 	}
-// }
+// =============================================
+// WEB OAUTH (AUTHORIZATION CODE + PKCE)
+// =============================================
+
+const (
+	oauthStateCookie    = "oauth_state"
+	oauthNonceCookie    = "oauth_nonce"
+	oauthVerifierCookie = "oauth_verifier"
+	refreshTokenCookie  = "refresh_token"
+
+	oauthCookieMaxAge   = 300
+	refreshCookiePath   = "/api/v1/auth"
+	oauthFlowCookiePath = "/api/v1/auth/google"
+)
+
+func (h *AuthHandler) GoogleLogin() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		session, err := h.authService.StartGoogleWebLogin()
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "failed to start login")
+		}
+
+		//state, nonce and PKCE verifier live in HTTP only cookies for 5 min
+		setFlowCookie(c, oauthStateCookie, session.State, oauthCookieMaxAge)
+		setFlowCookie(c, oauthNonceCookie, session.Nonce, oauthCookieMaxAge)
+		setFlowCookie(c, oauthVerifierCookie, session.Verifier, oauthCookieMaxAge)
+
+		return c.Redirect(http.StatusTemporaryRedirect, session.AuthURL)
+	}
+}
+
+func (h *AuthHandler) GoogleCallback() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if errParam := c.QueryParam("error"); errParam != "" {
+			return echo.NewHTTPError(http.StatusUnauthorized, "google login failed: "+errParam)
+		}
+
+		state := c.QueryParam("state")
+		if state == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "state is required")
+		}
+		code := c.QueryParam("code")
+		if code == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "code is required")
+		}
+
+		//validate state
+		stateCookie, err := c.Cookie(oauthStateCookie)
+		if err != nil || subtle.ConstantTimeCompare([]byte(stateCookie.Value), []byte(state)) != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "state does not match")
+		}
+		nonceCookie, err := c.Cookie(oauthNonceCookie)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "nonce is missing")
+		}
+		verifierCookie, err := c.Cookie(oauthVerifierCookie)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "code verifier is missing")
+		}
+
+		//clearing after use
+		setFlowCookie(c, oauthStateCookie, "", -1)
+		setFlowCookie(c, oauthNonceCookie, "", -1)
+		setFlowCookie(c, oauthVerifierCookie, "", -1)
+
+		resp, err := h.authService.CompleteGoogleWebLogin(
+			c.Request().Context(),
+			code,
+			verifierCookie.Value,
+			nonceCookie.Value,
+		)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+
+		h.setRefreshCookie(c, resp.RefreshToken)
+
+		if redirect := h.authService.WebPostLoginRedirect(); redirect != "" {
+			return c.Redirect(http.StatusSeeOther, redirect)
+		}
+
+		return c.JSON(http.StatusOK, user.ToWebAuthResponse(resp))
+	}
+}
+
+// WebRefresh rotates the refresh token kept in the HttpOnly cookie and hands
+// back a fresh access token. The refresh token itself never reaches JS.
+func (h *AuthHandler) WebRefresh() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cookie, err := c.Cookie(refreshTokenCookie)
+		if err != nil || cookie.Value == "" {
+			return echo.NewHTTPError(http.StatusUnauthorized, "missing refresh token")
+		}
+
+		resp, err := h.authService.Refresh(c.Request().Context(), cookie.Value)
+		if err != nil {
+			h.clearRefreshCookie(c)
+			return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired refresh token")
+		}
+
+		h.setRefreshCookie(c, resp.RefreshToken)
+		return c.JSON(http.StatusOK, user.ToWebAuthResponse(resp))
+	}
+}
+
+func (h *AuthHandler) WebLogout() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if cookie, err := c.Cookie(refreshTokenCookie); err == nil && cookie.Value != "" {
+			// already revoked or expired tokens are fine here
+			_ = h.authService.Logout(c.Request().Context(), cookie.Value)
+		}
+		h.clearRefreshCookie(c)
+		return c.JSON(http.StatusOK, map[string]string{
+			"message": "logout successful",
+		})
+	}
+}
+
+func setFlowCookie(c echo.Context, name, value string, maxAge int) {
+	c.SetCookie(&http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     oauthFlowCookiePath,
+		HttpOnly: true,
+		Secure:   true,
+		MaxAge:   maxAge,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (h *AuthHandler) setRefreshCookie(c echo.Context, token string) {
+	c.SetCookie(&http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    token,
+		Path:     refreshCookiePath,
+		HttpOnly: true,
+		Secure:   true,
+		MaxAge:   int(h.authService.TokenManager.RefreshTTL.Seconds()),
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func (h *AuthHandler) clearRefreshCookie(c echo.Context) {
+	c.SetCookie(&http.Cookie{
+		Name:     refreshTokenCookie,
+		Value:    "",
+		Path:     refreshCookiePath,
+		HttpOnly: true,
+		Secure:   true,
+		MaxAge:   -1,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
@@ -2,23 +2,31 @@ package handler
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/C0deNe0/agromart/internal/middleware"
 	"github.com/C0deNe0/agromart/internal/model/company"
 	"github.com/C0deNe0/agromart/internal/repository"
 	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/C0deNe0/agromart/internal/validation"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type CompanyHandler struct {
 	Handler
-	companyService *service.CompanyService
+	companyService      *service.CompanyService
+	kycService          *service.CompanyKYCService
+	imageService        *service.CompanyImageService
+	availabilityService *service.AvailabilityService
 }
 
-func NewCompanyHandler(companyService *service.CompanyService) *CompanyHandler {
+func NewCompanyHandler(companyService *service.CompanyService, kycService *service.CompanyKYCService, imageService *service.CompanyImageService, availabilityService *service.AvailabilityService) *CompanyHandler {
 	return &CompanyHandler{
-		companyService: companyService,
+		companyService:      companyService,
+		kycService:          kycService,
+		imageService:        imageService,
+		availabilityService: availabilityService,
 	}
 }
 
@@ -30,7 +38,6 @@ func (h *CompanyHandler) CreateCompany() echo.HandlerFunc {
 			comp := company.Company{
 				Name:          req.Name,
 				Description:   req.Description,
-				LogoURL:       req.LogoURL,
 				BusinessEmail: req.BusinessEmail,
 				BusinessPhone: req.BusinessPhone,
 
@@ -44,6 +51,9 @@ func (h *CompanyHandler) CreateCompany() echo.HandlerFunc {
 			if req.ProductVisibility != nil {
 				comp.ProductVisibility = *req.ProductVisibility
 			}
+			if req.Slug != nil {
+				comp.Slug = *req.Slug
+			}
 
 			created, err := h.companyService.Create(c.Request().Context(), userID, comp)
 			if err != nil {
@@ -76,6 +86,53 @@ func (h *CompanyHandler) GetCompanyByID() echo.HandlerFunc {
 	)
 }
 
+// GetCompanyBySlug redirects earlier slugs to the current one. It does not
+// go through Handle since a redirect has no body.
+func (h *CompanyHandler) GetCompanyBySlug() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := &company.GetCompanyBySlugRequest{}
+		if err := validation.BindAndValidate(c, req); err != nil {
+			return err
+		}
+		var userID *uuid.UUID
+		if id := middleware.GetUserID(c); id != uuid.Nil {
+			userID = &id
+		}
+
+		comp, moved, err := h.companyService.GetBySlug(c.Request().Context(), req.Slug, userID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if moved {
+			return redirectToSlug(c, req.Slug, comp.Slug)
+		}
+		return c.JSON(http.StatusOK, comp)
+	}
+}
+
+func (h *CompanyHandler) UpdateSlug() echo.HandlerFunc {
+	return Handle(
+		&company.UpdateSlugRequest{},
+		func(c echo.Context, req *company.UpdateSlugRequest) (*company.CompanyResponse, error) {
+			updated, err := h.companyService.UpdateSlug(c.Request().Context(), middleware.GetUserID(c), req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return company.ToCompanyResponse(updated, nil), nil
+		},
+		http.StatusOK,
+	)
+}
+
+// redirectToSlug swaps the slug in the request path, keeping the rest of
+// the path and the query.
+func redirectToSlug(c echo.Context, oldSlug, newSlug string) error {
+	u := *c.Request().URL
+	u.Path = strings.Replace(u.Path, "/by-slug/"+oldSlug, "/by-slug/"+newSlug, 1)
+	u.RawPath = ""
+	return c.Redirect(http.StatusMovedPermanently, u.RequestURI())
+}
+
 func (h *CompanyHandler) ListCompanies() echo.HandlerFunc {
 	return Handle(
 		&company.ListCompanyQuery{},
@@ -152,9 +209,9 @@ func (h *CompanyHandler) GetApprovalHistory() echo.HandlerFunc {
 	return Handle(
 		&company.GetApprovalHistoryRequest{},
 		func(c echo.Context, req *company.GetApprovalHistoryRequest) (interface{}, error) {
-			history, err := h.companyService.GetApprovalHistory(c.Request().Context(), req.ID)
+			history, err := h.companyService.GetApprovalHistory(c.Request().Context(), middleware.GetUserID(c), middleware.IsAdmin(c), req.ID)
 			if err != nil {
-				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
 			}
 
 			return history, nil
@@ -173,13 +230,20 @@ func (h *CompanyHandler) FollowCompany() echo.HandlerFunc {
 		func(c echo.Context, req *company.FollowCompanyRequest) (interface{}, error) {
 			userID := middleware.GetUserID(c)
 
-			err := h.companyService.Follow(c.Request().Context(), req.CompanyID, userID)
+			follower, err := h.companyService.Follow(c.Request().Context(), req.CompanyID, userID)
 			if err != nil {
 				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
 			}
 
+			if !follower.IsAccepted() {
+				return map[string]string{
+					"message": "Follow request sent, waiting for the company to accept it",
+					"status":  string(follower.Status),
+				}, nil
+			}
 			return map[string]string{
 				"message": "Successfully followed company",
+				"status":  string(follower.Status),
 			}, nil
 		},
 		http.StatusOK,
@@ -254,10 +318,170 @@ func (h *CompanyHandler) ListFollowers() echo.HandlerFunc {
 	)
 }
 
+// =============================================
+// FOLLOWER MANAGEMENT (OWNER)
+// =============================================
+
+func (h *CompanyHandler) UpdateFollowerSettings() echo.HandlerFunc {
+	return Handle(
+		&company.UpdateFollowerSettingsRequest{},
+		func(c echo.Context, req *company.UpdateFollowerSettingsRequest) (*company.CompanyResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			updated, err := h.companyService.UpdateFollowerSettings(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return company.ToCompanyResponse(updated, nil), nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) ListFollowRequests() echo.HandlerFunc {
+	return Handle(
+		&company.ListFollowersQuery{},
+		func(c echo.Context, req *company.ListFollowersQuery) (interface{}, error) {
+			userID := middleware.GetUserID(c)
+
+			requests, err := h.companyService.ListFollowRequests(c.Request().Context(), userID, req.CompanyID, req.Page, req.Limit)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return requests, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) AcceptFollowRequest() echo.HandlerFunc {
+	return Handle(
+		&company.FollowerActionRequest{},
+		func(c echo.Context, req *company.FollowerActionRequest) (*company.CompanyFollower, error) {
+			userID := middleware.GetUserID(c)
+
+			follower, err := h.companyService.AcceptFollower(c.Request().Context(), userID, req.CompanyID, req.UserID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return follower, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) RejectFollowRequest() echo.HandlerFunc {
+	return Handle(
+		&company.FollowerActionRequest{},
+		func(c echo.Context, req *company.FollowerActionRequest) (interface{}, error) {
+			userID := middleware.GetUserID(c)
+
+			if err := h.companyService.RejectFollower(c.Request().Context(), userID, req.CompanyID, req.UserID); err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Follow request rejected",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) RemoveFollower() echo.HandlerFunc {
+	return Handle(
+		&company.FollowerActionRequest{},
+		func(c echo.Context, req *company.FollowerActionRequest) (interface{}, error) {
+			userID := middleware.GetUserID(c)
+
+			if err := h.companyService.RemoveFollower(c.Request().Context(), userID, req.CompanyID, req.UserID); err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "Follower removed",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+// =============================================
+// BLOCK LIST (OWNER)
+// =============================================
+
+func (h *CompanyHandler) BlockUser() echo.HandlerFunc {
+	return Handle(
+		&company.BlockUserRequest{},
+		func(c echo.Context, req *company.BlockUserRequest) (interface{}, error) {
+			userID := middleware.GetUserID(c)
+
+			if err := h.companyService.BlockUser(c.Request().Context(), userID, req); err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "User blocked",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) UnblockUser() echo.HandlerFunc {
+	return Handle(
+		&company.FollowerActionRequest{},
+		func(c echo.Context, req *company.FollowerActionRequest) (interface{}, error) {
+			userID := middleware.GetUserID(c)
+
+			if err := h.companyService.UnblockUser(c.Request().Context(), userID, req.CompanyID, req.UserID); err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return map[string]string{
+				"message": "User unblocked",
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) ListBlockedUsers() echo.HandlerFunc {
+	return Handle(
+		&company.ListFollowersQuery{},
+		func(c echo.Context, req *company.ListFollowersQuery) (interface{}, error) {
+			userID := middleware.GetUserID(c)
+
+			blocked, err := h.companyService.ListBlockedUsers(c.Request().Context(), userID, req.CompanyID, req.Page, req.Limit)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return blocked, nil
+		},
+		http.StatusOK,
+	)
+}
+
 // =============================================
 // LIST FOLLOWED COMPANIES (MY FOLLOWED)
 // =============================================
 
+// ListMyCompanies returns the caller's companies, any of them can be sent
+// as the X-Company-ID header to act for it.
+func (h *CompanyHandler) ListMyCompanies() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		companies, err := h.companyService.ListMine(c.Request().Context(), middleware.GetUserID(c))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		return c.JSON(http.StatusOK, companies)
+	}
+}
+
 func (h *CompanyHandler) ListFollowedCompanies() echo.HandlerFunc {
 	return Handle(
 		&company.ListFollowedCompaniesQuery{},
@@ -279,3 +503,224 @@ func (h *CompanyHandler) ListFollowedCompanies() echo.HandlerFunc {
 		http.StatusOK,
 	)
 }
+
+// =============================================
+// KYC DOCUMENTS
+// =============================================
+
+func (h *CompanyHandler) GenerateKYCUploadURL() echo.HandlerFunc {
+	return Handle(
+		&company.GenerateKYCUploadURLRequest{},
+		func(c echo.Context, req *company.GenerateKYCUploadURLRequest) (*company.KYCUploadURLResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			resp, err := h.kycService.GenerateUploadURL(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return resp, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) ListKYCDocuments() echo.HandlerFunc {
+	return Handle(
+		&company.ListKYCDocumentsRequest{},
+		func(c echo.Context, req *company.ListKYCDocumentsRequest) ([]company.KYCDocumentResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			docs, err := h.kycService.List(c.Request().Context(), userID, middleware.IsAdmin(c), req.CompanyID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return docs, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) ConfirmKYCDocument() echo.HandlerFunc {
+	return Handle(
+		&company.ConfirmKYCDocumentRequest{},
+		func(c echo.Context, req *company.ConfirmKYCDocumentRequest) (*company.KYCDocument, error) {
+			userID := middleware.GetUserID(c)
+
+			doc, err := h.kycService.Confirm(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return doc, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) DeleteKYCDocument() echo.HandlerFunc {
+	return HandleNoContent(
+		&company.DeleteKYCDocumentRequest{},
+		func(c echo.Context, req *company.DeleteKYCDocumentRequest) error {
+			userID := middleware.GetUserID(c)
+
+			if err := h.kycService.Delete(c.Request().Context(), userID, req.CompanyID, req.DocumentID); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return nil
+		},
+		http.StatusNoContent,
+	)
+}
+
+// =============================================
+// LOGO AND BANNER
+// =============================================
+
+func (h *CompanyHandler) GenerateImageUploadURL() echo.HandlerFunc {
+	return Handle(
+		&company.GenerateCompanyImageUploadURLRequest{},
+		func(c echo.Context, req *company.GenerateCompanyImageUploadURLRequest) (*company.CompanyImageUploadURLResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			resp, err := h.imageService.GenerateUploadURL(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return resp, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) ConfirmImage() echo.HandlerFunc {
+	return Handle(
+		&company.ConfirmCompanyImageRequest{},
+		func(c echo.Context, req *company.ConfirmCompanyImageRequest) (*company.CompanyImage, error) {
+			userID := middleware.GetUserID(c)
+
+			img, err := h.imageService.Confirm(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return img, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) ListImages() echo.HandlerFunc {
+	return Handle(
+		&company.ListCompanyImagesRequest{},
+		func(c echo.Context, req *company.ListCompanyImagesRequest) ([]company.CompanyImage, error) {
+			userID := middleware.GetUserID(c)
+
+			images, err := h.imageService.List(c.Request().Context(), userID, req.CompanyID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return images, nil
+		},
+		http.StatusOK,
+	)
+}
+
+// =============================================
+// SERVICE AREAS
+// =============================================
+
+func (h *CompanyHandler) ListServiceAreas() echo.HandlerFunc {
+	return Handle(
+		&company.ListServiceAreasRequest{},
+		func(c echo.Context, req *company.ListServiceAreasRequest) ([]company.ServiceArea, error) {
+			areas, err := h.companyService.ListServiceAreas(c.Request().Context(), req.CompanyID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return areas, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) UpdateServiceAreas() echo.HandlerFunc {
+	return Handle(
+		&company.UpdateServiceAreasRequest{},
+		func(c echo.Context, req *company.UpdateServiceAreasRequest) ([]company.ServiceArea, error) {
+			areas, err := h.companyService.UpdateServiceAreas(c.Request().Context(), middleware.GetUserID(c), req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return areas, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) GetAvailability() echo.HandlerFunc {
+	return Handle(
+		&company.GetAvailabilityRequest{},
+		func(c echo.Context, req *company.GetAvailabilityRequest) (*company.AvailabilityResponse, error) {
+			availability, err := h.availabilityService.Get(c.Request().Context(), req.CompanyID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusNotFound, err.Error())
+			}
+			return availability, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) UpdateBusinessHours() echo.HandlerFunc {
+	return Handle(
+		&company.UpdateBusinessHoursRequest{},
+		func(c echo.Context, req *company.UpdateBusinessHoursRequest) (*company.CompanyResponse, error) {
+			updated, err := h.availabilityService.UpdateBusinessHours(c.Request().Context(), middleware.GetUserID(c), req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return company.ToCompanyResponse(updated, nil), nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) UpdateHolidays() echo.HandlerFunc {
+	return Handle(
+		&company.UpdateHolidaysRequest{},
+		func(c echo.Context, req *company.UpdateHolidaysRequest) ([]company.Holiday, error) {
+			holidays, err := h.availabilityService.UpdateHolidays(c.Request().Context(), middleware.GetUserID(c), req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return holidays, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) StartVacation() echo.HandlerFunc {
+	return Handle(
+		&company.StartVacationRequest{},
+		func(c echo.Context, req *company.StartVacationRequest) (*company.CompanyResponse, error) {
+			updated, err := h.availabilityService.StartVacation(c.Request().Context(), middleware.GetUserID(c), req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return company.ToCompanyResponse(updated, nil), nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *CompanyHandler) EndVacation() echo.HandlerFunc {
+	return Handle(
+		&company.EndVacationRequest{},
+		func(c echo.Context, req *company.EndVacationRequest) (*company.CompanyResponse, error) {
+			updated, err := h.availabilityService.EndVacation(c.Request().Context(), middleware.GetUserID(c), req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return company.ToCompanyResponse(updated, nil), nil
+		},
+		http.StatusOK,
+	)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model/feed"
+	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type FeedHandler struct {
+	Handler
+	feedService *service.FeedService
+}
+
+func NewFeedHandler(feedService *service.FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+func (h *FeedHandler) GetFeed() echo.HandlerFunc {
+	return Handle(
+		&feed.GetFeedRequest{},
+		func(c echo.Context, req *feed.GetFeedRequest) (*feed.Response, error) {
+			userID := middleware.GetUserID(c)
+
+			result, err := h.feedService.Get(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
@@ -5,21 +5,35 @@ import (
 )
 
 type Handlers struct {
-	Auth    *AuthHandler
-	User    *UserHandler
-	Company *CompanyHandler
-	Product *ProductHandler
-	Health  *HealthHandler
-	Admin   *AdminHandler
+	Auth          *AuthHandler
+	User          *UserHandler
+	Company       *CompanyHandler
+	Product       *ProductHandler
+	Health        *HealthHandler
+	Admin         *AdminHandler
+	Impersonation *ImpersonationHandler
+	APIKey        *APIKeyHandler
+	Report        *ReportHandler
+	Stats         *StatsHandler
+	Analytics     *AnalyticsHandler
+	Feed          *FeedHandler
+	Storefront    *StorefrontHandler
 }
 
 func NewHandlers(s *service.Services) Handlers {
 	return Handlers{
-		Health:  NewHealthHandler(),
-		User:    NewUserHandler(s.User),
-		Company: NewCompanyHandler(s.Company),
-		Product: NewProductHandler(s.Product),
-		Auth:    NewAuthHandler(s.Auth),
-		Admin:   NewAdminHandler(s.Company, s.Product),
+		Health:        NewHealthHandler(),
+		User:          NewUserHandler(s.User, s.LoginAudit),
+		Company:       NewCompanyHandler(s.Company, s.CompanyKYC, s.CompanyImage, s.Availability),
+		Product:       NewProductHandler(s.Product),
+		Auth:          NewAuthHandler(s.Auth),
+		Admin:         NewAdminHandler(s.Company, s.Product, s.CompanyKYC, s.Moderation, s.Audit, s.Suspension, s.CompanyImage),
+		Impersonation: NewImpersonationHandler(s.Impersonation),
+		APIKey:        NewAPIKeyHandler(s.APIKey),
+		Report:        NewReportHandler(s.Report),
+		Stats:         NewStatsHandler(s.Stats),
+		Analytics:     NewAnalyticsHandler(s.Analytics),
+		Feed:          NewFeedHandler(s.Feed),
+		Storefront:    NewStorefrontHandler(s.Storefront),
 	}
 }
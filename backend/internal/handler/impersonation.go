@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model/auth"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type ImpersonationHandler struct {
+	Handler
+	impersonationService *service.ImpersonationService
+}
+
+func NewImpersonationHandler(impersonationService *service.ImpersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+	}
+}
+
+func (h *ImpersonationHandler) Start() echo.HandlerFunc {
+	return Handle(
+		&auth.StartImpersonationRequest{},
+		func(c echo.Context, req *auth.StartImpersonationRequest) (*auth.ImpersonationResponse, error) {
+			resp, err := h.impersonationService.Start(c.Request().Context(), service.StartImpersonationInput{
+				AdminID:      middleware.GetUserID(c),
+				TargetUserID: req.UserID,
+				Reason:       req.Reason,
+				AllowWrite:   req.AllowWrite,
+				TTL:          time.Duration(req.TTLMinutes) * time.Minute,
+				IPAddress:    c.RealIP(),
+				UserAgent:    c.Request().UserAgent(),
+			})
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return resp, nil
+		},
+		http.StatusCreated,
+	)
+}
+
+func (h *ImpersonationHandler) Stop() echo.HandlerFunc {
+	return Handle(
+		&auth.StopImpersonationRequest{},
+		func(c echo.Context, req *auth.StopImpersonationRequest) (*auth.ImpersonationSession, error) {
+			session, err := h.impersonationService.Stop(c.Request().Context(), req.SessionID, middleware.GetUserID(c))
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return session, nil
+		},
+		http.StatusOK,
+	)
+}
+
+// StopCurrent is called with the impersonation token itself. It sits outside
+// RequireAuth so read-only sessions can still end themselves.
+func (h *ImpersonationHandler) StopCurrent() echo.HandlerFunc {
+	return Handle(
+		&auth.StopCurrentImpersonationRequest{},
+		func(c echo.Context, req *auth.StopCurrentImpersonationRequest) (*auth.ImpersonationSession, error) {
+			tokenStr, err := middleware.ExtractBearerToken(c)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+			}
+
+			session, err := h.impersonationService.StopByToken(c.Request().Context(), tokenStr)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return session, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *ImpersonationHandler) List() echo.HandlerFunc {
+	return Handle(
+		&auth.ListImpersonationsRequest{},
+		func(c echo.Context, req *auth.ListImpersonationsRequest) (interface{}, error) {
+			result, err := h.impersonationService.List(c.Request().Context(), repository.ImpersonationFilter{
+				AdminID:      req.AdminID,
+				TargetUserID: req.TargetUserID,
+				ActiveOnly:   req.ActiveOnly,
+				Page:         req.Page,
+				Limit:        req.Limit,
+			})
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
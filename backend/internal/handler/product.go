@@ -3,6 +3,7 @@ package handler
 import (
 	"net/http"
 
+	"github.com/C0deNe0/agromart/internal/model/company"
 	"github.com/C0deNe0/agromart/internal/model/product"
 	"github.com/C0deNe0/agromart/internal/repository/productRepo"
 	"github.com/C0deNe0/agromart/internal/service"
@@ -77,6 +78,9 @@ func (h *ProductHandler) ListProducts() echo.HandlerFunc {
 				Page:           req.Page,
 				Limit:          req.Limit,
 			}
+			if req.DeliversTo != nil {
+				filter.DeliversTo = &company.ServiceAreaLookup{Pincode: *req.DeliversTo}
+			}
 			result, err := h.productService.List(c.Request().Context(), userID, filter)
 			if err != nil {
 				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -89,6 +93,25 @@ func (h *ProductHandler) ListProducts() echo.HandlerFunc {
 	)
 }
 
+func (h *ProductHandler) CheckServiceability() echo.HandlerFunc {
+	return Handle(
+		&product.CheckServiceabilityRequest{},
+		func(c echo.Context, req *product.CheckServiceabilityRequest) (*product.ServiceabilityResponse, error) {
+			var userID *uuid.UUID
+			if id := middleware.GetUserID(c); id != uuid.Nil {
+				userID = &id
+			}
+
+			result, err := h.productService.CheckServiceability(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
 func (h *ProductHandler) UpdateProduct() echo.HandlerFunc {
 	return Handle(
 		&product.UpdateProductRequest{},
@@ -109,6 +132,26 @@ func (h *ProductHandler) UpdateProduct() echo.HandlerFunc {
 	)
 }
 
+func (h *ProductHandler) UpdateProductVisibility() echo.HandlerFunc {
+	return Handle(
+		&product.UpdateProductVisibilityRequest{},
+		func(c echo.Context, req *product.UpdateProductVisibilityRequest) (*product.ProductResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			updated, err := h.productService.SetVisibility(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			p, err := h.productService.GetByID(c.Request().Context(), updated.ID, &userID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return p, nil
+		},
+		http.StatusOK,
+	)
+}
+
 func (h *ProductHandler) DeleteProduct() echo.HandlerFunc {
 	return HandleNoContent(
 		&product.DeleteProductRequest{},
@@ -147,9 +190,9 @@ func (h *ProductHandler) GetApprovalHistory() echo.HandlerFunc {
 	return Handle(
 		&product.GetProductByIDRequest{},
 		func(c echo.Context, req *product.GetProductByIDRequest) (interface{}, error) {
-			history, err := h.productService.GetApprovalHistory(c.Request().Context(), req.ID)
+			history, err := h.productService.GetApprovalHistory(c.Request().Context(), middleware.GetUserID(c), middleware.IsAdmin(c), req.ID)
 			if err != nil {
-				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
 			}
 
 			return history, nil
@@ -158,6 +201,21 @@ func (h *ProductHandler) GetApprovalHistory() echo.HandlerFunc {
 	)
 }
 
+// GetPendingRevision shows the owner the draft waiting on an approved product.
+func (h *ProductHandler) GetPendingRevision() echo.HandlerFunc {
+	return Handle(
+		&product.GetPendingRevisionRequest{},
+		func(c echo.Context, req *product.GetPendingRevisionRequest) (*product.ProductRevision, error) {
+			rev, err := h.productService.GetPendingRevision(c.Request().Context(), middleware.GetUserID(c), req.ProductID)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return rev, nil
+		},
+		http.StatusOK,
+	)
+}
+
 //IMAGE HANDLERS
 
 func (h *ProductHandler) GenerateImageUploadURL() echo.HandlerFunc {
@@ -278,6 +336,37 @@ func (h *ProductHandler) UpdateVariant() echo.HandlerFunc {
 	)
 }
 
+func (h *ProductHandler) UpdateVariantStock() echo.HandlerFunc {
+	return Handle(
+		&product.UpdateVariantStockRequest{},
+		func(c echo.Context, req *product.UpdateVariantStockRequest) (*product.ProductVariantResponse, error) {
+			userID := middleware.GetUserID(c)
+
+			variant, err := h.productService.UpdateVariantStock(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			return &product.ProductVariantResponse{
+				ID:        variant.ID,
+				ProductID: variant.ProductID,
+
+				Label:             variant.Label,
+				QuantityValue:     variant.QuantityValue,
+				QuantityUnit:      variant.QuantityUnit,
+				Price:             variant.Price,
+				StockQuantity:     variant.StockQuantity,
+				LowStockThreshold: variant.LowStockThreshold,
+				IsLowStock:        variant.IsLowStock(),
+				IsAvailable:       variant.IsAvailable,
+				CreatedAt:         variant.CreatedAt,
+				UpdatedAt:         variant.UpdatedAt,
+			}, nil
+		},
+		http.StatusOK,
+	)
+}
+
 func (h *ProductHandler) DeleteVariant() echo.HandlerFunc {
 	return HandleNoContent(
 		&product.DeleteVariantRequest{},
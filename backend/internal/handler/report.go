@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/report"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type ReportHandler struct {
+	Handler
+	reportService *service.ReportService
+}
+
+func NewReportHandler(reportService *service.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// =============================================
+// USER
+// =============================================
+
+func (h *ReportHandler) CreateReport() echo.HandlerFunc {
+	return Handle(
+		&report.CreateReportRequest{},
+		func(c echo.Context, req *report.CreateReportRequest) (*report.Report, error) {
+			userID := middleware.GetUserID(c)
+
+			created, err := h.reportService.Create(c.Request().Context(), userID, req)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return created, nil
+		},
+		http.StatusCreated,
+	)
+}
+
+func (h *ReportHandler) ListMyReports() echo.HandlerFunc {
+	return Handle(
+		&report.ListMyReportsRequest{},
+		func(c echo.Context, req *report.ListMyReportsRequest) (*model.PaginatedResponse[report.Report], error) {
+			userID := middleware.GetUserID(c)
+
+			result, err := h.reportService.ListMine(c.Request().Context(), userID, req.Page, req.Limit)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+// =============================================
+// ADMIN
+// =============================================
+
+// ReportQueue lists reported entities with their open report counts, most
+// reported first.
+func (h *ReportHandler) ReportQueue() echo.HandlerFunc {
+	return Handle(
+		&report.ListReportQueueRequest{},
+		func(c echo.Context, req *report.ListReportQueueRequest) (*model.PaginatedResponse[report.QueueItem], error) {
+			result, err := h.reportService.Queue(c.Request().Context(), repository.ReportQueueFilter{
+				EntityType: req.EntityType,
+				Reason:     req.Reason,
+				HiddenOnly: req.HiddenOnly,
+				Page:       req.Page,
+				Limit:      req.Limit,
+			})
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *ReportHandler) ListEntityReports() echo.HandlerFunc {
+	return Handle(
+		&report.ListEntityReportsRequest{},
+		func(c echo.Context, req *report.ListEntityReportsRequest) ([]report.Report, error) {
+			reports, err := h.reportService.ListForEntity(c.Request().Context(), req.EntityType, req.EntityID, req.Status)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+			}
+			return reports, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *ReportHandler) ResolveReports() echo.HandlerFunc {
+	return Handle(
+		&report.DecideReportsRequest{},
+		func(c echo.Context, req *report.DecideReportsRequest) (*report.DecideReportsResponse, error) {
+			adminID := middleware.GetUserID(c)
+
+			result, err := h.reportService.Resolve(c.Request().Context(), adminID, req.EntityType, req.EntityID, req.Note, req.Restore)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *ReportHandler) DismissReports() echo.HandlerFunc {
+	return Handle(
+		&report.DecideReportsRequest{},
+		func(c echo.Context, req *report.DecideReportsRequest) (*report.DecideReportsResponse, error) {
+			adminID := middleware.GetUserID(c)
+
+			result, err := h.reportService.Dismiss(c.Request().Context(), adminID, req.EntityType, req.EntityID, req.Note)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+			return result, nil
+		},
+		http.StatusOK,
+	)
+}
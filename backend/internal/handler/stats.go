@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/C0deNe0/agromart/internal/model/stats"
+	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/C0deNe0/agromart/internal/validation"
+	"github.com/labstack/echo/v4"
+)
+
+type StatsHandler struct {
+	Handler
+	statsService *service.StatsService
+}
+
+func NewStatsHandler(statsService *service.StatsService) *StatsHandler {
+	return &StatsHandler{
+		statsService: statsService,
+	}
+}
+
+// GetStats returns the admin dashboard as JSON, or as a CSV download with
+// format=csv. It does not go through Handle since the CSV is not JSON.
+func (h *StatsHandler) GetStats() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := &stats.GetStatsRequest{}
+		if err := validation.BindAndValidate(c, req); err != nil {
+			return err
+		}
+
+		result, err := h.statsService.Get(c.Request().Context(), req.From, req.To, req.GroupBy)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
+		if req.Format != stats.FormatCSV {
+			return c.JSON(http.StatusOK, result)
+		}
+
+		var buf bytes.Buffer
+		if err := result.WriteCSV(&buf); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		filename := fmt.Sprintf("agromart-stats-%s-%s.csv", result.From.Format("20060102"), result.To.Format("20060102"))
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		return c.Blob(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/C0deNe0/agromart/internal/validation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type StorefrontHandler struct {
+	Handler
+	storefrontService *service.StorefrontService
+}
+
+func NewStorefrontHandler(storefrontService *service.StorefrontService) *StorefrontHandler {
+	return &StorefrontHandler{
+		storefrontService: storefrontService,
+	}
+}
+
+// GetStorefront redirects earlier slugs like GetCompanyBySlug does.
+func (h *StorefrontHandler) GetStorefront() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := &company.GetCompanyBySlugRequest{}
+		if err := validation.BindAndValidate(c, req); err != nil {
+			return err
+		}
+		var userID *uuid.UUID
+		if id := middleware.GetUserID(c); id != uuid.Nil {
+			userID = &id
+		}
+
+		result, moved, err := h.storefrontService.Get(c.Request().Context(), req.Slug, userID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		if moved {
+			return redirectToSlug(c, req.Slug, result.Company.Slug)
+		}
+		return c.JSON(http.StatusOK, result)
+	}
+}
@@ -4,16 +4,24 @@ import (
 	"net/http"
 
 	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/auth"
+	"github.com/C0deNe0/agromart/internal/repository"
 	"github.com/C0deNe0/agromart/internal/service"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type UserHandler struct {
-	userService *service.UserService
+	userService       *service.UserService
+	loginAuditService *service.LoginAuditService
 }
 
-func NewUserHandler(userService *service.UserService) *UserHandler {
-	return &UserHandler{userService: userService}
+func NewUserHandler(userService *service.UserService, loginAuditService *service.LoginAuditService) *UserHandler {
+	return &UserHandler{
+		userService:       userService,
+		loginAuditService: loginAuditService,
+	}
 }
 
 //We do NOT use Handle[...] here on purpose
@@ -30,3 +38,43 @@ func (h *UserHandler) Me() echo.HandlerFunc {
 		return c.JSON(http.StatusOK, resp)
 	}
 }
+
+// ListMyLoginEvents returns the caller's own login history.
+func (h *UserHandler) ListMyLoginEvents() echo.HandlerFunc {
+	return Handle(
+		&auth.ListLoginEventsRequest{},
+		func(c echo.Context, req *auth.ListLoginEventsRequest) (*model.PaginatedResponse[auth.LoginEvent], error) {
+			req.UserID = middleware.GetUserID(c)
+			return h.listLoginEvents(c, req)
+		},
+		http.StatusOK,
+	)
+}
+
+// ListUserLoginEvents is the admin view of any user's login history.
+func (h *UserHandler) ListUserLoginEvents() echo.HandlerFunc {
+	return Handle(
+		&auth.ListLoginEventsRequest{},
+		func(c echo.Context, req *auth.ListLoginEventsRequest) (*model.PaginatedResponse[auth.LoginEvent], error) {
+			if req.UserID == uuid.Nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, "user id is required")
+			}
+			return h.listLoginEvents(c, req)
+		},
+		http.StatusOK,
+	)
+}
+
+func (h *UserHandler) listLoginEvents(c echo.Context, req *auth.ListLoginEventsRequest) (*model.PaginatedResponse[auth.LoginEvent], error) {
+	result, err := h.loginAuditService.List(c.Request().Context(), repository.LoginEventFilter{
+		UserID:      req.UserID,
+		Success:     req.Success,
+		FlaggedOnly: req.FlaggedOnly,
+		Page:        req.Page,
+		Limit:       req.Limit,
+	})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	return result, nil
+}
@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -46,6 +47,24 @@ func (s *S3Service) GeneratePresignedUploadURL(ctx context.Context, key string,
 	return req.URL, nil
 }
 
+// GeneratePresignedDownloadURL gives temporary read access to a private object.
+func (s *S3Service) GeneratePresignedDownloadURL(ctx context.Context, key string, expiresInSeconds int) (string, error) {
+	presigner := s3.NewPresignClient(s.client)
+
+	req, err := presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = time.Duration(expiresInSeconds) * time.Second
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failure to generate presigned download URL: %w", err)
+	}
+
+	return req.URL, nil
+}
+
 // GET PUBLIC URL
 func (s *S3Service) GetPublicURL(key string) string {
 	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", s.bucket, s.region, key)
@@ -61,3 +80,34 @@ func (s *S3Service) DeleteObject(ctx context.Context, key string) error {
 	}
 	return nil
 }
+
+// HeadObject returns the size and content type S3 stored for an object.
+func (s *S3Service) HeadObject(ctx context.Context, key string) (int64, string, error) {
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to head object %s: %w", key, err)
+	}
+	return aws.ToInt64(out.ContentLength), aws.ToString(out.ContentType), nil
+}
+
+// GetObject reads at most maxBytes of an object.
+func (s *S3Service) GetObject(ctx context.Context, key string, maxBytes int64) ([]byte, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=0-%d", maxBytes-1)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", key, err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(out.Body, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %w", key, err)
+	}
+	return data, nil
+}
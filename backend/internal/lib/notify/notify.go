@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Notification is a channel agnostic message addressed to a single user.
+type Notification struct {
+	UserID uuid.UUID
+	Kind   string
+	Title  string
+	Body   string
+	Data   map[string]string
+}
+
+// Notifier delivers notifications (email, push, SMS, ...). Implementations
+// should not block the caller for long, delivery is best effort.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// LogNotifier only writes notifications to the application log, it is the
+// default until a real channel is configured.
+type LogNotifier struct {
+	log *zerolog.Logger
+}
+
+func NewLogNotifier(log *zerolog.Logger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, msg Notification) error {
+	event := n.log.Info().
+		Str("userId", msg.UserID.String()).
+		Str("kind", msg.Kind).
+		Str("title", msg.Title)
+	for k, v := range msg.Data {
+		event = event.Str(k, v)
+	}
+	event.Msg(msg.Body)
+	return nil
+}
+
+// MultiNotifier fans a notification out to every configured notifier.
+type MultiNotifier []Notifier
+
+func (m MultiNotifier) Notify(ctx context.Context, msg Notification) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
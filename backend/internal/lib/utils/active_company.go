@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// ActiveCompanyHeader lets a seller who owns several companies say which
+// one a request acts for.
+const ActiveCompanyHeader = "X-Company-ID"
+
+type activeCompanyCtxKey struct{}
+
+// WithActiveCompany scopes the request to one of the caller's companies,
+// services refuse to manage any other company while it is set.
+func WithActiveCompany(ctx context.Context, companyID uuid.UUID) context.Context {
+	return context.WithValue(ctx, activeCompanyCtxKey{}, companyID)
+}
+
+func ActiveCompanyFromContext(ctx context.Context) (uuid.UUID, bool) {
+	companyID, ok := ctx.Value(activeCompanyCtxKey{}).(uuid.UUID)
+	return companyID, ok
+}
@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	APIKeyPrefix = "agm_"
+
+	apiKeyDisplayLen = 12
+)
+
+type apiKeyCtxKey struct{}
+
+// GenerateAPIKey returns the plain key (shown once) and the short prefix kept for display.
+func GenerateAPIKey() (key string, prefix string, err error) {
+	secret, err := GenerateRandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	key = APIKeyPrefix + secret
+	return key, key[:apiKeyDisplayLen], nil
+}
+
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// WithAPIKeyCompany pins the request to the company an API key belongs to,
+// services use it to stop a key from touching the owner's other companies.
+func WithAPIKeyCompany(ctx context.Context, companyID uuid.UUID) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, companyID)
+}
+
+func APIKeyCompanyFromContext(ctx context.Context) (uuid.UUID, bool) {
+	companyID, ok := ctx.Value(apiKeyCtxKey{}).(uuid.UUID)
+	return companyID, ok
+}
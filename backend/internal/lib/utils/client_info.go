@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+)
+
+// ClientInfo describes where a request came from. Country and region are
+// only known when the CDN in front of the API forwards them.
+type ClientInfo struct {
+	RequestID string
+	IPAddress string
+	UserAgent string
+	Country   string
+	Region    string
+}
+
+type clientInfoCtxKey struct{}
+
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoCtxKey{}, info)
+}
+
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoCtxKey{}).(ClientInfo)
+	return info
+}
+
+// DeviceFingerprint is a coarse device identifier derived from the user agent.
+func (c ClientInfo) DeviceFingerprint() string {
+	if c.UserAgent == "" {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(c.UserAgent)))[:32]
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const gstinCharset = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+var (
+	gstinPattern = regexp.MustCompile(`^[0-9]{2}[A-Z]{5}[0-9]{4}[A-Z][1-9A-Z]Z[0-9A-Z]$`)
+	panPattern   = regexp.MustCompile(`^[A-Z]{5}[0-9]{4}[A-Z]$`)
+)
+
+// GST state codes as issued by the GST network, keyed by normalised state name.
+var gstStateCodes = map[string]string{
+	"jammuandkashmir":                   "01",
+	"himachalpradesh":                   "02",
+	"punjab":                            "03",
+	"chandigarh":                        "04",
+	"uttarakhand":                       "05",
+	"uttaranchal":                       "05",
+	"haryana":                           "06",
+	"delhi":                             "07",
+	"newdelhi":                          "07",
+	"nctofdelhi":                        "07",
+	"rajasthan":                         "08",
+	"uttarpradesh":                      "09",
+	"bihar":                             "10",
+	"sikkim":                            "11",
+	"arunachalpradesh":                  "12",
+	"nagaland":                          "13",
+	"manipur":                           "14",
+	"mizoram":                           "15",
+	"tripura":                           "16",
+	"meghalaya":                         "17",
+	"assam":                             "18",
+	"westbengal":                        "19",
+	"jharkhand":                         "20",
+	"odisha":                            "21",
+	"orissa":                            "21",
+	"chhattisgarh":                      "22",
+	"madhyapradesh":                     "23",
+	"gujarat":                           "24",
+	"dadraandnagarhavelianddamananddiu": "26",
+	"dadraandnagarhaveli":               "26",
+	"damananddiu":                       "26",
+	"maharashtra":                       "27",
+	"karnataka":                         "29",
+	"goa":                               "30",
+	"lakshadweep":                       "31",
+	"kerala":                            "32",
+	"tamilnadu":                         "33",
+	"puducherry":                        "34",
+	"pondicherry":                       "34",
+	"andamanandnicobarislands":          "35",
+	"telangana":                         "36",
+	"andhrapradesh":                     "37",
+	"ladakh":                            "38",
+}
+
+// legacy codes that are still valid on older registrations
+var gstLegacyStateCodes = map[string][]string{
+	"26": {"25"}, // Daman and Diu before the 2020 merger
+	"37": {"28"}, // Andhra Pradesh before the 2014 split
+}
+
+func NormalizeTaxID(v string) string {
+	return strings.ToUpper(strings.TrimSpace(v))
+}
+
+func ValidatePAN(pan string) error {
+	if !panPattern.MatchString(pan) {
+		return errors.New("PAN must be 10 characters: 5 letters, 4 digits, 1 letter")
+	}
+	return nil
+}
+
+// ValidateGSTIN checks the 15 character format and the mod-36 check digit.
+func ValidateGSTIN(gstin string) error {
+	if !gstinPattern.MatchString(gstin) {
+		return errors.New("GSTIN format is invalid")
+	}
+	if gstinCheckDigit(gstin[:14]) != gstin[14] {
+		return errors.New("GSTIN checksum does not match")
+	}
+	return nil
+}
+
+// GSTINStateCode is the 2 digit state code a GSTIN was issued in.
+func GSTINStateCode(gstin string) string {
+	return gstin[:2]
+}
+
+// GSTINPAN is the PAN of the business embedded in the GSTIN.
+func GSTINPAN(gstin string) string {
+	return gstin[2:12]
+}
+
+// GSTStateCode resolves a free text state name ("Tamil Nadu", "orissa") to its GST code.
+func GSTStateCode(state string) (string, bool) {
+	code, ok := gstStateCodes[normalizeStateName(state)]
+	return code, ok
+}
+
+// GSTINMatchesState reports whether the GSTIN was issued in the given state.
+func GSTINMatchesState(gstin, state string) (bool, error) {
+	code, ok := GSTStateCode(state)
+	if !ok {
+		return false, fmt.Errorf("unknown state: %s", state)
+	}
+	issued := GSTINStateCode(gstin)
+	if issued == code {
+		return true, nil
+	}
+	for _, legacy := range gstLegacyStateCodes[code] {
+		if issued == legacy {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func gstinCheckDigit(body string) byte {
+	sum := 0
+	for i := 0; i < len(body); i++ {
+		value := strings.IndexByte(gstinCharset, body[i])
+		factor := 1
+		if i%2 == 1 {
+			factor = 2
+		}
+		product := value * factor
+		sum += product/36 + product%36
+	}
+	return gstinCharset[(36-sum%36)%36]
+}
+
+func normalizeStateName(state string) string {
+	state = strings.ToLower(strings.ReplaceAll(state, "&", "and"))
+	var b strings.Builder
+	for _, r := range state {
+		if r >= 'a' && r <= 'z' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
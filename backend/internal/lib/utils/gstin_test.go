@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateGSTIN(t *testing.T) {
+	tests := []struct {
+		name    string
+		gstin   string
+		wantErr string
+	}{
+		{name: "valid maharashtra", gstin: "27AAPFU0939F1ZV"},
+		{name: "valid karnataka", gstin: "29AAGCB7383J1Z4"},
+		{name: "bad check digit", gstin: "27AAPFU0939F1ZW", wantErr: "checksum does not match"},
+		{name: "lower case", gstin: "27aapfu0939f1zv", wantErr: "format is invalid"},
+		{name: "too short", gstin: "27AAPFU0939F1Z", wantErr: "format is invalid"},
+		{name: "missing Z", gstin: "27AAPFU0939F1AV", wantErr: "format is invalid"},
+		{name: "zero entity number", gstin: "27AAPFU0939F0ZV", wantErr: "format is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGSTIN(tt.gstin)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidatePAN(t *testing.T) {
+	tests := []struct {
+		pan   string
+		valid bool
+	}{
+		{pan: "AAPFU0939F", valid: true},
+		{pan: "AAGCB7383J", valid: true},
+		{pan: "AAPFU0939", valid: false},
+		{pan: "AAPF00939F", valid: false},
+		{pan: "aapfu0939f", valid: false},
+		{pan: "AAPFU0939F1", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pan, func(t *testing.T) {
+			if err := ValidatePAN(tt.pan); (err == nil) != tt.valid {
+				t.Fatalf("ValidatePAN(%q) = %v, want valid %v", tt.pan, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestGSTINPAN(t *testing.T) {
+	if got := GSTINPAN("27AAPFU0939F1ZV"); got != "AAPFU0939F" {
+		t.Fatalf("GSTINPAN = %q, want AAPFU0939F", got)
+	}
+}
+
+func TestGSTINMatchesState(t *testing.T) {
+	tests := []struct {
+		name    string
+		gstin   string
+		state   string
+		want    bool
+		wantErr bool
+	}{
+		{name: "same state", gstin: "27AAPFU0939F1ZV", state: "Maharashtra", want: true},
+		{name: "free text state name", gstin: "29AAGCB7383J1Z4", state: " karnataka ", want: true},
+		{name: "state does not match", gstin: "27AAPFU0939F1ZV", state: "Karnataka", want: false},
+		{name: "legacy andhra pradesh code", gstin: "28AAPFU0939F1ZV", state: "Andhra Pradesh", want: true},
+		{name: "legacy code is not valid for telangana", gstin: "28AAPFU0939F1ZV", state: "Telangana", want: false},
+		{name: "ampersand in state name", gstin: "35AAPFU0939F1ZV", state: "Andaman & Nicobar Islands", want: true},
+		{name: "unknown state", gstin: "27AAPFU0939F1ZV", state: "Atlantis", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GSTINMatchesState(tt.gstin, tt.state)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("GSTINMatchesState(%q, %q) = %v, want %v", tt.gstin, tt.state, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/http"
+)
+
+// ImageInfo sniffs the real type of an uploaded image and reads its
+// dimensions without decoding the pixels. Only JPEG, PNG and WebP pass.
+func ImageInfo(data []byte) (contentType string, width, height int, err error) {
+	contentType = http.DetectContentType(data)
+
+	switch contentType {
+	case "image/jpeg", "image/png":
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return "", 0, 0, errors.New("image could not be read")
+		}
+		return contentType, cfg.Width, cfg.Height, nil
+	case "image/webp":
+		width, height, err := webpSize(data)
+		if err != nil {
+			return "", 0, 0, err
+		}
+		return contentType, width, height, nil
+	default:
+		return "", 0, 0, errors.New("file is not a JPEG, PNG or WebP image")
+	}
+}
+
+// webpSize reads the dimensions from the first chunk of a RIFF WebP file,
+// the standard library has no WebP decoder.
+func webpSize(data []byte) (int, int, error) {
+	invalid := errors.New("WebP image could not be read")
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, invalid
+	}
+
+	switch string(data[12:16]) {
+	case "VP8 ": // lossy, frame header after the 3 byte start code
+		if data[23] != 0x9d || data[24] != 0x01 || data[25] != 0x2a {
+			return 0, 0, invalid
+		}
+		w := int(binary.LittleEndian.Uint16(data[26:28]) & 0x3fff)
+		h := int(binary.LittleEndian.Uint16(data[28:30]) & 0x3fff)
+		return w, h, nil
+	case "VP8L": // lossless, 14 bit width-1 and height-1
+		if data[20] != 0x2f {
+			return 0, 0, invalid
+		}
+		bits := binary.LittleEndian.Uint32(data[21:25])
+		return int(bits&0x3fff) + 1, int((bits>>14)&0x3fff) + 1, nil
+	case "VP8X": // extended, 24 bit canvas width-1 and height-1
+		w := int(data[24]) | int(data[25])<<8 | int(data[26])<<16
+		h := int(data[27]) | int(data[28])<<8 | int(data[29])<<16
+		return w + 1, h + 1, nil
+	default:
+		return 0, 0, invalid
+	}
+}
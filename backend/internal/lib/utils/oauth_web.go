@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+)
+
+// OAuthWebClient runs the server side authorization-code flow (with PKCE)
+// used by the web admin panel. Mobile apps keep using the ID token flow.
+type OAuthWebClient struct {
+	config *oauth2.Config
+	// where the browser is sent after a successful callback
+	PostLoginRedirect string
+}
+
+func NewGoogleWebOAuth(clientID, clientSecret, redirectURI, postLoginRedirect string) *OAuthWebClient {
+	return &OAuthWebClient{
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURI,
+			Endpoint:     endpoints.Google,
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+		PostLoginRedirect: postLoginRedirect,
+	}
+}
+
+// AuthURL builds the provider consent URL bound to state, nonce and the PKCE challenge.
+func (o *OAuthWebClient) AuthURL(state, nonce, verifier string) string {
+	return o.config.AuthCodeURL(
+		state,
+		oauth2.S256ChallengeOption(verifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	)
+}
+
+// Exchange swaps the code for tokens and returns the raw ID token.
+func (o *OAuthWebClient) Exchange(ctx context.Context, code, verifier string) (string, error) {
+	token, err := o.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	idToken, ok := token.Extra("id_token").(string)
+	if !ok || idToken == "" {
+		return "", errors.New("id_token missing from token response")
+	}
+
+	return idToken, nil
+}
+
+func GenerateRandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
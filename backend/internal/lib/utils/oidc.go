@@ -0,0 +1,330 @@
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	GoogleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+	defaultJWKSCacheTTL = time.Hour
+	// unknown kids do not refetch the JWKS more often than this
+	defaultJWKSMinRefreshInterval = time.Minute
+)
+
+// Google issues tokens with both "accounts.google.com" and the https form.
+var GoogleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+var errJWKSRefreshThrottled = errors.New("JWKS was refreshed too recently")
+
+// OIDCProvider describes a single identity provider whose ID tokens we accept.
+// The iss claim has to match one of Issuers exactly.
+type OIDCProvider struct {
+	Name      string
+	Issuers   []string
+	Audiences []string
+	JWKSURL   string
+}
+
+// OIDCUserClaims are the verified claims we care about from an ID token.
+type OIDCUserClaims struct {
+	Sub           string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+	Nonce         string
+}
+
+type oidcTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	Nonce         string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// OIDCVerifier validates ID tokens for one provider against its JWKS.
+// Keys are cached and refetched when an unknown kid shows up or the cache
+// expires, at most once per minRefreshInterval so forged kids cannot make
+// us hammer the provider. Concurrent callers share one fetch.
+type OIDCVerifier struct {
+	provider           OIDCProvider
+	httpClient         *http.Client
+	cacheTTL           time.Duration
+	minRefreshInterval time.Duration
+
+	mu        sync.RWMutex
+	keys      map[string]any
+	fetchedAt time.Time
+
+	refreshMu     sync.Mutex
+	lastRefresh   time.Time
+	refreshFlight *jwksFetch
+}
+
+// jwksFetch is a JWKS request other callers can wait on.
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+func NewOIDCVerifier(provider OIDCProvider) *OIDCVerifier {
+	return &OIDCVerifier{
+		provider:           provider,
+		httpClient:         &http.Client{Timeout: 10 * time.Second},
+		cacheTTL:           defaultJWKSCacheTTL,
+		minRefreshInterval: defaultJWKSMinRefreshInterval,
+		keys:               make(map[string]any),
+	}
+}
+
+func (v *OIDCVerifier) Provider() OIDCProvider {
+	return v.provider
+}
+
+func (v *OIDCVerifier) Verify(ctx context.Context, rawToken string) (*OIDCUserClaims, error) {
+	claims := &oidcTokenClaims{}
+
+	_, err := jwt.ParseWithClaims(rawToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s ID token verification failed: %w", v.provider.Name, err)
+	}
+
+	if !slices.Contains(v.provider.Issuers, claims.Issuer) {
+		return nil, fmt.Errorf("unexpected token issuer: %s", claims.Issuer)
+	}
+
+	if !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+		return slices.Contains(v.provider.Audiences, aud)
+	}) {
+		return nil, errors.New("token audience is not accepted")
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("sub claim is missing")
+	}
+
+	return &OIDCUserClaims{
+		Sub:           claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: parseBoolClaim(claims.EmailVerified),
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+		Nonce:         claims.Nonce,
+	}, nil
+}
+
+func (v *OIDCVerifier) key(ctx context.Context, kid string) (any, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	fresh := time.Since(v.fetchedAt) < v.cacheTTL
+	v.mu.RUnlock()
+
+	if ok && fresh {
+		return key, nil
+	}
+
+	// a throttled refresh falls back to the keys we already have
+	if err := v.refreshKeys(ctx); err != nil && !errors.Is(err, errJWKSRefreshThrottled) {
+		return nil, err
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	key, ok = v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("signing key %q not found in JWKS", kid)
+	}
+	return key, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// refreshKeys refetches the JWKS unless that happened less than
+// minRefreshInterval ago. Callers arriving during a fetch wait for it.
+func (v *OIDCVerifier) refreshKeys(ctx context.Context) error {
+	v.refreshMu.Lock()
+	if f := v.refreshFlight; f != nil {
+		v.refreshMu.Unlock()
+		select {
+		case <-f.done:
+			return f.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if !v.lastRefresh.IsZero() && time.Since(v.lastRefresh) < v.minRefreshInterval {
+		v.refreshMu.Unlock()
+		return errJWKSRefreshThrottled
+	}
+	f := &jwksFetch{done: make(chan struct{})}
+	v.refreshFlight = f
+	v.lastRefresh = time.Now()
+	v.refreshMu.Unlock()
+
+	// the fetch is shared, one caller giving up must not fail the others
+	f.err = v.fetchKeys(context.WithoutCancel(ctx))
+
+	v.refreshMu.Lock()
+	v.refreshFlight = nil
+	v.refreshMu.Unlock()
+	close(f.done)
+	return f.err
+}
+
+func (v *OIDCVerifier) fetchKeys(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.provider.JWKSURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build JWKS request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		pub, err := k.publicKey()
+		if err != nil {
+			// skip keys we can't use instead of failing the whole set
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.fetchedAt = time.Now()
+	v.mu.Unlock()
+
+	return nil
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %s", k.Kty)
+	}
+}
+
+// some providers send email_verified as a string
+func parseBoolClaim(v any) bool {
+	switch val := v.(type) {
+	case bool:
+		return val
+	case string:
+		return strings.EqualFold(val, "true")
+	default:
+		return false
+	}
+}
+
+// OIDCRegistry holds one verifier per configured provider, keyed by the
+// upper-cased provider name that is stored as auth_provider.
+type OIDCRegistry struct {
+	verifiers map[string]*OIDCVerifier
+}
+
+func NewOIDCRegistry(providers ...OIDCProvider) *OIDCRegistry {
+	r := &OIDCRegistry{verifiers: make(map[string]*OIDCVerifier, len(providers))}
+	for _, p := range providers {
+		p.Name = strings.ToUpper(p.Name)
+		r.verifiers[p.Name] = NewOIDCVerifier(p)
+	}
+	return r
+}
+
+func (r *OIDCRegistry) Get(name string) (*OIDCVerifier, bool) {
+	v, ok := r.verifiers[strings.ToUpper(name)]
+	return v, ok
+}
+
+func (r *OIDCRegistry) Names() []string {
+	names := make([]string, 0, len(r.verifiers))
+	for name := range r.verifiers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,289 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testAudience = "agromart-test-client"
+
+// stubIssuer is a local OIDC issuer serving a JWKS and signing ID tokens
+// with generated RSA keys.
+type stubIssuer struct {
+	t   *testing.T
+	srv *httptest.Server
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches int
+}
+
+func newStubIssuer(t *testing.T, kid string) *stubIssuer {
+	t.Helper()
+	s := &stubIssuer{t: t, keys: map[string]*rsa.PrivateKey{kid: generateRSAKey(t)}}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveJWKS))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	return key
+}
+
+func (s *stubIssuer) serveJWKS(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches++
+
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	for kid, key := range s.keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "RSA",
+			Kid: kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+// rotate replaces every published key with a new one under kid.
+func (s *stubIssuer) rotate(kid string) {
+	key := generateRSAKey(s.t)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = map[string]*rsa.PrivateKey{kid: key}
+}
+
+func (s *stubIssuer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+func (s *stubIssuer) provider() OIDCProvider {
+	return OIDCProvider{
+		Name:      "STUB",
+		Issuers:   []string{s.srv.URL},
+		Audiences: []string{testAudience},
+		JWKSURL:   s.srv.URL,
+	}
+}
+
+func (s *stubIssuer) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            s.srv.URL,
+		"aud":            testAudience,
+		"sub":            "user-123",
+		"email":          "farmer@example.com",
+		"email_verified": "true",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+}
+
+// sign signs with the key published under kid, or a throwaway key when
+// the issuer does not know kid.
+func (s *stubIssuer) sign(kid string, claims jwt.MapClaims) string {
+	s.t.Helper()
+	s.mu.Lock()
+	key, ok := s.keys[kid]
+	s.mu.Unlock()
+	if !ok {
+		key = generateRSAKey(s.t)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	raw, err := token.SignedString(key)
+	if err != nil {
+		s.t.Fatalf("failed to sign token: %v", err)
+	}
+	return raw
+}
+
+func TestOIDCVerifierVerify(t *testing.T) {
+	issuer := newStubIssuer(t, "k1")
+
+	hmacToken := func() string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, issuer.claims())
+		token.Header["kid"] = "k1"
+		raw, err := token.SignedString([]byte("shared-secret"))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return raw
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		wantErr string
+	}{
+		{
+			name:  "good token",
+			token: func() string { return issuer.sign("k1", issuer.claims()) },
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				c := issuer.claims()
+				c["iss"] = "https://evil.example.com"
+				return issuer.sign("k1", c)
+			},
+			wantErr: "unexpected token issuer",
+		},
+		{
+			name: "issuer without scheme",
+			token: func() string {
+				c := issuer.claims()
+				c["iss"] = strings.TrimPrefix(issuer.srv.URL, "http://")
+				return issuer.sign("k1", c)
+			},
+			wantErr: "unexpected token issuer",
+		},
+		{
+			name: "audience not accepted",
+			token: func() string {
+				c := issuer.claims()
+				c["aud"] = "someone-else"
+				return issuer.sign("k1", c)
+			},
+			wantErr: "audience is not accepted",
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				c := issuer.claims()
+				c["iat"] = time.Now().Add(-2 * time.Hour).Unix()
+				c["exp"] = time.Now().Add(-time.Hour).Unix()
+				return issuer.sign("k1", c)
+			},
+			wantErr: "token is expired",
+		},
+		{
+			name: "missing sub",
+			token: func() string {
+				c := issuer.claims()
+				delete(c, "sub")
+				return issuer.sign("k1", c)
+			},
+			wantErr: "sub claim is missing",
+		},
+		{
+			name:    "disallowed alg",
+			token:   hmacToken,
+			wantErr: "signing method HS256 is invalid",
+		},
+	}
+
+	verifier := NewOIDCVerifier(issuer.provider())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := verifier.Verify(context.Background(), tt.token())
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got none", tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %q", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.Sub != "user-123" || claims.Email != "farmer@example.com" || !claims.EmailVerified {
+				t.Fatalf("unexpected claims: %+v", claims)
+			}
+		})
+	}
+}
+
+func TestOIDCVerifierGoogleIssuers(t *testing.T) {
+	issuer := newStubIssuer(t, "k1")
+	provider := issuer.provider()
+	provider.Issuers = GoogleIssuers
+	verifier := NewOIDCVerifier(provider)
+
+	for _, iss := range GoogleIssuers {
+		c := issuer.claims()
+		c["iss"] = iss
+		if _, err := verifier.Verify(context.Background(), issuer.sign("k1", c)); err != nil {
+			t.Fatalf("issuer %q: unexpected error: %v", iss, err)
+		}
+	}
+}
+
+func TestOIDCVerifierKeyRotation(t *testing.T) {
+	issuer := newStubIssuer(t, "k1")
+	verifier := NewOIDCVerifier(issuer.provider())
+	ctx := context.Background()
+
+	if _, err := verifier.Verify(ctx, issuer.sign("k1", issuer.claims())); err != nil {
+		t.Fatalf("unexpected error before rotation: %v", err)
+	}
+
+	issuer.rotate("k2")
+	rotated := issuer.sign("k2", issuer.claims())
+
+	// inside the refresh interval the new kid is not fetched yet
+	if _, err := verifier.Verify(ctx, rotated); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected key not found inside the refresh interval, got %v", err)
+	}
+
+	verifier.refreshMu.Lock()
+	verifier.lastRefresh = time.Now().Add(-2 * verifier.minRefreshInterval)
+	verifier.refreshMu.Unlock()
+
+	if _, err := verifier.Verify(ctx, rotated); err != nil {
+		t.Fatalf("unexpected error after rotation: %v", err)
+	}
+	if got := issuer.fetchCount(); got != 2 {
+		t.Fatalf("expected 2 JWKS fetches, got %d", got)
+	}
+}
+
+func TestOIDCVerifierThrottlesUnknownKids(t *testing.T) {
+	issuer := newStubIssuer(t, "k1")
+	verifier := NewOIDCVerifier(issuer.provider())
+
+	forged := issuer.sign("forged", issuer.claims())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := verifier.Verify(context.Background(), forged); err == nil {
+				t.Error("expected forged kid to be rejected")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := issuer.fetchCount(); got != 1 {
+		t.Fatalf("expected forged kids to share a single JWKS fetch, got %d", got)
+	}
+}
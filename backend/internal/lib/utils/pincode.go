@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"errors"
+	"regexp"
+)
+
+var (
+	pincodePattern       = regexp.MustCompile(`^[1-9][0-9]{5}$`)
+	pincodePrefixPattern = regexp.MustCompile(`^[1-9][0-9]{0,4}$`)
+)
+
+// Leading pincode digits per GST state code. Postal circles do not follow
+// state borders exactly, the three digit prefixes take precedence over the
+// two digit ones where a district sits in another state.
+var statePincodePrefixes = map[string][]string{
+	"01": {"18", "19"},                                           // Jammu and Kashmir
+	"02": {"17"},                                                 // Himachal Pradesh
+	"03": {"14", "15", "16"},                                     // Punjab
+	"04": {"160"},                                                // Chandigarh
+	"05": {"246", "248", "249", "262", "263"},                    // Uttarakhand
+	"06": {"12", "13"},                                           // Haryana
+	"07": {"11"},                                                 // Delhi
+	"08": {"30", "31", "32", "33", "34"},                         // Rajasthan
+	"09": {"20", "21", "22", "23", "24", "25", "26", "27", "28"}, // Uttar Pradesh
+	"10": {"80", "81", "82", "84", "85"},                         // Bihar
+	"11": {"737"},                                                // Sikkim
+	"12": {"790", "791", "792"},                                  // Arunachal Pradesh
+	"13": {"797", "798"},                                         // Nagaland
+	"14": {"795"},                                                // Manipur
+	"15": {"796"},                                                // Mizoram
+	"16": {"799"},                                                // Tripura
+	"17": {"793", "794"},                                         // Meghalaya
+	"18": {"78"},                                                 // Assam
+	"19": {"70", "71", "72", "73", "74"},                         // West Bengal
+	"20": { // Jharkhand
+		"83", "813", "814", "815", "816", "822", "825", "826", "827", "828", "829",
+	},
+	"21": {"75", "76", "77"},             // Odisha
+	"22": {"49"},                         // Chhattisgarh
+	"23": {"45", "46", "47", "48"},       // Madhya Pradesh
+	"24": {"36", "37", "38", "39"},       // Gujarat
+	"27": {"40", "41", "42", "43", "44"}, // Maharashtra
+	"29": {"56", "57", "58", "59"},       // Karnataka
+	"30": {"403"},                        // Goa
+	"32": {"67", "68", "69"},             // Kerala
+	"33": {"60", "61", "62", "63", "64"}, // Tamil Nadu
+	"34": {"605"},                        // Puducherry
+	"35": {"744"},                        // Andaman and Nicobar Islands
+	"36": {"50"},                         // Telangana
+	"37": {"51", "52", "53"},             // Andhra Pradesh
+	"38": {"194"},                        // Ladakh
+}
+
+var pincodeStateCodes = func() map[string]string {
+	m := make(map[string]string)
+	for code, prefixes := range statePincodePrefixes {
+		for _, p := range prefixes {
+			m[p] = code
+		}
+	}
+	return m
+}()
+
+func ValidatePincode(pincode string) error {
+	if !pincodePattern.MatchString(pincode) {
+		return errors.New("pincode must be 6 digits and not start with 0")
+	}
+	return nil
+}
+
+func ValidatePincodePrefix(prefix string) error {
+	if !pincodePrefixPattern.MatchString(prefix) {
+		return errors.New("pincode prefix must be 1 to 5 digits and not start with 0")
+	}
+	return nil
+}
+
+// PincodePrefixes lists every proper prefix of a pincode, shortest first.
+func PincodePrefixes(pincode string) []string {
+	prefixes := make([]string, 0, len(pincode)-1)
+	for i := 1; i < len(pincode); i++ {
+		prefixes = append(prefixes, pincode[:i])
+	}
+	return prefixes
+}
+
+// PincodeStateCode resolves the GST state code a pincode belongs to.
+func PincodeStateCode(pincode string) (string, bool) {
+	if len(pincode) < 3 {
+		return "", false
+	}
+	if code, ok := pincodeStateCodes[pincode[:3]]; ok {
+		return code, true
+	}
+	code, ok := pincodeStateCodes[pincode[:2]]
+	return code, ok
+}
+
+// IsGSTStateCode reports whether code is one of the known two digit codes.
+func IsGSTStateCode(code string) bool {
+	for _, c := range gstStateCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPincodeStateCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		pincode string
+		want    string
+		wantOK  bool
+	}{
+		{name: "mumbai", pincode: "400001", want: "27", wantOK: true},
+		{name: "bengaluru", pincode: "560001", want: "29", wantOK: true},
+		{name: "chandigarh overrides punjab", pincode: "160017", want: "04", wantOK: true},
+		{name: "punjab", pincode: "141001", want: "03", wantOK: true},
+		{name: "goa overrides maharashtra", pincode: "403001", want: "30", wantOK: true},
+		{name: "sikkim overrides west bengal", pincode: "737101", want: "11", wantOK: true},
+		{name: "west bengal", pincode: "734001", want: "19", wantOK: true},
+		{name: "jharkhand overrides bihar", pincode: "813210", want: "20", wantOK: true},
+		{name: "bihar", pincode: "812001", want: "10", wantOK: true},
+		{name: "unassigned prefix", pincode: "990001", wantOK: false},
+		{name: "too short", pincode: "40", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := PincodeStateCode(tt.pincode)
+			if ok != tt.wantOK || got != tt.want {
+				t.Fatalf("PincodeStateCode(%q) = %q, %v, want %q, %v", tt.pincode, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPincodePrefixes(t *testing.T) {
+	tests := []struct {
+		pincode string
+		want    []string
+	}{
+		{pincode: "560001", want: []string{"5", "56", "560", "5600", "56000"}},
+		{pincode: "40", want: []string{"4"}},
+		{pincode: "4", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pincode, func(t *testing.T) {
+			if got := PincodePrefixes(tt.pincode); !slices.Equal(got, tt.want) {
+				t.Fatalf("PincodePrefixes(%q) = %v, want %v", tt.pincode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePincode(t *testing.T) {
+	tests := []struct {
+		pincode string
+		valid   bool
+	}{
+		{pincode: "560001", valid: true},
+		{pincode: "060001", valid: false},
+		{pincode: "56000", valid: false},
+		{pincode: "5600011", valid: false},
+		{pincode: "56000a", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pincode, func(t *testing.T) {
+			if err := ValidatePincode(tt.pincode); (err == nil) != tt.valid {
+				t.Fatalf("ValidatePincode(%q) = %v, want valid %v", tt.pincode, err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	SlugMinLength = 3
+	SlugMaxLength = 60
+)
+
+var (
+	slugPattern  = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	slugSeparate = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Slugify turns a name into a URL slug, "Green Valley Farms" becomes
+// "green-valley-farms". The result can be shorter than SlugMinLength.
+func Slugify(name string) string {
+	slug := slugSeparate.ReplaceAllString(strings.ToLower(name), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > SlugMaxLength {
+		slug = strings.TrimRight(slug[:SlugMaxLength], "-")
+	}
+	return slug
+}
+
+// IsValidSlug reports whether s is lowercase letters and digits separated
+// by single hyphens, within the length limits.
+func IsValidSlug(s string) bool {
+	return len(s) >= SlugMinLength && len(s) <= SlugMaxLength && slugPattern.MatchString(s)
+}
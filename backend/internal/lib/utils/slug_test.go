@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "spaces", in: "Green Valley Farms", want: "green-valley-farms"},
+		{name: "punctuation", in: "Shree Krishna Agro (P) Ltd.", want: "shree-krishna-agro-p-ltd"},
+		{name: "leading and trailing separators", in: "  --Organic & Co--  ", want: "organic-co"},
+		{name: "digits kept", in: "Farm 24x7", want: "farm-24x7"},
+		{name: "non ascii letters dropped", in: "Kisan Sevā Kendra", want: "kisan-sev-kendra"},
+		{name: "nothing usable", in: "!!!", want: ""},
+		{name: "cut at the max length", in: strings.Repeat("ab ", 30), want: strings.TrimSuffix(strings.Repeat("ab-", 20), "-")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Slugify(tt.in)
+			if got != tt.want {
+				t.Fatalf("Slugify(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if len(got) > SlugMaxLength {
+				t.Fatalf("Slugify(%q) is %d characters, over the max", tt.in, len(got))
+			}
+		})
+	}
+}
+
+func TestIsValidSlug(t *testing.T) {
+	tests := []struct {
+		slug  string
+		valid bool
+	}{
+		{slug: "green-valley-farms", valid: true},
+		{slug: "farm-24x7", valid: true},
+		{slug: "abc", valid: true},
+		{slug: "ab", valid: false},
+		{slug: strings.Repeat("a", SlugMaxLength), valid: true},
+		{slug: strings.Repeat("a", SlugMaxLength+1), valid: false},
+		{slug: "Green-Valley", valid: false},
+		{slug: "green--valley", valid: false},
+		{slug: "-green", valid: false},
+		{slug: "green-", valid: false},
+		{slug: "green_valley", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.slug, func(t *testing.T) {
+			if got := IsValidSlug(tt.slug); got != tt.valid {
+				t.Fatalf("IsValidSlug(%q) = %v, want %v", tt.slug, got, tt.valid)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"time"
@@ -12,8 +13,33 @@ import (
 type AccessClaims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Role   string    `json:"role"`
+	// set only on impersonation tokens, identifies the admin acting as UserID
+	Act *ActorClaim `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
+
+// ActorClaim follows the RFC 8693 "act" claim, with the session it belongs to.
+type ActorClaim struct {
+	Sub       uuid.UUID `json:"sub"`
+	SessionID uuid.UUID `json:"sid"`
+	ReadOnly  bool      `json:"read_only"`
+}
+
+const ImpersonationAudience = "impersonation"
+
+type impersonatorCtxKey struct{}
+
+// WithImpersonator records the admin behind an impersonated request so the
+// services can attribute changes to both users.
+func WithImpersonator(ctx context.Context, adminID uuid.UUID) context.Context {
+	return context.WithValue(ctx, impersonatorCtxKey{}, adminID)
+}
+
+func ImpersonatorFromContext(ctx context.Context) (uuid.UUID, bool) {
+	adminID, ok := ctx.Value(impersonatorCtxKey{}).(uuid.UUID)
+	return adminID, ok
+}
+
 type RefreshClaims struct {
 	UserID uuid.UUID `json:"user_id"`
 	jwt.RegisteredClaims
@@ -51,6 +77,26 @@ func (tm *TokenManager) GenerateAccessToken(userID uuid.UUID, role string) (stri
 	return token.SignedString([]byte(tm.AccessSecret))
 }
 
+// GenerateImpersonationToken issues a short-lived access token for userID on
+// behalf of an admin. There is no refresh token for these sessions.
+func (tm *TokenManager) GenerateImpersonationToken(userID uuid.UUID, role string, act ActorClaim, expiresAt time.Time) (string, error) {
+	claims := &AccessClaims{
+		UserID: userID,
+		Role:   role,
+		Act:    &act,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        act.SessionID.String(),
+			Issuer:    "agromart-api",
+			Audience:  []string{ImpersonationAudience},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(tm.AccessSecret))
+}
+
 func (tm *TokenManager) GenerateRefreshToken(userID uuid.UUID) (string, error) {
 	claims := &RefreshClaims{
 		UserID: userID,
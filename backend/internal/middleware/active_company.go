@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// ActiveCompany reads the X-Company-ID header into the request context.
+// Ownership is not checked here, the service layer does that against the
+// company being managed.
+func ActiveCompany() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			raw := strings.TrimSpace(c.Request().Header.Get(utils.ActiveCompanyHeader))
+			if raw == "" {
+				return next(c)
+			}
+			companyID, err := uuid.Parse(raw)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "invalid "+utils.ActiveCompanyHeader+" header")
+			}
+			req := c.Request()
+			c.SetRequest(req.WithContext(utils.WithActiveCompany(req.Context(), companyID)))
+			return next(c)
+		}
+	}
+}
@@ -17,7 +17,7 @@ func NewAdminMiddleware() *AdminMiddleware {
 func (a *AdminMiddleware) RequireAdmin(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		role := GetUserRole(c)
-		if role != user.RoleAdmin {
+		if role != user.RoleAdmin || IsImpersonating(c) {
 			return echo.NewHTTPError(http.StatusForbidden, "You are not authorized to access this resource")
 		}
 		return next(c)
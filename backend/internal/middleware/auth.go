@@ -1,42 +1,135 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/model/company"
 	"github.com/C0deNe0/agromart/internal/model/user"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
+// ImpersonationChecker tells whether an impersonation session is still open,
+// so stopped sessions are cut off before their token expires.
+type ImpersonationChecker interface {
+	IsImpersonationActive(ctx context.Context, sessionID uuid.UUID) (bool, error)
+}
+
+// APIKeyAuthenticator resolves a raw company API key.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, rawKey string, ip string) (*company.APIKeyPrincipal, error)
+}
+
 type AuthMiddleware struct {
-	tokenManager *utils.TokenManager
+	tokenManager  *utils.TokenManager
+	impersonation ImpersonationChecker
+	apiKeys       APIKeyAuthenticator
 }
 
-func NewAuthMiddleware(tokenManager *utils.TokenManager) *AuthMiddleware {
-	return &AuthMiddleware{tokenManager: tokenManager}
+func NewAuthMiddleware(tokenManager *utils.TokenManager, impersonation ImpersonationChecker, apiKeys APIKeyAuthenticator) *AuthMiddleware {
+	return &AuthMiddleware{
+		tokenManager:  tokenManager,
+		impersonation: impersonation,
+		apiKeys:       apiKeys,
+	}
 }
 
 func (am *AuthMiddleware) RequireAuth() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// Implement your authentication logic here
-
 			tokenStr, err := ExtractBearerToken(c)
 			if err != nil {
 				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 			}
-			claims, err := am.tokenManager.ParseAccessToken(tokenStr)
+			if err := am.authenticateJWT(c, tokenStr); err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireAuthOrAPIKey accepts either a user's bearer JWT or a company API key,
+// sent as "X-API-Key: agm_..." or "Authorization: Bearer agm_...".
+func (am *AuthMiddleware) RequireAuthOrAPIKey() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			rawKey := strings.TrimSpace(c.Request().Header.Get("X-API-Key"))
+			if rawKey == "" {
+				tokenStr, err := ExtractBearerToken(c)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+				}
+				if !utils.IsAPIKey(tokenStr) {
+					if err := am.authenticateJWT(c, tokenStr); err != nil {
+						return err
+					}
+					return next(c)
+				}
+				rawKey = tokenStr
+			}
+
+			principal, err := am.apiKeys.AuthenticateAPIKey(c.Request().Context(), rawKey, c.RealIP())
 			if err != nil {
 				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 			}
-			c.Set("userID", claims.UserID)
-			c.Set("role", user.UserRole(claims.Role))
+
+			// the key acts as the company owner, but only for its own company
+			c.Set("userID", principal.OwnerID)
+			c.Set("role", user.RoleUser)
+			c.Set("apiKey", principal)
+			c.SetRequest(c.Request().WithContext(
+				utils.WithAPIKeyCompany(c.Request().Context(), principal.CompanyID),
+			))
 			return next(c)
 		}
 	}
 }
 
+// RequireScope guards a route for API key callers. JWT callers pass through,
+// they are authorized by the service layer as usual.
+func (am *AuthMiddleware) RequireScope(scope company.APIKeyScope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := GetAPIKey(c)
+			if principal != nil && !slices.Contains(principal.Scopes, string(scope)) {
+				return echo.NewHTTPError(http.StatusForbidden, "api key is missing scope "+string(scope))
+			}
+			return next(c)
+		}
+	}
+}
+
+func (am *AuthMiddleware) authenticateJWT(c echo.Context, tokenStr string) error {
+	claims, err := am.tokenManager.ParseAccessToken(tokenStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+	c.Set("userID", claims.UserID)
+	c.Set("role", user.UserRole(claims.Role))
+
+	if claims.Act != nil {
+		active, err := am.impersonation.IsImpersonationActive(c.Request().Context(), claims.Act.SessionID)
+		if err != nil || !active {
+			return echo.NewHTTPError(http.StatusUnauthorized, "impersonation session has ended")
+		}
+		if claims.Act.ReadOnly && !isSafeMethod(c.Request().Method) {
+			return echo.NewHTTPError(http.StatusForbidden, "impersonated session is read-only")
+		}
+		c.Set("impersonationID", claims.Act.SessionID)
+		c.SetRequest(c.Request().WithContext(
+			utils.WithImpersonator(c.Request().Context(), claims.Act.Sub),
+		))
+		// lets clients show a banner for impersonated sessions
+		c.Response().Header().Set("X-Impersonated-By", claims.Act.Sub.String())
+	}
+	return nil
+}
+
 func ExtractBearerToken(c echo.Context) (string, error) {
 	authHeader := c.Request().Header.Get("Authorization")
 	if authHeader == "" {
@@ -53,3 +146,11 @@ func ExtractBearerToken(c echo.Context) (string, error) {
 	token = strings.Trim(token, "\"")
 	return token, nil
 }
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
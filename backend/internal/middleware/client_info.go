@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/labstack/echo/v4"
+)
+
+// ClientInfo stores the caller's IP, user agent and (when the CDN provides it)
+// country/region on the request context for the service layer. It runs after
+// echo's RequestID middleware so the generated id is already on the response.
+func ClientInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			info := utils.ClientInfo{
+				RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+				IPAddress: c.RealIP(),
+				UserAgent: req.UserAgent(),
+				Country:   firstHeader(c, "CF-IPCountry", "CloudFront-Viewer-Country"),
+				Region:    firstHeader(c, "CF-Region-Code", "CloudFront-Viewer-Country-Region"),
+			}
+			c.SetRequest(req.WithContext(utils.WithClientInfo(req.Context(), info)))
+			return next(c)
+		}
+	}
+}
+
+func firstHeader(c echo.Context, names ...string) string {
+	for _, name := range names {
+		if v := c.Request().Header.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"github.com/C0deNe0/agromart/internal/model/company"
 	"github.com/C0deNe0/agromart/internal/model/user"
 	"github.com/google/uuid"
 )
@@ -34,3 +35,20 @@ func IsAdmin(c interface {
 	}
 	return val.(user.UserRole) == user.RoleAdmin
 }
+
+func IsImpersonating(c interface {
+	Get(string) interface{}
+}) bool {
+	return c.Get("impersonationID") != nil
+}
+
+// GetAPIKey returns the API key behind the request, nil for JWT requests.
+func GetAPIKey(c interface {
+	Get(string) interface{}
+}) *company.APIKeyPrincipal {
+	val := c.Get("apiKey")
+	if val == nil {
+		return nil
+	}
+	return val.(*company.APIKeyPrincipal)
+}
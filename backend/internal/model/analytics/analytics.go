@@ -0,0 +1,147 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/stats"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ViewKind string
+
+const (
+	ViewKindDetail     ViewKind = "VIEW"
+	ViewKindImpression ViewKind = "IMPRESSION"
+)
+
+// ViewEvent is one product view or list impression waiting to be flushed.
+type ViewEvent struct {
+	Kind      ViewKind
+	ProductID uuid.UUID
+	CompanyID uuid.UUID
+	ViewerKey string
+	At        time.Time
+}
+
+// =============================================
+// DASHBOARDS
+// =============================================
+
+type ProductPoint struct {
+	Bucket        time.Time `json:"bucket" db:"bucket"`
+	Views         int       `json:"views" db:"views"`
+	Impressions   int       `json:"impressions" db:"impressions"`
+	UniqueViewers int       `json:"uniqueViewers" db:"unique_viewers"`
+	FavoriteAdds  int       `json:"favoriteAdds" db:"favorite_adds"`
+}
+
+type CompanyPoint struct {
+	ProductPoint
+	Follows   int `json:"follows" db:"follows"`
+	Unfollows int `json:"unfollows" db:"unfollows"`
+}
+
+// Totals covers the whole range, UniqueViewers is counted over the range
+// and is not the sum of the buckets.
+type Totals struct {
+	Views         int `json:"views"`
+	Impressions   int `json:"impressions"`
+	UniqueViewers int `json:"uniqueViewers"`
+	FavoriteAdds  int `json:"favoriteAdds"`
+	Follows       int `json:"follows,omitempty"`
+	Unfollows     int `json:"unfollows,omitempty"`
+}
+
+type ProductSummary struct {
+	ProductID    uuid.UUID `json:"productId" db:"product_id"`
+	Name         string    `json:"name" db:"name"`
+	Views        int       `json:"views" db:"views"`
+	Impressions  int       `json:"impressions" db:"impressions"`
+	FavoriteAdds int       `json:"favoriteAdds" db:"favorite_adds"`
+}
+
+type CompanyAnalyticsResponse struct {
+	CompanyID     uuid.UUID        `json:"companyId"`
+	From          time.Time        `json:"from"`
+	To            time.Time        `json:"to"`
+	GroupBy       stats.GroupBy    `json:"groupBy"`
+	FollowerCount int              `json:"followerCount"`
+	Totals        Totals           `json:"totals"`
+	Series        []CompanyPoint   `json:"series"`
+	TopProducts   []ProductSummary `json:"topProducts"`
+}
+
+type ProductAnalyticsResponse struct {
+	ProductID uuid.UUID      `json:"productId"`
+	CompanyID uuid.UUID      `json:"companyId"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	GroupBy   stats.GroupBy  `json:"groupBy"`
+	Totals    Totals         `json:"totals"`
+	Series    []ProductPoint `json:"series"`
+}
+
+// =============================================
+// REQUESTS
+// =============================================
+
+// GetCompanyAnalyticsRequest covers [From, To), the last 30 days by default.
+type GetCompanyAnalyticsRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required,uuid"`
+
+	// RFC 3339, e.g. 2025-01-31T00:00:00Z
+	From    *time.Time    `query:"from" validate:"omitempty"`
+	To      *time.Time    `query:"to" validate:"omitempty"`
+	GroupBy stats.GroupBy `query:"groupBy" validate:"oneof=day week month"`
+}
+
+func (r *GetCompanyAnalyticsRequest) Validate() error {
+	if r.GroupBy == "" {
+		r.GroupBy = stats.GroupByDay
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type GetProductAnalyticsRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required,uuid"`
+	ProductID uuid.UUID `param:"productId" validate:"required,uuid"`
+
+	// RFC 3339, e.g. 2025-01-31T00:00:00Z
+	From    *time.Time    `query:"from" validate:"omitempty"`
+	To      *time.Time    `query:"to" validate:"omitempty"`
+	GroupBy stats.GroupBy `query:"groupBy" validate:"oneof=day week month"`
+}
+
+func (r *GetProductAnalyticsRequest) Validate() error {
+	if r.GroupBy == "" {
+		r.GroupBy = stats.GroupByDay
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// =============================================
+// AGGREGATES
+// =============================================
+
+// DailyKey identifies one product_stats_daily row, Day is truncated to UTC
+// midnight.
+type DailyKey struct {
+	ProductID uuid.UUID
+	CompanyID uuid.UUID
+	Day       time.Time
+}
+
+type DailyCounts struct {
+	Views       int
+	Impressions int
+}
+
+type DailyViewer struct {
+	DailyKey
+	ViewerKey string
+}
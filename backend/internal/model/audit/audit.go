@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type Action string
+
+const (
+	ActionCreate    Action = "CREATE"
+	ActionUpdate    Action = "UPDATE"
+	ActionDelete    Action = "DELETE"
+	ActionResubmit  Action = "RESUBMIT"
+	ActionApprove   Action = "APPROVE"
+	ActionReject    Action = "REJECT"
+	ActionFollow    Action = "FOLLOW"
+	ActionUnfollow  Action = "UNFOLLOW"
+	ActionRotate    Action = "ROTATE"
+	ActionRevoke    Action = "REVOKE"
+	ActionBlock     Action = "BLOCK"
+	ActionUnblock   Action = "UNBLOCK"
+	ActionReport    Action = "REPORT"
+	ActionHide      Action = "HIDE"
+	ActionRestore   Action = "RESTORE"
+	ActionResolve   Action = "RESOLVE"
+	ActionDismiss   Action = "DISMISS"
+	ActionSuspend   Action = "SUSPEND"
+	ActionReinstate Action = "REINSTATE"
+)
+
+type EntityType string
+
+const (
+	EntityUser            EntityType = "USER"
+	EntityCompany         EntityType = "COMPANY"
+	EntityCompanyAPIKey   EntityType = "COMPANY_API_KEY"
+	EntityCompanyKYC      EntityType = "COMPANY_KYC_DOCUMENT"
+	EntityCompanyImage    EntityType = "COMPANY_IMAGE"
+	EntityProduct         EntityType = "PRODUCT"
+	EntityProductRevision EntityType = "PRODUCT_REVISION"
+	EntityProductImage    EntityType = "PRODUCT_IMAGE"
+	EntityProductVariant  EntityType = "PRODUCT_VARIANT"
+)
+
+// Entry is one audit_log row. Before and After hold the JSON form of the
+// entity (or the changed part of it) around the action, either may be empty.
+type Entry struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	ActorID        *uuid.UUID      `json:"actorId,omitempty" db:"actor_id"`
+	ImpersonatorID *uuid.UUID      `json:"impersonatorId,omitempty" db:"impersonator_id"`
+	Action         Action          `json:"action" db:"action"`
+	EntityType     EntityType      `json:"entityType" db:"entity_type"`
+	EntityID       uuid.UUID       `json:"entityId" db:"entity_id"`
+	Before         json.RawMessage `json:"before,omitempty" db:"before"`
+	After          json.RawMessage `json:"after,omitempty" db:"after"`
+	RequestID      *string         `json:"requestId,omitempty" db:"request_id"`
+	IPAddress      *string         `json:"ipAddress,omitempty" db:"ip_address"`
+	CreatedAt      time.Time       `json:"createdAt" db:"created_at"`
+}
+
+type ListAuditLogRequest struct {
+	Page  int `query:"page" validate:"min=1"`
+	Limit int `query:"limit" validate:"min=1,max=100"`
+
+	ActorID    *uuid.UUID  `query:"actorId" validate:"omitempty"`
+	EntityType *EntityType `query:"entityType" validate:"omitempty,max=50"`
+	EntityID   *uuid.UUID  `query:"entityId" validate:"omitempty"`
+	Action     *Action     `query:"action" validate:"omitempty,max=50"`
+	RequestID  *string     `query:"requestId" validate:"omitempty,max=100"`
+
+	// RFC 3339, e.g. 2025-01-31T00:00:00Z
+	From *time.Time `query:"from" validate:"omitempty"`
+	To   *time.Time `query:"to" validate:"omitempty"`
+}
+
+func (r *ListAuditLogRequest) Validate() error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 50
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -1,6 +1,12 @@
 package auth
 
-import "github.com/go-playground/validator/v10"
+import (
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
 
 type GoogleIDTokenRequest struct {
 	// The ID token obtained from the native Google Sign-In SDK on the mobile device
@@ -20,3 +26,55 @@ func (l *LogoutRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(l)
 }
+
+// OIDC LOGIN / ACCOUNT LINKING
+
+// providerPattern is the auth_provider check from migration 008, matched
+// before the name is upper-cased.
+var providerPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+func validateProvider(provider string) error {
+	if !providerPattern.MatchString(provider) {
+		return errors.New("provider must start with a letter and contain only letters, digits and underscores")
+	}
+	return nil
+}
+
+type OIDCLoginRequest struct {
+	// provider name as configured, e.g. "google", "apple"
+	Provider string `param:"provider" validate:"required,max=50"`
+	IDToken  string `json:"id_token" validate:"required"`
+}
+
+func (r *OIDCLoginRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateProvider(r.Provider)
+}
+
+type UnlinkProviderRequest struct {
+	Provider string `param:"provider" validate:"required,max=50"`
+}
+
+func (r *UnlinkProviderRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return validateProvider(r.Provider)
+}
+
+type ListAuthMethodsRequest struct{}
+
+func (r *ListAuthMethodsRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type AuthMethodResponse struct {
+	Provider    string    `json:"provider"`
+	HasPassword bool      `json:"hasPassword"`
+	LinkedAt    time.Time `json:"linkedAt"`
+}
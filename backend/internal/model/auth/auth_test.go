@@ -0,0 +1,29 @@
+package auth
+
+import "testing"
+
+func TestOIDCLoginRequestProvider(t *testing.T) {
+	tests := []struct {
+		provider string
+		valid    bool
+	}{
+		{provider: "google", valid: true},
+		{provider: "APPLE", valid: true},
+		{provider: "azure_ad", valid: true},
+		{provider: "KEYCLOAK_2", valid: true},
+		{provider: "", valid: false},
+		{provider: "_google", valid: false},
+		{provider: "2fa", valid: false},
+		{provider: "azure-ad", valid: false},
+		{provider: "azure ad", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			req := &OIDCLoginRequest{Provider: tt.provider, IDToken: "token"}
+			if err := req.Validate(); (err == nil) != tt.valid {
+				t.Fatalf("Validate() with provider %q = %v, want valid %v", tt.provider, err, tt.valid)
+			}
+		})
+	}
+}
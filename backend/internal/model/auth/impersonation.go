@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// ImpersonationSession is one support session where an admin acts as a user.
+// Rows are never deleted, they double as the audit trail.
+type ImpersonationSession struct {
+	model.Base
+	AdminID      uuid.UUID  `json:"adminId" db:"admin_id"`
+	TargetUserID uuid.UUID  `json:"targetUserId" db:"target_user_id"`
+	Reason       string     `json:"reason" db:"reason"`
+	ReadOnly     bool       `json:"readOnly" db:"read_only"`
+	IPAddress    *string    `json:"ipAddress,omitempty" db:"ip_address"`
+	UserAgent    *string    `json:"userAgent,omitempty" db:"user_agent"`
+	ExpiresAt    time.Time  `json:"expiresAt" db:"expires_at"`
+	EndedAt      *time.Time `json:"endedAt,omitempty" db:"ended_at"`
+	EndedBy      *uuid.UUID `json:"endedBy,omitempty" db:"ended_by"`
+}
+
+func (s *ImpersonationSession) IsActive() bool {
+	return s.EndedAt == nil && time.Now().Before(s.ExpiresAt)
+}
+
+// =============================================
+// REQUESTS
+// =============================================
+
+type StartImpersonationRequest struct {
+	UserID uuid.UUID `param:"id" validate:"required"`
+	Reason string    `json:"reason" validate:"required,min=5,max=500"`
+	// impersonated sessions are read-only unless explicitly asked for
+	AllowWrite bool `json:"allowWrite"`
+	TTLMinutes int  `json:"ttlMinutes" validate:"omitempty,min=1,max=30"`
+}
+
+func (r *StartImpersonationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type StopImpersonationRequest struct {
+	SessionID uuid.UUID `param:"id" validate:"required"`
+}
+
+func (r *StopImpersonationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type StopCurrentImpersonationRequest struct{}
+
+func (r *StopCurrentImpersonationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ListImpersonationsRequest struct {
+	Page         int        `query:"page" validate:"min=1"`
+	Limit        int        `query:"limit" validate:"min=1,max=100"`
+	AdminID      *uuid.UUID `query:"adminId" validate:"omitempty"`
+	TargetUserID *uuid.UUID `query:"userId" validate:"omitempty"`
+	ActiveOnly   bool       `query:"activeOnly"`
+}
+
+func (r *ListImpersonationsRequest) Validate() error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// =============================================
+// RESPONSES
+// =============================================
+
+type ImpersonationResponse struct {
+	Session     ImpersonationSession `json:"session"`
+	AccessToken string               `json:"accessToken"`
+	ExpiresAt   time.Time            `json:"expiresAt"`
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type LoginEvent struct {
+	ID     uuid.UUID  `json:"id" db:"id"`
+	UserID *uuid.UUID `json:"userId,omitempty" db:"user_id"`
+	Email  *string    `json:"email,omitempty" db:"email"`
+
+	AuthProvider  string  `json:"authProvider" db:"auth_provider"`
+	Success       bool    `json:"success" db:"success"`
+	FailureReason *string `json:"failureReason,omitempty" db:"failure_reason"`
+
+	IPAddress         *string `json:"ipAddress,omitempty" db:"ip_address"`
+	UserAgent         *string `json:"userAgent,omitempty" db:"user_agent"`
+	DeviceFingerprint *string `json:"-" db:"device_fingerprint"`
+	Country           *string `json:"country,omitempty" db:"country"`
+	Region            *string `json:"region,omitempty" db:"region"`
+
+	IsNewDevice   bool `json:"isNewDevice" db:"is_new_device"`
+	IsNewLocation bool `json:"isNewLocation" db:"is_new_location"`
+
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// failure reasons kept coarse on purpose, they are shown to the account owner
+const (
+	LoginFailureInvalidCredentials = "INVALID_CREDENTIALS"
+	LoginFailureInvalidToken       = "INVALID_TOKEN"
+	LoginFailureUserInactive       = "USER_INACTIVE"
+	LoginFailureEmailNotVerified   = "EMAIL_NOT_VERIFIED"
+	LoginFailureInternal           = "INTERNAL_ERROR"
+)
+
+type ListLoginEventsRequest struct {
+	UserID      uuid.UUID `param:"id"`
+	Page        int       `query:"page" validate:"min=1"`
+	Limit       int       `query:"limit" validate:"min=1,max=100"`
+	Success     *bool     `query:"success"`
+	FlaggedOnly bool      `query:"flaggedOnly"`
+}
+
+func (r *ListLoginEventsRequest) Validate() error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -0,0 +1,194 @@
+package company
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// BusinessTimezone is what business hours and holidays are given in.
+var BusinessTimezone = time.FixedZone("IST", 5*60*60+30*60)
+
+// longest a vacation can be set for in one go
+const MaxVacationDays = 180
+
+type Weekday string
+
+const (
+	Monday    Weekday = "MON"
+	Tuesday   Weekday = "TUE"
+	Wednesday Weekday = "WED"
+	Thursday  Weekday = "THU"
+	Friday    Weekday = "FRI"
+	Saturday  Weekday = "SAT"
+	Sunday    Weekday = "SUN"
+)
+
+// indexed by time.Weekday
+var weekdays = []Weekday{Sunday, Monday, Tuesday, Wednesday, Thursday, Friday, Saturday}
+
+// at most this many open periods per day, e.g. a morning and evening shift
+const maxTimeRangesPerDay = 3
+
+// TimeRange is an open period within a day, "HH:MM" 24 hour clock.
+type TimeRange struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// BusinessHours maps a day to its open periods, a missing day is closed.
+type BusinessHours map[Weekday][]TimeRange
+
+func (h BusinessHours) Validate() error {
+	for day, ranges := range h {
+		if !isWeekday(day) {
+			return fmt.Errorf("unknown day: %s", day)
+		}
+		if len(ranges) > maxTimeRangesPerDay {
+			return fmt.Errorf("%s has more than %d open periods", day, maxTimeRangesPerDay)
+		}
+
+		lastClose := -1
+		for _, r := range ranges {
+			open, err := parseClock(r.Open)
+			if err != nil {
+				return fmt.Errorf("%s: %w", day, err)
+			}
+			closing, err := parseClock(r.Close)
+			if err != nil {
+				return fmt.Errorf("%s: %w", day, err)
+			}
+			if closing <= open {
+				return fmt.Errorf("%s: %s-%s closes before it opens", day, r.Open, r.Close)
+			}
+			if open <= lastClose {
+				return fmt.Errorf("%s: open periods must be in order and not overlap", day)
+			}
+			lastClose = closing
+		}
+	}
+	return nil
+}
+
+// IsOpenAt reports whether t falls in one of the day's open periods.
+func (h BusinessHours) IsOpenAt(t time.Time) bool {
+	t = t.In(BusinessTimezone)
+	minute := t.Hour()*60 + t.Minute()
+	for _, r := range h[weekdays[t.Weekday()]] {
+		open, _ := parseClock(r.Open)
+		closing, _ := parseClock(r.Close)
+		if minute >= open && minute < closing {
+			return true
+		}
+	}
+	return false
+}
+
+func isWeekday(day Weekday) bool {
+	for _, d := range weekdays {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock returns minutes since midnight, "24:00" is allowed as a close.
+func parseClock(v string) (int, error) {
+	if v == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, errors.New("times must be HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+type Holiday struct {
+	CompanyID uuid.UUID `json:"companyId" db:"company_id"`
+	Date      time.Time `json:"date" db:"holiday_date"`
+	Note      *string   `json:"note,omitempty" db:"note"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// AvailabilityResponse is everything a buyer needs to know about when the
+// seller is around.
+type AvailabilityResponse struct {
+	CompanyID     uuid.UUID     `json:"companyId"`
+	BusinessHours BusinessHours `json:"businessHours"`
+	Timezone      string        `json:"timezone"`
+	// nil when no business hours are set
+	OpenNow          *bool      `json:"openNow,omitempty"`
+	UpcomingHolidays []Holiday  `json:"upcomingHolidays"`
+	OnVacation       bool       `json:"onVacation"`
+	VacationUntil    *time.Time `json:"vacationUntil,omitempty"`
+	VacationMessage  *string    `json:"vacationMessage,omitempty"`
+}
+
+// =============================================
+// AVAILABILITY REQUESTS
+// =============================================
+
+type GetAvailabilityRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+}
+
+func (r *GetAvailabilityRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type UpdateBusinessHoursRequest struct {
+	CompanyID uuid.UUID     `param:"id" validate:"required"`
+	Hours     BusinessHours `json:"hours"`
+}
+
+func (r *UpdateBusinessHoursRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.Hours.Validate()
+}
+
+type HolidayInput struct {
+	Date string  `json:"date" validate:"required,datetime=2006-01-02"`
+	Note *string `json:"note,omitempty" validate:"omitempty,max=255"`
+}
+
+// UpdateHolidaysRequest replaces the upcoming holidays, past ones are kept.
+type UpdateHolidaysRequest struct {
+	CompanyID uuid.UUID      `param:"id" validate:"required"`
+	Holidays  []HolidayInput `json:"holidays" validate:"max=366,dive"`
+}
+
+func (r *UpdateHolidaysRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// StartVacationRequest takes the company's products offline until the
+// given time, a vacation already running is extended or shortened.
+type StartVacationRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+	Until     time.Time `json:"until" validate:"required"`
+	Message   *string   `json:"message,omitempty" validate:"omitempty,max=500"`
+}
+
+func (r *StartVacationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type EndVacationRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+}
+
+func (r *EndVacationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
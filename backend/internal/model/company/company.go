@@ -28,8 +28,10 @@ type Company struct {
 	OwnerID uuid.UUID `json:"ownerId" db:"owner_id"`
 
 	Name        string  `json:"name" db:"name"`
+	Slug        string  `json:"slug" db:"slug"`
 	Description *string `json:"description,omitempty" db:"description"`
 	LogoURL     *string `json:"logoUrl,omitempty" db:"logo_url"`
+	BannerURL   *string `json:"bannerUrl,omitempty" db:"banner_url"`
 
 	BusinessEmail *string `json:"businessEmail,omitempty" db:"business_email"`
 	BusinessPhone *string `json:"businessPhone,omitempty" db:"business_phone"`
@@ -50,9 +52,24 @@ type Company struct {
 	RejectionReason *string    `json:"rejectionReason,omitempty" db:"rejection_reason"`
 
 	IsActive bool `json:"isActive" db:"is_active"`
+	// set while user reports are under review
+	HiddenAt *time.Time `json:"hiddenAt,omitempty" db:"hidden_at"`
+
+	// admin suspension, IsActive is false while it lasts
+	SuspendedAt      *time.Time `json:"suspendedAt,omitempty" db:"suspended_at"`
+	SuspendedUntil   *time.Time `json:"suspendedUntil,omitempty" db:"suspended_until"`
+	SuspensionReason *string    `json:"suspensionReason,omitempty" db:"suspension_reason"`
 
 	FollowerCount     int               `json:"followerCount" db:"follower_count"`
 	ProductVisibility ProductVisibility `json:"productVisibility" db:"product_visibility"`
+	// new follows wait for the owner to accept them
+	ApproveFollowers bool `json:"approveFollowers" db:"approve_followers"`
+
+	BusinessHours BusinessHours `json:"businessHours" db:"business_hours"`
+	// products show as unavailable until VacationUntil, a sweep clears it
+	VacationStartedAt *time.Time `json:"vacationStartedAt,omitempty" db:"vacation_started_at"`
+	VacationUntil     *time.Time `json:"vacationUntil,omitempty" db:"vacation_until"`
+	VacationMessage   *string    `json:"vacationMessage,omitempty" db:"vacation_message"`
 }
 
 func (c *Company) IsPending() bool {
@@ -74,20 +91,58 @@ func (c *Company) CanBeFollowed() bool {
 	return c.IsApproved() && c.IsActive
 }
 
+func (c *Company) IsHidden() bool {
+	return c.HiddenAt != nil
+}
+
+func (c *Company) IsSuspended() bool {
+	return c.SuspendedAt != nil
+}
+
 func (c *Company) CanBeModified() bool {
 	// Can only modify PENDING or REJECTED companies
 	return c.IsPending() || c.IsRejected()
 }
 
+// IsOnVacation also covers the time between the end date and the sweep.
+func (c *Company) IsOnVacation() bool {
+	return c.VacationUntil != nil && c.VacationUntil.After(time.Now())
+}
+
 // COMPANY FOLLOWER MODEL
 
+type FollowStatus string
+
+const (
+	FollowStatusPending  FollowStatus = "PENDING"
+	FollowStatusAccepted FollowStatus = "ACCEPTED"
+)
+
 type CompanyFollower struct {
-	ID         uuid.UUID `json:"id" db:"id"`
-	CompanyID  uuid.UUID `json:"companyId" db:"company_id"`
-	UserID     uuid.UUID `json:"userId" db:"user_id"`
+	ID        uuid.UUID    `json:"id" db:"id"`
+	CompanyID uuid.UUID    `json:"companyId" db:"company_id"`
+	UserID    uuid.UUID    `json:"userId" db:"user_id"`
+	Status    FollowStatus `json:"status" db:"status"`
+	// request time while pending, acceptance time afterwards
 	FollowedAt time.Time `json:"followedAt" db:"followed_at"`
 }
 
+func (f *CompanyFollower) IsAccepted() bool {
+	return f.Status == FollowStatusAccepted
+}
+
+// BlockedUser is a user the company blocked, they cannot follow it or see
+// its non-public products.
+type BlockedUser struct {
+	CompanyID   uuid.UUID  `json:"companyId" db:"company_id"`
+	UserID      uuid.UUID  `json:"userId" db:"user_id"`
+	UserName    string     `json:"userName" db:"user_name"`
+	UserEmail   string     `json:"userEmail" db:"user_email"`
+	BlockedByID *uuid.UUID `json:"blockedById,omitempty" db:"blocked_by_id"`
+	Reason      *string    `json:"reason,omitempty" db:"reason"`
+	CreatedAt   time.Time  `json:"createdAt" db:"created_at"`
+}
+
 type ApprovalAction string
 
 const (
@@ -95,16 +150,44 @@ const (
 	ActionApproved    ApprovalAction = "APPROVED"
 	ActionRejected    ApprovalAction = "REJECTED"
 	ActionResubmitted ApprovalAction = "RESUBMITTED"
+
+	// only used for products, edits to an approved product go through a revision
+	ActionRevisionSubmitted ApprovalAction = "REVISION_SUBMITTED"
+	ActionRevisionApproved  ApprovalAction = "REVISION_APPROVED"
+	ActionRevisionRejected  ApprovalAction = "REVISION_REJECTED"
+
+	// only used for products, decisions taken by the pre-moderation rules
+	ActionAutoApproved ApprovalAction = "AUTO_APPROVED"
+	ActionAutoRejected ApprovalAction = "AUTO_REJECTED"
+	ActionAutoFlagged  ApprovalAction = "AUTO_FLAGGED"
+
+	// admin suspension of an approved company or product
+	ActionSuspended  ApprovalAction = "SUSPENDED"
+	ActionReinstated ApprovalAction = "REINSTATED"
 )
 
 type CompanyApprovalHistory struct {
-	ID            uuid.UUID      `json:"id" db:"id"`
-	CompanyID     uuid.UUID      `json:"companyId" db:"company_id"`
-	Action        ApprovalAction `json:"action" db:"action"`
-	PerformedByID uuid.UUID      `json:"performedById" db:"performed_by_id"`
-	Reason        *string        `json:"reason,omitempty" db:"reason"`
-	Notes         *string        `json:"notes,omitempty" db:"notes"`
-	CreatedAt     time.Time      `json:"createdAt" db:"created_at"`
+	ID        uuid.UUID      `json:"id" db:"id"`
+	CompanyID uuid.UUID      `json:"companyId" db:"company_id"`
+	Action    ApprovalAction `json:"action" db:"action"`
+	// nil when the system reinstated the company after a suspension ended
+	PerformedByID *uuid.UUID `json:"performedById,omitempty" db:"performed_by_id"`
+	Reason        *string    `json:"reason,omitempty" db:"reason"`
+	Notes         *string    `json:"notes,omitempty" db:"notes"`
+	// set on SUBMITTED / RESUBMITTED rows
+	Snapshot  map[string]any     `json:"snapshot,omitempty" db:"snapshot"`
+	Changes   model.FieldChanges `json:"changes,omitempty" db:"-"`
+	CreatedAt time.Time          `json:"createdAt" db:"created_at"`
 }
- 
 
+func (h *CompanyApprovalHistory) IsSubmission() bool {
+	return h.Action == ActionSubmitted || h.Action == ActionResubmitted
+}
+
+func (h *CompanyApprovalHistory) GetSnapshot() map[string]any {
+	return h.Snapshot
+}
+
+func (h *CompanyApprovalHistory) SetChanges(changes model.FieldChanges) {
+	h.Changes = changes
+}
@@ -9,9 +9,10 @@ import (
 )
 
 type CreateCompanyRequest struct {
-	Name        string  `json:"name" validate:"required,min=3,max=255"`
+	Name string `json:"name" validate:"required,min=3,max=255"`
+	// generated from the name when empty
+	Slug        *string `json:"slug,omitempty" validate:"omitempty,min=3,max=60"`
 	Description *string `json:"description,omitempty" validate:"omitempty,max=2048"`
-	LogoURL     *string `json:"logoUrl,omitempty" validate:"omitempty,url"`
 
 	BusinessEmail *string `json:"businessEmail,omitempty" validate:"omitempty,email"`
 	BusinessPhone *string `json:"businessPhone,omitempty" validate:"omitempty,min=10,max=15"`
@@ -34,7 +35,6 @@ type UpdateCompanyRequest struct {
 	ID          uuid.UUID `json:"id" validate:"required,uuid"`
 	Name        *string   `json:"name,omitempty" validate:"omitempty,min=3,max=255"`
 	Description *string   `json:"description,omitempty" validate:"omitempty,max=2048"`
-	LogoURL     *string   `json:"logoUrl,omitempty" validate:"omitempty,url"`
 
 	BusinessEmail *string `json:"businessEmail,omitempty" validate:"omitempty,email"`
 	BusinessPhone *string `json:"businessPhone,omitempty" validate:"omitempty,min=10,max=15"`
@@ -91,6 +91,27 @@ func (r *GetCompanyByIDRequest) Validate() error {
 	return validate.Struct(r)
 }
 
+type GetCompanyBySlugRequest struct {
+	Slug string `param:"slug" validate:"required,max=60"`
+}
+
+func (r *GetCompanyBySlugRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// UpdateSlugRequest changes the public slug, the old one keeps redirecting.
+// It does not go through re-approval.
+type UpdateSlugRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required,uuid"`
+	Slug      string    `json:"slug" validate:"required,min=3,max=60"`
+}
+
+func (r *UpdateSlugRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
 type DeleteCompanyRequest struct {
 	ID uuid.UUID `param:"id" validate:"required,uuid"`
 }
@@ -139,6 +160,30 @@ func (r *RejectCompanyRequest) Validate() error {
 	return validate.Struct(r)
 }
 
+// SuspendCompanyRequest takes an approved company offline. Without Until
+// the suspension lasts until an admin reinstates it.
+type SuspendCompanyRequest struct {
+	CompanyID uuid.UUID  `param:"id" validate:"required,uuid"`
+	Reason    string     `json:"reason" validate:"required,max=255"`
+	Until     *time.Time `json:"until,omitempty" validate:"omitempty"`
+	Notes     *string    `json:"notes,omitempty" validate:"omitempty,max=2048"`
+}
+
+func (r *SuspendCompanyRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ReinstateCompanyRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required,uuid"`
+	Notes     *string   `json:"notes,omitempty" validate:"omitempty,max=2048"`
+}
+
+func (r *ReinstateCompanyRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
 type CountPendingApprovalsRequest struct {
 }
 
@@ -191,6 +236,47 @@ func (q *ListFollowersQuery) Validate() error {
 	return nil
 }
 
+// FOLLOWER SETTINGS
+
+// UpdateFollowerSettingsRequest can be sent for approved companies too, it
+// does not go through re-approval.
+type UpdateFollowerSettingsRequest struct {
+	CompanyID        uuid.UUID `param:"id" validate:"required,uuid"`
+	ApproveFollowers *bool     `json:"approveFollowers" validate:"required"`
+}
+
+func (r *UpdateFollowerSettingsRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// FOLLOW REQUESTS / FOLLOWER MANAGEMENT
+
+// FollowerActionRequest accepts, rejects, removes or blocks one follower.
+type FollowerActionRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required,uuid"`
+	UserID    uuid.UUID `param:"userId" validate:"required,uuid"`
+}
+
+func (r *FollowerActionRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// BLOCK LIST
+
+// BlockUserRequest blocks any user, follower or not.
+type BlockUserRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required,uuid"`
+	UserID    uuid.UUID `param:"userId" validate:"required,uuid"`
+	Reason    *string   `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+func (r *BlockUserRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
 // LIST FOLLOWING QUERY
 type ListFollowedCompaniesQuery struct {
 	// CompanyID uuid.UUID `param:"id" validate:"required,uuid"`
@@ -221,8 +307,10 @@ type CompanyResponse struct {
 	ID          uuid.UUID `json:"id"`
 	OwnerID     uuid.UUID `json:"ownerId"`
 	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
 	Description *string   `json:"description,omitempty"`
 	LogoURL     *string   `json:"logoUrl,omitempty"`
+	BannerURL   *string   `json:"bannerUrl,omitempty"`
 
 	BusinessEmail *string `json:"businessEmail,omitempty"`
 	BusinessPhone *string `json:"businessPhone,omitempty"`
@@ -235,38 +323,56 @@ type CompanyResponse struct {
 	ApprovedBy *uuid.UUID `json:"approvedBy,omitempty"`
 	ApprovedAt *time.Time `json:"approvedAt,omitempty"`
 	IsActive   bool       `json:"isActive"`
+	HiddenAt   *time.Time `json:"hiddenAt,omitempty"`
+
+	SuspendedAt      *time.Time `json:"suspendedAt,omitempty"`
+	SuspendedUntil   *time.Time `json:"suspendedUntil,omitempty"`
+	SuspensionReason *string    `json:"suspensionReason,omitempty"`
 
 	FollowerCount     int               `json:"followerCount"`
 	ProductVisibility ProductVisibility `json:"productVisibility"`
+	ApproveFollowers  bool              `json:"approveFollowers"`
 	IsFollowing       *bool             `json:"isFollowing,omitempty"`
-	CreatedAt         time.Time         `json:"createdAt"`
-	UpdatedAt         time.Time         `json:"updatedAt"`
+
+	BusinessHours   BusinessHours `json:"businessHours"`
+	OnVacation      bool          `json:"onVacation"`
+	VacationUntil   *time.Time    `json:"vacationUntil,omitempty"`
+	VacationMessage *string       `json:"vacationMessage,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 }
 
 type CompanyFollowerResponse struct {
-	ID         uuid.UUID `json:"id"`
-	CompanyID  uuid.UUID `json:"companyId"`
-	UserID     uuid.UUID `json:"userId"`
-	UserName   string    `json:"userName"`
-	UserEmail  string    `json:"userEmail"`
-	FollowedAt time.Time `json:"followedAt"`
+	ID         uuid.UUID    `json:"id"`
+	CompanyID  uuid.UUID    `json:"companyId"`
+	UserID     uuid.UUID    `json:"userId"`
+	UserName   string       `json:"userName"`
+	UserEmail  string       `json:"userEmail"`
+	Status     FollowStatus `json:"status"`
+	FollowedAt time.Time    `json:"followedAt"`
 }
 
+// IsFollowing is only true for accepted follows, a request waiting for the
+// owner shows up as IsPending.
 type FollowStatusResponse struct {
 	CompanyID   uuid.UUID  `json:"companyId"`
 	IsFollowing bool       `json:"isFollowing"`
+	IsPending   bool       `json:"isPending"`
 	FollowedAt  *time.Time `json:"followedAt,omitempty"`
 }
 
 //MAPPERS
 
 func ToCompanyResponse(c *Company, isFollowing *bool) *CompanyResponse {
-	return &CompanyResponse{
+	resp := &CompanyResponse{
 		ID:                c.ID,
 		OwnerID:           c.OwnerID,
 		Name:              c.Name,
+		Slug:              c.Slug,
 		Description:       c.Description,
 		LogoURL:           c.LogoURL,
+		BannerURL:         c.BannerURL,
 		BusinessEmail:     c.BusinessEmail,
 		BusinessPhone:     c.BusinessPhone,
 		City:              c.City,
@@ -276,12 +382,24 @@ func ToCompanyResponse(c *Company, isFollowing *bool) *CompanyResponse {
 		ApprovedBy:        c.ReviewedByID,
 		ApprovedAt:        c.ReviewedAt,
 		IsActive:          c.IsActive,
+		HiddenAt:          c.HiddenAt,
+		SuspendedAt:       c.SuspendedAt,
+		SuspendedUntil:    c.SuspendedUntil,
+		SuspensionReason:  c.SuspensionReason,
 		FollowerCount:     c.FollowerCount,
 		ProductVisibility: c.ProductVisibility,
+		ApproveFollowers:  c.ApproveFollowers,
 		IsFollowing:       isFollowing,
+		BusinessHours:     c.BusinessHours,
 		CreatedAt:         c.CreatedAt,
 		UpdatedAt:         c.UpdatedAt,
 	}
+	if c.IsOnVacation() {
+		resp.OnVacation = true
+		resp.VacationUntil = c.VacationUntil
+		resp.VacationMessage = c.VacationMessage
+	}
+	return resp
 }
 
 func MapCompanyPage(page *model.PaginatedResponse[Company], followStatusMap map[uuid.UUID]bool) *model.PaginatedResponse[CompanyResponse] {
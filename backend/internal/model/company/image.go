@@ -0,0 +1,162 @@
+package company
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ImageKind string
+
+const (
+	ImageKindLogo   ImageKind = "LOGO"
+	ImageKindBanner ImageKind = "BANNER"
+)
+
+type ImageStatus string
+
+const (
+	ImageStatusUploading ImageStatus = "UPLOADING"
+	ImageStatusPending   ImageStatus = "PENDING"
+	ImageStatusActive    ImageStatus = "ACTIVE"
+	ImageStatusRejected  ImageStatus = "REJECTED"
+	ImageStatusReplaced  ImageStatus = "REPLACED"
+)
+
+// ImageSpec is what an uploaded logo or banner has to satisfy.
+type ImageSpec struct {
+	MinWidth  int
+	MinHeight int
+	MaxWidth  int
+	MaxHeight int
+	// width / height
+	MinAspect float64
+	MaxAspect float64
+	MaxBytes  int64
+}
+
+var ImageSpecs = map[ImageKind]ImageSpec{
+	// roughly square
+	ImageKindLogo: {
+		MinWidth: 128, MinHeight: 128,
+		MaxWidth: 2048, MaxHeight: 2048,
+		MinAspect: 0.8, MaxAspect: 1.25,
+		MaxBytes: 2 << 20,
+	},
+	// wide strip across the top of the storefront
+	ImageKindBanner: {
+		MinWidth: 1200, MinHeight: 300,
+		MaxWidth: 4096, MaxHeight: 2048,
+		MinAspect: 2, MaxAspect: 6,
+		MaxBytes: 5 << 20,
+	},
+}
+
+type CompanyImage struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	CompanyID    uuid.UUID `json:"companyId" db:"company_id"`
+	UploadedByID uuid.UUID `json:"uploadedById" db:"uploaded_by_id"`
+
+	Kind   ImageKind   `json:"kind" db:"kind"`
+	Status ImageStatus `json:"status" db:"status"`
+
+	S3Key       string `json:"-" db:"s3_key"`
+	ImageURL    string `json:"imageUrl" db:"image_url"`
+	ContentType string `json:"contentType" db:"content_type"`
+	SizeBytes   *int64 `json:"sizeBytes,omitempty" db:"size_bytes"`
+	Width       *int   `json:"width,omitempty" db:"width"`
+	Height      *int   `json:"height,omitempty" db:"height"`
+
+	ReviewedByID    *uuid.UUID `json:"reviewedById,omitempty" db:"reviewed_by_id"`
+	ReviewedAt      *time.Time `json:"reviewedAt,omitempty" db:"reviewed_at"`
+	RejectionReason *string    `json:"rejectionReason,omitempty" db:"rejection_reason"`
+
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// PendingCompanyImage is a logo in the admin review queue.
+type PendingCompanyImage struct {
+	CompanyImage
+	CompanyName    string  `json:"companyName" db:"company_name"`
+	CurrentLogoURL *string `json:"currentLogoUrl,omitempty" db:"current_logo_url"`
+}
+
+// =============================================
+// IMAGE REQUESTS
+// =============================================
+
+type GenerateCompanyImageUploadURLRequest struct {
+	CompanyID   uuid.UUID `param:"id" validate:"required"`
+	Kind        ImageKind `json:"kind" validate:"required,oneof=LOGO BANNER"`
+	ContentType string    `json:"contentType" validate:"required,oneof=image/jpeg image/png image/webp"`
+}
+
+func (r *GenerateCompanyImageUploadURLRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type CompanyImageUploadURLResponse struct {
+	UploadURL string    `json:"uploadUrl"`
+	ImageID   uuid.UUID `json:"imageId"`
+	S3Key     string    `json:"s3Key"`
+	ExpiresIn int       `json:"expiresIn"` // seconds
+}
+
+type ListCompanyImagesRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+}
+
+func (r *ListCompanyImagesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// ConfirmCompanyImageRequest is sent once the file is in S3.
+type ConfirmCompanyImageRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+	ImageID   uuid.UUID `param:"imageId" validate:"required"`
+}
+
+func (r *ConfirmCompanyImageRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ListPendingCompanyImagesRequest struct {
+	Page  int `query:"page" validate:"min=1"`
+	Limit int `query:"limit" validate:"min=1,max=100"`
+}
+
+func (r *ListPendingCompanyImagesRequest) Validate() error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ApproveCompanyImageRequest struct {
+	ImageID uuid.UUID `param:"imageId" validate:"required,uuid"`
+}
+
+func (r *ApproveCompanyImageRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type RejectCompanyImageRequest struct {
+	ImageID uuid.UUID `param:"imageId" validate:"required,uuid"`
+	Reason  string    `json:"reason" validate:"required,min=10,max=500"`
+}
+
+func (r *RejectCompanyImageRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
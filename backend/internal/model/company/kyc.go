@@ -0,0 +1,145 @@
+package company
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type KYCDocumentType string
+
+const (
+	KYCDocumentGSTCertificate KYCDocumentType = "GST_CERTIFICATE"
+	KYCDocumentPANCard        KYCDocumentType = "PAN_CARD"
+	KYCDocumentFSSAILicence   KYCDocumentType = "FSSAI_LICENCE"
+)
+
+type KYCDocumentStatus string
+
+const (
+	KYCDocumentStatusUploading KYCDocumentStatus = "UPLOADING"
+	KYCDocumentStatusUploaded  KYCDocumentStatus = "UPLOADED"
+)
+
+// largest KYC file accepted on confirm
+const MaxKYCDocumentBytes int64 = 10 << 20
+
+type KYCDocument struct {
+	ID           uuid.UUID `json:"id" db:"id"`
+	CompanyID    uuid.UUID `json:"companyId" db:"company_id"`
+	UploadedByID uuid.UUID `json:"uploadedById" db:"uploaded_by_id"`
+
+	DocumentType   KYCDocumentType `json:"documentType" db:"document_type"`
+	DocumentNumber *string         `json:"documentNumber,omitempty" db:"document_number"`
+
+	S3Key       string `json:"-" db:"s3_key"`
+	FileName    string `json:"fileName" db:"file_name"`
+	ContentType string `json:"contentType" db:"content_type"`
+
+	// only UPLOADED documents are listed and count for review
+	Status    KYCDocumentStatus `json:"status" db:"status"`
+	SizeBytes *int64            `json:"sizeBytes,omitempty" db:"size_bytes"`
+
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// =============================================
+// KYC REQUESTS
+// =============================================
+
+type GenerateKYCUploadURLRequest struct {
+	CompanyID      uuid.UUID       `param:"id" validate:"required"`
+	DocumentType   KYCDocumentType `json:"documentType" validate:"required,oneof=GST_CERTIFICATE PAN_CARD FSSAI_LICENCE"`
+	DocumentNumber *string         `json:"documentNumber,omitempty" validate:"omitempty,max=50"`
+	FileName       string          `json:"fileName" validate:"required,max=255"`
+	ContentType    string          `json:"contentType" validate:"required,oneof=application/pdf image/jpeg image/png"`
+}
+
+func (r *GenerateKYCUploadURLRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ListKYCDocumentsRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+}
+
+func (r *ListKYCDocumentsRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// ConfirmKYCDocumentRequest is sent once the file is in S3.
+type ConfirmKYCDocumentRequest struct {
+	CompanyID  uuid.UUID `param:"id" validate:"required"`
+	DocumentID uuid.UUID `param:"documentId" validate:"required"`
+}
+
+func (r *ConfirmKYCDocumentRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type DeleteKYCDocumentRequest struct {
+	CompanyID  uuid.UUID `param:"id" validate:"required"`
+	DocumentID uuid.UUID `param:"documentId" validate:"required"`
+}
+
+func (r *DeleteKYCDocumentRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type GetCompanyReviewRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+}
+
+func (r *GetCompanyReviewRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// =============================================
+// KYC RESPONSES
+// =============================================
+
+type KYCUploadURLResponse struct {
+	UploadURL  string    `json:"uploadUrl"`
+	DocumentID uuid.UUID `json:"documentId"`
+	S3Key      string    `json:"s3Key"`
+	ExpiresIn  int       `json:"expiresIn"`
+}
+
+type KYCDocumentResponse struct {
+	KYCDocument
+	// short lived, the KYC prefix is never public
+	DownloadURL string `json:"downloadUrl"`
+}
+
+// TaxIDCheck is the result of the GSTIN / PAN checks shown to reviewers.
+type TaxIDCheck struct {
+	GSTNumber    *string  `json:"gstNumber,omitempty"`
+	PANNumber    *string  `json:"panNumber,omitempty"`
+	GSTINValid   *bool    `json:"gstinValid,omitempty"`
+	PANValid     *bool    `json:"panValid,omitempty"`
+	StateMatches *bool    `json:"stateMatches,omitempty"`
+	PANMatches   *bool    `json:"panMatches,omitempty"`
+	Errors       []string `json:"errors"`
+}
+
+func (t *TaxIDCheck) OK() bool {
+	return len(t.Errors) == 0
+}
+
+// CompanyReviewResponse is everything an admin needs before ApproveCompany.
+type CompanyReviewResponse struct {
+	Company         CompanyResponse          `json:"company"`
+	ApprovalStatus  ApprovalStatus           `json:"approvalStatus"`
+	RejectionReason *string                  `json:"rejectionReason,omitempty"`
+	TaxIDs          TaxIDCheck               `json:"taxIds"`
+	KYCDocuments    []KYCDocumentResponse    `json:"kycDocuments"`
+	MissingKYC      []KYCDocumentType        `json:"missingKyc"`
+	History         []CompanyApprovalHistory `json:"history"`
+}
@@ -0,0 +1,65 @@
+package company
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type ServiceAreaType string
+
+const (
+	ServiceAreaPincode       ServiceAreaType = "PINCODE"
+	ServiceAreaPincodePrefix ServiceAreaType = "PINCODE_PREFIX"
+	ServiceAreaState         ServiceAreaType = "STATE"
+)
+
+type ServiceArea struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	CompanyID uuid.UUID       `json:"companyId" db:"company_id"`
+	Type      ServiceAreaType `json:"type" db:"area_type"`
+	// pincode, prefix or GST state code
+	Value     string    `json:"value" db:"value"`
+	Label     *string   `json:"label,omitempty" db:"label"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+}
+
+// ServiceAreaLookup is every area value a single pincode matches.
+type ServiceAreaLookup struct {
+	Pincode   string
+	Prefixes  []string
+	StateCode *string
+}
+
+// =============================================
+// SERVICE AREA REQUESTS
+// =============================================
+
+type ServiceAreaInput struct {
+	Type ServiceAreaType `json:"type" validate:"required,oneof=PINCODE PINCODE_PREFIX STATE"`
+	// a state can be given by name or GST state code
+	Value string `json:"value" validate:"required,max=100"`
+}
+
+type ListServiceAreasRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+}
+
+func (r *ListServiceAreasRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// UpdateServiceAreasRequest replaces the whole list, an empty list means
+// the company delivers everywhere.
+type UpdateServiceAreasRequest struct {
+	CompanyID uuid.UUID `param:"id" validate:"required"`
+	// prefixes and states cover larger regions than this allows
+	Areas []ServiceAreaInput `json:"areas" validate:"max=1000,dive"`
+}
+
+func (r *UpdateServiceAreasRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// FieldChange is one changed field, keyed by its json name in FieldChanges.
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type FieldChanges map[string]FieldChange
+
+// Diff compares two values through their json form and returns the fields
+// that differ. Both values should be of the same struct type.
+func Diff(before, after any) (FieldChanges, error) {
+	from, err := toJSONMap(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := toJSONMap(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := FieldChanges{}
+	for key, old := range from {
+		if val, ok := to[key]; !ok || !reflect.DeepEqual(old, val) {
+			changes[key] = FieldChange{From: old, To: to[key]}
+		}
+	}
+	for key, val := range to {
+		if _, ok := from[key]; !ok {
+			changes[key] = FieldChange{From: nil, To: val}
+		}
+	}
+	return changes, nil
+}
+
+func toJSONMap(v any) (map[string]any, error) {
+	if v == nil {
+		return map[string]any{}, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]any{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type diffSubject struct {
+	Name    string   `json:"name"`
+	Price   float64  `json:"price"`
+	Tags    []string `json:"tags"`
+	Note    *string  `json:"note,omitempty"`
+	private string
+}
+
+func TestDiff(t *testing.T) {
+	note := "fresh stock"
+
+	tests := []struct {
+		name   string
+		before any
+		after  any
+		want   FieldChanges
+	}{
+		{
+			name:   "no changes",
+			before: diffSubject{Name: "Wheat", Price: 24, Tags: []string{"grain"}},
+			after:  diffSubject{Name: "Wheat", Price: 24, Tags: []string{"grain"}},
+			want:   FieldChanges{},
+		},
+		{
+			name:   "changed fields only",
+			before: diffSubject{Name: "Wheat", Price: 24, Tags: []string{"grain"}},
+			after:  diffSubject{Name: "Wheat", Price: 26.5, Tags: []string{"grain", "organic"}},
+			want: FieldChanges{
+				"price": {From: 24.0, To: 26.5},
+				"tags":  {From: []any{"grain"}, To: []any{"grain", "organic"}},
+			},
+		},
+		{
+			name:   "omitted field added",
+			before: diffSubject{Name: "Wheat"},
+			after:  diffSubject{Name: "Wheat", Note: &note},
+			want:   FieldChanges{"note": {From: nil, To: "fresh stock"}},
+		},
+		{
+			name:   "omitted field removed",
+			before: diffSubject{Name: "Wheat", Note: &note},
+			after:  diffSubject{Name: "Wheat"},
+			want:   FieldChanges{"note": {From: "fresh stock", To: nil}},
+		},
+		{
+			name:   "unexported fields ignored",
+			before: diffSubject{Name: "Wheat", private: "a"},
+			after:  diffSubject{Name: "Wheat", private: "b"},
+			want:   FieldChanges{},
+		},
+		{
+			name:   "nil before",
+			before: nil,
+			after:  json.RawMessage(`{"name":"Wheat"}`),
+			want:   FieldChanges{"name": {From: nil, To: "Wheat"}},
+		},
+		{
+			name:   "raw snapshots",
+			before: json.RawMessage(`{"name":"Wheat","price":24}`),
+			after:  json.RawMessage(`{"name":"Durum Wheat","price":24}`),
+			want:   FieldChanges{"name": {From: "Wheat", To: "Durum Wheat"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Diff() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffRejectsNonObjects(t *testing.T) {
+	if _, err := Diff([]string{"a"}, []string{"b"}); err == nil {
+		t.Fatal("expected an error diffing non object values")
+	}
+}
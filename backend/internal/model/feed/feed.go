@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type EventType string
+
+const (
+	EventNewProduct  EventType = "NEW_PRODUCT"
+	EventPriceDrop   EventType = "PRICE_DROP"
+	EventBackInStock EventType = "BACK_IN_STOCK"
+)
+
+// Event is one product_feed_events row with the names needed to show it.
+type Event struct {
+	ID           uuid.UUID        `json:"id" db:"id"`
+	Type         EventType        `json:"type" db:"event"`
+	ProductID    uuid.UUID        `json:"productId" db:"product_id"`
+	CompanyID    uuid.UUID        `json:"companyId" db:"company_id"`
+	CompanyName  string           `json:"companyName" db:"company_name"`
+	VariantID    *uuid.UUID       `json:"variantId,omitempty" db:"variant_id"`
+	VariantLabel *string          `json:"variantLabel,omitempty" db:"variant_label"`
+	OldPrice     *decimal.Decimal `json:"oldPrice,omitempty" db:"old_price"`
+	NewPrice     *decimal.Decimal `json:"newPrice,omitempty" db:"new_price"`
+	CreatedAt    time.Time        `json:"createdAt" db:"created_at"`
+}
+
+type Item struct {
+	Event
+	Product *product.ProductResponse `json:"product,omitempty"`
+}
+
+// Response holds one page, NextCursor is empty on the last one.
+type Response struct {
+	Data       []Item `json:"data"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// =============================================
+// CURSOR
+// =============================================
+
+// Cursor points at the last event of a page, the next page starts after it.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (c Cursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errors.New("invalid cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	eventID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &Cursor{CreatedAt: createdAt, ID: eventID}, nil
+}
+
+// =============================================
+// REQUESTS
+// =============================================
+
+type GetFeedRequest struct {
+	// nextCursor of the previous page, empty for the first
+	Cursor string `query:"cursor" validate:"omitempty,max=200"`
+	Limit  int    `query:"limit" validate:"min=1,max=100"`
+}
+
+func (r *GetFeedRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -0,0 +1,59 @@
+package feed
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{name: "utc", cursor: Cursor{CreatedAt: time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC), ID: uuid.New()}},
+		{name: "nanoseconds kept", cursor: Cursor{CreatedAt: time.Date(2025, 3, 14, 9, 30, 0, 123456789, time.UTC), ID: uuid.New()}},
+		{name: "other zone", cursor: Cursor{CreatedAt: time.Date(2025, 3, 14, 15, 0, 0, 0, ist), ID: uuid.New()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.CreatedAt.Equal(tt.cursor.CreatedAt) || got.ID != tt.cursor.ID {
+				t.Fatalf("DecodeCursor(Encode()) = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "no separator", cursor: encode("2025-03-14T09:30:00Z")},
+		{name: "bad time", cursor: encode("yesterday|" + uuid.NewString())},
+		{name: "bad id", cursor: encode("2025-03-14T09:30:00Z|42")},
+		{name: "empty", cursor: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor); err == nil {
+				t.Fatalf("expected DecodeCursor(%q) to fail", tt.cursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package moderation
+
+import (
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type EntityType string
+
+const (
+	EntityCompany EntityType = "COMPANY"
+	EntityProduct EntityType = "PRODUCT"
+)
+
+// Claim is a reviewer's lock on a pending company or product.
+// It stops counting once ExpiresAt passes, no cleanup job needed.
+type Claim struct {
+	model.Base
+	EntityType   EntityType `json:"entityType" db:"entity_type"`
+	EntityID     uuid.UUID  `json:"entityId" db:"entity_id"`
+	ReviewerID   uuid.UUID  `json:"reviewerId" db:"reviewer_id"`
+	AssignedByID *uuid.UUID `json:"assignedById,omitempty" db:"assigned_by_id"`
+	ExpiresAt    time.Time  `json:"expiresAt" db:"expires_at"`
+}
+
+func (c *Claim) IsActive() bool {
+	return time.Now().Before(c.ExpiresAt)
+}
+
+func (c *Claim) IsHeldBy(reviewerID uuid.UUID) bool {
+	return c.IsActive() && c.ReviewerID == reviewerID
+}
+
+// =============================================
+// QUEUE ITEMS
+// =============================================
+
+type CompanyQueueItem struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	OwnerID           uuid.UUID  `json:"ownerId" db:"owner_id"`
+	Name              string     `json:"name" db:"name"`
+	City              *string    `json:"city,omitempty" db:"city"`
+	State             *string    `json:"state,omitempty" db:"state"`
+	SubmittedAt       time.Time  `json:"submittedAt" db:"submitted_at"`
+	WaitingMinutes    int        `json:"waitingMinutes" db:"waiting_minutes"`
+	ResubmissionCount int        `json:"resubmissionCount" db:"resubmission_count"`
+	ReviewerID        *uuid.UUID `json:"reviewerId,omitempty" db:"reviewer_id"`
+	ClaimExpiresAt    *time.Time `json:"claimExpiresAt,omitempty" db:"claim_expires_at"`
+}
+
+type ProductQueueItem struct {
+	ID                uuid.UUID  `json:"id" db:"id"`
+	CompanyID         uuid.UUID  `json:"companyId" db:"company_id"`
+	CompanyName       string     `json:"companyName" db:"company_name"`
+	CategoryID        *uuid.UUID `json:"categoryId,omitempty" db:"category_id"`
+	CategoryName      *string    `json:"categoryName,omitempty" db:"category_name"`
+	Name              string     `json:"name" db:"name"`
+	State             *string    `json:"state,omitempty" db:"state"`
+	SubmittedAt       time.Time  `json:"submittedAt" db:"submitted_at"`
+	WaitingMinutes    int        `json:"waitingMinutes" db:"waiting_minutes"`
+	ResubmissionCount int        `json:"resubmissionCount" db:"resubmission_count"`
+	// a pre-moderation rule flagged the current submission
+	Flagged        bool       `json:"flagged" db:"flagged"`
+	ReviewerID     *uuid.UUID `json:"reviewerId,omitempty" db:"reviewer_id"`
+	ClaimExpiresAt *time.Time `json:"claimExpiresAt,omitempty" db:"claim_expires_at"`
+}
+
+// QueueSLA is computed over the whole filtered queue, not only the current page.
+type QueueSLA struct {
+	TargetHours          int        `json:"targetHours"`
+	OldestSubmittedAt    *time.Time `json:"oldestSubmittedAt,omitempty"`
+	OldestWaitingMinutes int        `json:"oldestWaitingMinutes"`
+	BreachedCount        int        `json:"breachedCount"`
+}
+
+type QueueResponse[T any] struct {
+	model.PaginatedResponse[T]
+	SLA QueueSLA `json:"sla"`
+}
+
+// =============================================
+// REQUESTS
+// =============================================
+
+type ListQueueRequest struct {
+	Page  int `query:"page" validate:"min=1"`
+	Limit int `query:"limit" validate:"min=1,max=100"`
+
+	// age is measured from submitted_at, in hours
+	MinAgeHours *int `query:"minAgeHours" validate:"omitempty,min=0"`
+	MaxAgeHours *int `query:"maxAgeHours" validate:"omitempty,min=0"`
+
+	// only used by the product queue
+	CategoryID *uuid.UUID `query:"categoryId" validate:"omitempty"`
+	Flagged    *bool      `query:"flagged"`
+	State      *string    `query:"state" validate:"omitempty,max=100"`
+
+	MinResubmissions *int `query:"minResubmissions" validate:"omitempty,min=0"`
+	MaxResubmissions *int `query:"maxResubmissions" validate:"omitempty,min=0"`
+
+	ReviewerID *uuid.UUID `query:"reviewerId" validate:"omitempty"`
+	Unclaimed  bool       `query:"unclaimed"`
+}
+
+func (r *ListQueueRequest) Validate() error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ClaimRequest struct {
+	ID         uuid.UUID `param:"id" validate:"required"`
+	TTLMinutes int       `json:"ttlMinutes" validate:"omitempty,min=1,max=240"`
+}
+
+func (r *ClaimRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type AssignRequest struct {
+	ID         uuid.UUID `param:"id" validate:"required"`
+	ReviewerID uuid.UUID `json:"reviewerId" validate:"required"`
+	TTLMinutes int       `json:"ttlMinutes" validate:"omitempty,min=1,max=240"`
+}
+
+func (r *AssignRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ReleaseClaimRequest struct {
+	ID uuid.UUID `param:"id" validate:"required"`
+}
+
+func (r *ReleaseClaimRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// =============================================
+// BULK DECISIONS
+// =============================================
+
+type BulkApproveRequest struct {
+	IDs   []uuid.UUID `json:"ids" validate:"required,min=1,max=100,dive,required"`
+	Notes *string     `json:"notes,omitempty" validate:"omitempty,max=2048"`
+}
+
+func (r *BulkApproveRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type BulkRejectRequest struct {
+	IDs    []uuid.UUID `json:"ids" validate:"required,min=1,max=100,dive,required"`
+	Reason string      `json:"reason" validate:"required,max=255"`
+	Notes  *string     `json:"notes,omitempty" validate:"omitempty,max=2048"`
+}
+
+func (r *BulkRejectRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type BulkItemResult struct {
+	ID      uuid.UUID `json:"id"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+}
+
+type BulkResult struct {
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
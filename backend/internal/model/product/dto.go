@@ -14,13 +14,16 @@ import (
 //CREATE PRODUCT
 
 type CreateProductRequest struct {
-	CompanyID   uuid.UUID       `json:"companyId" validate:"required,uuid"`
+	// defaults to the request's active company, or the owner's only one
+	CompanyID   *uuid.UUID      `json:"companyId,omitempty" validate:"omitempty,uuid"`
 	CategoryID  *uuid.UUID      `json:"categoryId,omitempty" validate:"omitempty,uuid"`
 	Name        string          `json:"name" validate:"required,min=3,max=255"`
 	Description *string         `json:"description,omitempty" validate:"omitempty,max=2000"`
 	Unit        string          `json:"unit" validate:"required,max=50"`
 	Origin      *string         `json:"origin,omitempty" validate:"omitempty,max=100"`
 	BasePrice   decimal.Decimal `json:"basePrice" validate:"required,gt=0"`
+	// leave empty to follow the company's product visibility
+	Visibility *company.ProductVisibility `json:"visibility,omitempty" validate:"omitempty,oneof=PUBLIC FOLLOWERS_ONLY PRIVATE"`
 
 	Variants []CreateVariantInput `json:"variants" validate:"required,min=1,dive"`
 }
@@ -58,6 +61,20 @@ func (r *UpdateProductRequest) Validate() error {
 	return validate.Struct(r)
 }
 
+// PRODUCT VISIBILITY
+
+// UpdateProductVisibilityRequest sets or, with a null visibility, clears
+// the override. It applies right away, also to approved products.
+type UpdateProductVisibilityRequest struct {
+	ID         uuid.UUID                  `param:"id" validate:"required,uuid"`
+	Visibility *company.ProductVisibility `json:"visibility" validate:"omitempty,oneof=PUBLIC FOLLOWERS_ONLY PRIVATE"`
+}
+
+func (r *UpdateProductVisibilityRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
 // LIST PRODUCT
 
 // type ListProductsQuery struct {
@@ -77,6 +94,8 @@ type ListProductsQuery struct {
 	Search         *string                 `query:"search" validate:"omitempty,min=1"`
 	ApprovalStatus *company.ApprovalStatus `query:"approvalStatus" validate:"omitempty"`
 	IsActive       *bool                   `query:"isActive" validate:"omitempty"`
+	// pincode the company has to deliver to
+	DeliversTo *string `query:"deliversTo" validate:"omitempty,len=6,numeric"`
 }
 
 func (q *ListProductsQuery) Validate() error {
@@ -166,7 +185,7 @@ func (r *CreateVariantRequest) Validate() error {
 }
 
 type UpdateVariantRequest struct {
-	ProductID uuid.UUID `param:"productId" validate:"required,uuid"`
+	ProductID uuid.UUID `param:"id" validate:"required,uuid"`
 	VariantID uuid.UUID `param:"variantId" validate:"required,uuid"`
 
 	// SKU           *string          `json:"sku,omitempty" validate:"omitempty,max=50"`
@@ -185,6 +204,22 @@ func (r *UpdateVariantRequest) Validate() error {
 	return validate.Struct(r)
 }
 
+// UpdateVariantStockRequest only touches stock fields, it is what
+// ERP syncs with the variants:stock scope are allowed to send.
+type UpdateVariantStockRequest struct {
+	ProductID uuid.UUID `param:"id" validate:"required,uuid"`
+	VariantID uuid.UUID `param:"variantId" validate:"required,uuid"`
+
+	StockQuantity     *int  `json:"stockQuantity" validate:"required,gte=0"`
+	LowStockThreshold *int  `json:"lowStockThreshold,omitempty" validate:"omitempty,gte=0"`
+	IsAvailable       *bool `json:"isAvailable,omitempty"`
+}
+
+func (r *UpdateVariantStockRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
 type DeleteVariantRequest struct {
 	ProductID uuid.UUID `param:"id" validate:"required,uuid"`
 	VariantID uuid.UUID `param:"variantId" validate:"required,uuid"`
@@ -217,6 +252,30 @@ func (r *RejectProductRequest) Validate() error {
 	return validate.Struct(r)
 }
 
+// SuspendProductRequest takes an approved product offline. Without Until
+// the suspension lasts until an admin reinstates it.
+type SuspendProductRequest struct {
+	ProductID uuid.UUID  `param:"id" validate:"required,uuid"`
+	Reason    string     `json:"reason" validate:"required,min=10,max=500"`
+	Until     *time.Time `json:"until,omitempty" validate:"omitempty"`
+	Notes     *string    `json:"notes,omitempty" validate:"omitempty,max=500"`
+}
+
+func (r *SuspendProductRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ReinstateProductRequest struct {
+	ProductID uuid.UUID `param:"id" validate:"required,uuid"`
+	Notes     *string   `json:"notes,omitempty" validate:"omitempty,max=500"`
+}
+
+func (r *ReinstateProductRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
 type ResubmitProductRequest struct {
 	ProductID uuid.UUID `param:"id" validate:"required,uuid"`
 }
@@ -251,7 +310,15 @@ type ProductResponse struct {
 	ReviewedAt      *time.Time             `json:"reviewedAt,omitempty"`
 	RejectionReason *string                `json:"rejectionReason,omitempty"`
 
-	IsActive bool `json:"isActive"`
+	IsActive bool       `json:"isActive"`
+	HiddenAt *time.Time `json:"hiddenAt,omitempty"`
+
+	SuspendedAt      *time.Time `json:"suspendedAt,omitempty"`
+	SuspendedUntil   *time.Time `json:"suspendedUntil,omitempty"`
+	SuspensionReason *string    `json:"suspensionReason,omitempty"`
+
+	// nil when the company's product visibility applies
+	Visibility *company.ProductVisibility `json:"visibility,omitempty"`
 
 	Images   []ProductImageResponse   `json:"images"`
 	Variants []ProductVariantResponse `json:"variants"`
@@ -259,10 +326,23 @@ type ProductResponse struct {
 	CanBeModified bool `json:"canBeModified"`
 	IsVisible     bool `json:"isVisible"`
 
+	// set while the company is on vacation, approval is not affected
+	TemporarilyUnavailable bool       `json:"temporarilyUnavailable"`
+	BackOn                 *time.Time `json:"backOn,omitempty"`
+
+	// only filled for the owner while an edit of an approved product waits for review
+	PendingRevision *ProductRevision `json:"pendingRevision,omitempty"`
+
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+type CategoryCount struct {
+	CategoryID   *uuid.UUID `json:"categoryId,omitempty" db:"category_id"`
+	CategoryName *string    `json:"categoryName,omitempty" db:"category_name"`
+	Count        int        `json:"count" db:"count"`
+}
+
 type ProductImageResponse struct {
 	ID           uuid.UUID `json:"id"`
 	ImageURL     string    `json:"imageUrl"`
@@ -328,26 +408,31 @@ func ToProductResponse(
 	}
 
 	return &ProductResponse{
-		ID:              p.ID,
-		CompanyID:       p.CompanyID,
-		CategoryID:      p.CategoryID,
-		Name:            p.Name,
-		Description:     p.Description,
-		Unit:            p.Unit,
-		Origin:          p.Origin,
-		BasePrice:       p.BasePrice,
-		ApprovalStatus:  p.ApprovalStatus,
-		SubmittedAt:     p.SubmittedAt,
-		ReviewedByID:    p.ReviewedByID,
-		ReviewedAt:      p.ReviewedAt,
-		RejectionReason: p.RejectionReason,
-		IsActive:        p.IsActive,
-		Images:          imageResponses,
-		Variants:        variantResponses,
-		CanBeModified:   p.CanBeModified(),
-		IsVisible:       p.IsVisible(),
-		CreatedAt:       p.CreatedAt,
-		UpdatedAt:       p.UpdatedAt,
+		ID:               p.ID,
+		CompanyID:        p.CompanyID,
+		CategoryID:       p.CategoryID,
+		Name:             p.Name,
+		Description:      p.Description,
+		Unit:             p.Unit,
+		Origin:           p.Origin,
+		BasePrice:        p.BasePrice,
+		ApprovalStatus:   p.ApprovalStatus,
+		SubmittedAt:      p.SubmittedAt,
+		ReviewedByID:     p.ReviewedByID,
+		ReviewedAt:       p.ReviewedAt,
+		RejectionReason:  p.RejectionReason,
+		IsActive:         p.IsActive,
+		HiddenAt:         p.HiddenAt,
+		SuspendedAt:      p.SuspendedAt,
+		SuspendedUntil:   p.SuspendedUntil,
+		SuspensionReason: p.SuspensionReason,
+		Visibility:       p.Visibility,
+		Images:           imageResponses,
+		Variants:         variantResponses,
+		CanBeModified:    p.CanBeModified(),
+		IsVisible:        p.IsVisible(),
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
 	}
 }
 
@@ -380,3 +465,28 @@ func MapProductPage(
 		TotalPages: page.TotalPages,
 	}
 }
+
+// =============================================
+// SERVICEABILITY
+// =============================================
+
+type CheckServiceabilityRequest struct {
+	ID      uuid.UUID `param:"id" validate:"required,uuid"`
+	Pincode string    `query:"pincode" validate:"required,len=6,numeric"`
+}
+
+func (r *CheckServiceabilityRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// ServiceabilityResponse says whether the seller delivers to the pincode.
+// MatchedArea is empty when the company has no service areas, it then
+// delivers everywhere.
+type ServiceabilityResponse struct {
+	ProductID   uuid.UUID            `json:"productId"`
+	CompanyID   uuid.UUID            `json:"companyId"`
+	Pincode     string               `json:"pincode"`
+	Serviceable bool                 `json:"serviceable"`
+	MatchedArea *company.ServiceArea `json:"matchedArea,omitempty"`
+}
@@ -19,7 +19,7 @@ type Product struct {
 	Unit        string  `json:"unit" db:"unit"`
 	Origin      *string `json:"origin,omitempty" db:"origin"`
 
-	BasePrice decimal.Decimal `json:"price" db:"price"`
+	BasePrice decimal.Decimal `json:"price" db:"base_price"`
 
 	ApprovalStatus company.ApprovalStatus `json:"approvalStatus" db:"approval_status"`
 	SubmittedAt    time.Time              `json:"submittedAt" db:"submitted_at"`
@@ -29,6 +29,16 @@ type Product struct {
 	RejectionReason *string    `json:"rejectionReason,omitempty" db:"rejection_reason"`
 
 	IsActive bool `json:"isActive" db:"is_active"`
+	// set while user reports are under review
+	HiddenAt *time.Time `json:"hiddenAt,omitempty" db:"hidden_at"`
+
+	// admin suspension, IsActive is false while it lasts
+	SuspendedAt      *time.Time `json:"suspendedAt,omitempty" db:"suspended_at"`
+	SuspendedUntil   *time.Time `json:"suspendedUntil,omitempty" db:"suspended_until"`
+	SuspensionReason *string    `json:"suspensionReason,omitempty" db:"suspension_reason"`
+
+	// overrides the company's ProductVisibility when set
+	Visibility *company.ProductVisibility `json:"visibility,omitempty" db:"visibility"`
 	// Variants []ProductVariant `json:"variants" db:"variants"`
 }
 
@@ -49,7 +59,23 @@ func (p *Product) CanBeModified() bool {
 }
 
 func (p *Product) IsVisible() bool {
-	return p.IsApproved() && p.IsActive
+	return p.IsApproved() && p.IsActive && !p.IsHidden()
+}
+
+func (p *Product) IsHidden() bool {
+	return p.HiddenAt != nil
+}
+
+func (p *Product) IsSuspended() bool {
+	return p.SuspendedAt != nil
+}
+
+// EffectiveVisibility is the product override, or the company default.
+func (p *Product) EffectiveVisibility(companyDefault company.ProductVisibility) company.ProductVisibility {
+	if p.Visibility != nil {
+		return *p.Visibility
+	}
+	return companyDefault
 }
 
 // for the product images
@@ -88,11 +114,30 @@ func (v *ProductVariant) IsLowStock() bool {
 }
 
 type ProductApprovalHistory struct {
-	ID            uuid.UUID              `json:"id" db:"id"`
-	ProductID     uuid.UUID              `json:"productId" db:"product_id"`
-	Action        company.ApprovalAction `json:"action" db:"action"`
-	PerformedByID uuid.UUID              `json:"performedById" db:"performed_by_id"`
-	Reason        *string                `json:"reason,omitempty" db:"reason"`
-	Notes         *string                `json:"notes,omitempty" db:"notes"`
-	CreatedAt     time.Time              `json:"createdAt" db:"created_at"`
+	ID        uuid.UUID              `json:"id" db:"id"`
+	ProductID uuid.UUID              `json:"productId" db:"product_id"`
+	Action    company.ApprovalAction `json:"action" db:"action"`
+	// nil when the decision was taken by a pre-moderation rule or the
+	// system reinstated the product after a suspension ended
+	PerformedByID *uuid.UUID `json:"performedById,omitempty" db:"performed_by_id"`
+	Rule          *string    `json:"rule,omitempty" db:"rule"`
+	Reason        *string    `json:"reason,omitempty" db:"reason"`
+	Notes         *string    `json:"notes,omitempty" db:"notes"`
+	RevisionID    *uuid.UUID `json:"revisionId,omitempty" db:"revision_id"`
+	// stored for revision events, computed from Snapshot for resubmissions
+	Changes   model.FieldChanges `json:"changes,omitempty" db:"changes"`
+	Snapshot  map[string]any     `json:"snapshot,omitempty" db:"snapshot"`
+	CreatedAt time.Time          `json:"createdAt" db:"created_at"`
+}
+
+func (h *ProductApprovalHistory) IsSubmission() bool {
+	return h.Action == company.ActionSubmitted || h.Action == company.ActionResubmitted
+}
+
+func (h *ProductApprovalHistory) GetSnapshot() map[string]any {
+	return h.Snapshot
+}
+
+func (h *ProductApprovalHistory) SetChanges(changes model.FieldChanges) {
+	h.Changes = changes
 }
@@ -0,0 +1,126 @@
+package product
+
+import (
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ProductContent is the part of a product the seller edits. Revisions carry
+// it and field level diffs are computed over it.
+type ProductContent struct {
+	CategoryID  *uuid.UUID      `json:"categoryId" db:"category_id"`
+	Name        string          `json:"name" db:"name"`
+	Description *string         `json:"description" db:"description"`
+	Unit        string          `json:"unit" db:"unit"`
+	Origin      *string         `json:"origin" db:"origin"`
+	BasePrice   decimal.Decimal `json:"price" db:"base_price"`
+}
+
+func (p *Product) Content() ProductContent {
+	return ProductContent{
+		CategoryID:  p.CategoryID,
+		Name:        p.Name,
+		Description: p.Description,
+		Unit:        p.Unit,
+		Origin:      p.Origin,
+		BasePrice:   p.BasePrice,
+	}
+}
+
+// ApplyUpdate copies the fields set on the request, same rules as a direct update.
+func (c *ProductContent) ApplyUpdate(u *UpdateProductRequest) {
+	if u.CategoryID != nil {
+		c.CategoryID = u.CategoryID
+	}
+	if u.Name != nil {
+		c.Name = *u.Name
+	}
+	if u.Description != nil {
+		c.Description = u.Description
+	}
+	if u.Unit != nil {
+		c.Unit = *u.Unit
+	}
+	if u.Origin != nil {
+		c.Origin = u.Origin
+	}
+	if u.BasePrice != nil {
+		c.BasePrice = *u.BasePrice
+	}
+}
+
+// ProductRevision is a pending edit of an APPROVED product. The live product
+// stays visible as is until the revision is approved and applied.
+type ProductRevision struct {
+	model.Base
+	ProductID     uuid.UUID `json:"productId" db:"product_id"`
+	SubmittedByID uuid.UUID `json:"submittedById" db:"submitted_by_id"`
+
+	ProductContent
+	Changes model.FieldChanges `json:"changes" db:"changes"`
+
+	Status          company.ApprovalStatus `json:"status" db:"status"`
+	ReviewedByID    *uuid.UUID             `json:"reviewedById,omitempty" db:"reviewed_by_id"`
+	ReviewedAt      *time.Time             `json:"reviewedAt,omitempty" db:"reviewed_at"`
+	RejectionReason *string                `json:"rejectionReason,omitempty" db:"rejection_reason"`
+}
+
+func (r *ProductRevision) IsPending() bool {
+	return r.Status == company.ApprovalStatusPending
+}
+
+// =============================================
+// REVISION REQUESTS
+// =============================================
+
+type GetPendingRevisionRequest struct {
+	ProductID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetPendingRevisionRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ListPendingRevisionsRequest struct {
+	Page  int `query:"page" validate:"min=1"`
+	Limit int `query:"limit" validate:"min=1,max=100"`
+}
+
+func (r *ListPendingRevisionsRequest) Validate() error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ApproveRevisionRequest struct {
+	RevisionID uuid.UUID `param:"revisionId" validate:"required,uuid"`
+	Notes      *string   `json:"notes,omitempty" validate:"omitempty,max=500"`
+}
+
+func (r *ApproveRevisionRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type RejectRevisionRequest struct {
+	RevisionID uuid.UUID `param:"revisionId" validate:"required,uuid"`
+	Reason     string    `json:"reason" validate:"required,min=10,max=500"`
+	Notes      *string   `json:"notes,omitempty" validate:"omitempty,max=500"`
+}
+
+func (r *RejectRevisionRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
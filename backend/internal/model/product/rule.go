@@ -0,0 +1,79 @@
+package product
+
+import (
+	"strings"
+
+	"github.com/C0deNe0/agromart/internal/model/company"
+)
+
+type RuleOutcome string
+
+const (
+	RuleOutcomeApprove RuleOutcome = "APPROVE"
+	RuleOutcomeFlag    RuleOutcome = "FLAG"
+	RuleOutcomeReject  RuleOutcome = "REJECT"
+)
+
+// RuleDecision is what a single pre-moderation rule concluded.
+type RuleDecision struct {
+	Rule    string      `json:"rule"`
+	Outcome RuleOutcome `json:"outcome"`
+	Reason  string      `json:"reason"`
+}
+
+func (d *RuleDecision) Action() company.ApprovalAction {
+	switch d.Outcome {
+	case RuleOutcomeApprove:
+		return company.ActionAutoApproved
+	case RuleOutcomeReject:
+		return company.ActionAutoRejected
+	default:
+		return company.ActionAutoFlagged
+	}
+}
+
+// RuleVerdict collects the decisions of one pre-moderation run.
+// A rejection wins over a flag, and an approval only counts when no
+// other rule fired.
+type RuleVerdict struct {
+	Decisions []RuleDecision `json:"decisions"`
+}
+
+func (v *RuleVerdict) Add(d RuleDecision) {
+	v.Decisions = append(v.Decisions, d)
+}
+
+// Outcome returns "" when no rule fired, the product then waits for a reviewer.
+func (v *RuleVerdict) Outcome() RuleOutcome {
+	var outcome RuleOutcome
+	for _, d := range v.Decisions {
+		switch {
+		case d.Outcome == RuleOutcomeReject:
+			return RuleOutcomeReject
+		case d.Outcome == RuleOutcomeFlag:
+			outcome = RuleOutcomeFlag
+		case outcome == "":
+			outcome = d.Outcome
+		}
+	}
+	return outcome
+}
+
+func (v *RuleVerdict) IsRejected() bool {
+	return v.Outcome() == RuleOutcomeReject
+}
+
+func (v *RuleVerdict) IsApproved() bool {
+	return v.Outcome() == RuleOutcomeApprove
+}
+
+// RejectionReason joins the reasons of every rejecting rule.
+func (v *RuleVerdict) RejectionReason() string {
+	var reasons []string
+	for _, d := range v.Decisions {
+		if d.Outcome == RuleOutcomeReject {
+			reasons = append(reasons, d.Reason)
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
@@ -0,0 +1,150 @@
+package report
+
+import (
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// EntityType is what a report is about. Reviews are left out until there is
+// a review entity to report, adding one needs REVIEW here and in the
+// entity_type check of the reports table.
+type EntityType string
+
+const (
+	EntityProduct EntityType = "PRODUCT"
+	EntityCompany EntityType = "COMPANY"
+)
+
+type Reason string
+
+const (
+	ReasonFakeListing    Reason = "FAKE_LISTING"
+	ReasonFraud          Reason = "FRAUD"
+	ReasonCounterfeit    Reason = "COUNTERFEIT"
+	ReasonProhibitedItem Reason = "PROHIBITED_ITEM"
+	ReasonMisleadingInfo Reason = "MISLEADING_INFO"
+	ReasonOffensive      Reason = "OFFENSIVE"
+	ReasonSpam           Reason = "SPAM"
+	ReasonOther          Reason = "OTHER"
+)
+
+type Status string
+
+const (
+	StatusOpen      Status = "OPEN"
+	StatusResolved  Status = "RESOLVED"
+	StatusDismissed Status = "DISMISSED"
+)
+
+type Report struct {
+	model.Base
+	ReporterID uuid.UUID  `json:"reporterId" db:"reporter_id"`
+	EntityType EntityType `json:"entityType" db:"entity_type"`
+	EntityID   uuid.UUID  `json:"entityId" db:"entity_id"`
+	Reason     Reason     `json:"reason" db:"reason"`
+	Comment    *string    `json:"comment,omitempty" db:"comment"`
+
+	Status         Status     `json:"status" db:"status"`
+	ResolvedByID   *uuid.UUID `json:"resolvedById,omitempty" db:"resolved_by_id"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty" db:"resolved_at"`
+	ResolutionNote *string    `json:"resolutionNote,omitempty" db:"resolution_note"`
+}
+
+// QueueItem groups the open reports of one entity for triage.
+type QueueItem struct {
+	EntityType      EntityType `json:"entityType" db:"entity_type"`
+	EntityID        uuid.UUID  `json:"entityId" db:"entity_id"`
+	EntityName      *string    `json:"entityName,omitempty" db:"entity_name"`
+	OpenReports     int        `json:"openReports" db:"open_reports"`
+	Reasons         []Reason   `json:"reasons" db:"reasons"`
+	FirstReportedAt time.Time  `json:"firstReportedAt" db:"first_reported_at"`
+	LastReportedAt  time.Time  `json:"lastReportedAt" db:"last_reported_at"`
+	HiddenAt        *time.Time `json:"hiddenAt,omitempty" db:"hidden_at"`
+}
+
+// =============================================
+// REQUESTS
+// =============================================
+
+type CreateReportRequest struct {
+	EntityType EntityType `json:"entityType" validate:"required,oneof=PRODUCT COMPANY"`
+	EntityID   uuid.UUID  `json:"entityId" validate:"required"`
+	Reason     Reason     `json:"reason" validate:"required,oneof=FAKE_LISTING FRAUD COUNTERFEIT PROHIBITED_ITEM MISLEADING_INFO OFFENSIVE SPAM OTHER"`
+	Comment    *string    `json:"comment,omitempty" validate:"omitempty,max=2000"`
+}
+
+func (r *CreateReportRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ListMyReportsRequest struct {
+	Page  int `query:"page" validate:"min=1"`
+	Limit int `query:"limit" validate:"min=1,max=100"`
+}
+
+func (r *ListMyReportsRequest) Validate() error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ListReportQueueRequest struct {
+	Page  int `query:"page" validate:"min=1"`
+	Limit int `query:"limit" validate:"min=1,max=100"`
+
+	EntityType *EntityType `query:"entityType" validate:"omitempty,oneof=PRODUCT COMPANY"`
+	Reason     *Reason     `query:"reason" validate:"omitempty,oneof=FAKE_LISTING FRAUD COUNTERFEIT PROHIBITED_ITEM MISLEADING_INFO OFFENSIVE SPAM OTHER"`
+	HiddenOnly bool        `query:"hiddenOnly"`
+}
+
+func (r *ListReportQueueRequest) Validate() error {
+	if r.Page == 0 {
+		r.Page = 1
+	}
+	if r.Limit == 0 {
+		r.Limit = 20
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type ListEntityReportsRequest struct {
+	EntityType EntityType `param:"entityType" validate:"required,oneof=PRODUCT COMPANY"`
+	EntityID   uuid.UUID  `param:"entityId" validate:"required"`
+	Status     *Status    `query:"status" validate:"omitempty,oneof=OPEN RESOLVED DISMISSED"`
+}
+
+func (r *ListEntityReportsRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// DecideReportsRequest closes every open report of the entity at once.
+// Restore only applies to resolve, dismissing always restores the entity.
+type DecideReportsRequest struct {
+	EntityType EntityType `param:"entityType" validate:"required,oneof=PRODUCT COMPANY"`
+	EntityID   uuid.UUID  `param:"entityId" validate:"required"`
+	Note       *string    `json:"note,omitempty" validate:"omitempty,max=2048"`
+	Restore    bool       `json:"restore"`
+}
+
+func (r *DecideReportsRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type DecideReportsResponse struct {
+	Closed   int  `json:"closed"`
+	Restored bool `json:"restored"`
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteCSV writes the stats as one long table with the columns
+// section, bucket, key, label and value. bucket is empty for the
+// distributions, label carries category and plan names.
+func (s *StatsResponse) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"section", "bucket", "key", "label", "value"}}
+
+	add := func(section string, bucket *time.Time, key, label string, value string) {
+		b := ""
+		if bucket != nil {
+			b = bucket.Format(time.RFC3339)
+		}
+		rows = append(rows, []string{section, b, key, label, value})
+	}
+
+	for _, p := range s.Signups {
+		add("signups", &p.Bucket, "count", "", strconv.Itoa(p.Count))
+	}
+	for _, p := range s.Companies {
+		add("companies", &p.Bucket, "created", "", strconv.Itoa(p.Created))
+		add("companies", &p.Bucket, "approved", "", strconv.Itoa(p.Approved))
+		add("companies", &p.Bucket, "rejected", "", strconv.Itoa(p.Rejected))
+	}
+	for _, p := range s.Followers {
+		add("followers", &p.Bucket, "follows", "", strconv.Itoa(p.Follows))
+		add("followers", &p.Bucket, "unfollows", "", strconv.Itoa(p.Unfollows))
+		add("followers", &p.Bucket, "net", "", strconv.Itoa(p.Net))
+	}
+	for _, p := range s.ApprovalTime {
+		add("approval_time", &p.Bucket, "company_approvals", "", strconv.Itoa(p.CompanyApprovals))
+		add("approval_time", &p.Bucket, "company_median_seconds", "", formatSeconds(p.CompanyMedianSeconds))
+		add("approval_time", &p.Bucket, "product_approvals", "", strconv.Itoa(p.ProductApprovals))
+		add("approval_time", &p.Bucket, "product_median_seconds", "", formatSeconds(p.ProductMedianSeconds))
+	}
+	for _, c := range s.ProductsPerCategory {
+		label := "Uncategorized"
+		if c.CategoryName != nil {
+			label = *c.CategoryName
+		}
+		add("products_per_category", nil, "total", label, strconv.Itoa(c.Total))
+		add("products_per_category", nil, "approved", label, strconv.Itoa(c.Approved))
+		add("products_per_category", nil, "pending", label, strconv.Itoa(c.Pending))
+		add("products_per_category", nil, "rejected", label, strconv.Itoa(c.Rejected))
+	}
+	for _, p := range s.Subscriptions.Plans {
+		add("subscriptions", nil, "active", p.PlanName, strconv.Itoa(p.Active))
+		add("subscriptions", nil, "paused", p.PlanName, strconv.Itoa(p.Paused))
+		add("subscriptions", nil, "expired", p.PlanName, strconv.Itoa(p.Expired))
+		add("subscriptions", nil, "cancelled", p.PlanName, strconv.Itoa(p.Cancelled))
+	}
+	add("subscriptions", nil, "companies_without_plan", "", strconv.Itoa(s.Subscriptions.CompaniesWithoutPlan))
+
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func formatSeconds(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', 0, 64)
+}
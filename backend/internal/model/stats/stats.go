@@ -0,0 +1,124 @@
+package stats
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type GroupBy string
+
+const (
+	GroupByDay   GroupBy = "day"
+	GroupByWeek  GroupBy = "week"
+	GroupByMonth GroupBy = "month"
+)
+
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+// =============================================
+// SERIES
+// =============================================
+
+type CountPoint struct {
+	Bucket time.Time `json:"bucket" db:"bucket"`
+	Count  int       `json:"count" db:"count"`
+}
+
+type CompanyPoint struct {
+	Bucket   time.Time `json:"bucket" db:"bucket"`
+	Created  int       `json:"created" db:"created"`
+	Approved int       `json:"approved" db:"approved"`
+	Rejected int       `json:"rejected" db:"rejected"`
+}
+
+type FollowerPoint struct {
+	Bucket    time.Time `json:"bucket" db:"bucket"`
+	Follows   int       `json:"follows" db:"follows"`
+	Unfollows int       `json:"unfollows" db:"unfollows"`
+	Net       int       `json:"net" db:"net"`
+}
+
+// ApprovalTimePoint holds the median time from (re)submission to approval
+// of what was approved in the bucket, nil when nothing was approved.
+type ApprovalTimePoint struct {
+	Bucket               time.Time `json:"bucket" db:"bucket"`
+	CompanyApprovals     int       `json:"companyApprovals" db:"company_approvals"`
+	CompanyMedianSeconds *float64  `json:"companyMedianSeconds,omitempty" db:"company_median_seconds"`
+	ProductApprovals     int       `json:"productApprovals" db:"product_approvals"`
+	ProductMedianSeconds *float64  `json:"productMedianSeconds,omitempty" db:"product_median_seconds"`
+}
+
+// =============================================
+// DISTRIBUTIONS
+// =============================================
+
+// CategoryCount is the current number of products per category, products
+// without a category have a nil CategoryID.
+type CategoryCount struct {
+	CategoryID   *uuid.UUID `json:"categoryId,omitempty" db:"category_id"`
+	CategoryName *string    `json:"categoryName,omitempty" db:"category_name"`
+	Total        int        `json:"total" db:"total"`
+	Approved     int        `json:"approved" db:"approved"`
+	Pending      int        `json:"pending" db:"pending"`
+	Rejected     int        `json:"rejected" db:"rejected"`
+}
+
+type PlanCount struct {
+	PlanID    uuid.UUID `json:"planId" db:"plan_id"`
+	PlanName  string    `json:"planName" db:"plan_name"`
+	Active    int       `json:"active" db:"active"`
+	Paused    int       `json:"paused" db:"paused"`
+	Expired   int       `json:"expired" db:"expired"`
+	Cancelled int       `json:"cancelled" db:"cancelled"`
+}
+
+type SubscriptionDistribution struct {
+	Plans []PlanCount `json:"plans"`
+	// approved companies without an active subscription
+	CompaniesWithoutPlan int `json:"companiesWithoutPlan"`
+}
+
+type StatsResponse struct {
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	GroupBy GroupBy   `json:"groupBy"`
+
+	Signups      []CountPoint        `json:"signups"`
+	Companies    []CompanyPoint      `json:"companies"`
+	Followers    []FollowerPoint     `json:"followers"`
+	ApprovalTime []ApprovalTimePoint `json:"approvalTime"`
+
+	ProductsPerCategory []CategoryCount          `json:"productsPerCategory"`
+	Subscriptions       SubscriptionDistribution `json:"subscriptions"`
+}
+
+// =============================================
+// REQUESTS
+// =============================================
+
+// GetStatsRequest covers [From, To). Without a range the last 30 days are
+// returned, Format csv downloads the same data as a flat table.
+type GetStatsRequest struct {
+	// RFC 3339, e.g. 2025-01-31T00:00:00Z
+	From    *time.Time `query:"from" validate:"omitempty"`
+	To      *time.Time `query:"to" validate:"omitempty"`
+	GroupBy GroupBy    `query:"groupBy" validate:"oneof=day week month"`
+	Format  string     `query:"format" validate:"oneof=json csv"`
+}
+
+func (r *GetStatsRequest) Validate() error {
+	if r.GroupBy == "" {
+		r.GroupBy = GroupByDay
+	}
+	if r.Format == "" {
+		r.Format = FormatJSON
+	}
+
+	validate := validator.New()
+	return validate.Struct(r)
+}
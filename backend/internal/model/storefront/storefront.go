@@ -0,0 +1,15 @@
+package storefront
+
+import (
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/model/product"
+)
+
+// Response is everything the company page needs in one call. The company
+// carries the logo, banner and follower count.
+type Response struct {
+	Company          *company.CompanyResponse  `json:"company"`
+	FeaturedProducts []product.ProductResponse `json:"featuredProducts"`
+	Categories       []product.CategoryCount   `json:"categories"`
+	ProductCount     int                       `json:"productCount"`
+}
@@ -39,3 +39,17 @@ type AuthResponse struct {
 	AccessToken  string       `json:"accessToken"`
 	RefreshToken string       `json:"refreshToken"`
 }
+
+// WebAuthResponse is returned to the browser flow, the refresh token
+// travels only in the HttpOnly cookie.
+type WebAuthResponse struct {
+	User        UserResponse `json:"user"`
+	AccessToken string       `json:"accessToken"`
+}
+
+func ToWebAuthResponse(a *AuthResponse) *WebAuthResponse {
+	return &WebAuthResponse{
+		User:        a.User,
+		AccessToken: a.AccessToken,
+	}
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/analytics"
+	"github.com/C0deNe0/agromart/internal/model/stats"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AnalyticsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAnalyticsRepository(db *pgxpool.Pool) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// Flush adds aggregated counts and viewers in one transaction. Rows for
+// products deleted in the meantime are dropped.
+func (r *AnalyticsRepository) Flush(ctx context.Context, counts map[analytics.DailyKey]analytics.DailyCounts, viewers []analytics.DailyViewer) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if len(counts) > 0 {
+			var productIDs, companyIDs []uuid.UUID
+			var days []time.Time
+			var views, impressions []int32
+			for k, c := range counts {
+				productIDs = append(productIDs, k.ProductID)
+				companyIDs = append(companyIDs, k.CompanyID)
+				days = append(days, k.Day)
+				views = append(views, int32(c.Views))
+				impressions = append(impressions, int32(c.Impressions))
+			}
+
+			stmt := `
+				INSERT INTO product_stats_daily (product_id, company_id, day, views, impressions)
+				SELECT u.product_id, u.company_id, u.day, u.views, u.impressions
+				FROM unnest(@product_ids::uuid[], @company_ids::uuid[], @days::date[], @views::int[], @impressions::int[])
+					AS u(product_id, company_id, day, views, impressions)
+				WHERE EXISTS (SELECT 1 FROM products p WHERE p.id = u.product_id)
+				ON CONFLICT (product_id, day) DO UPDATE SET
+					views = product_stats_daily.views + EXCLUDED.views,
+					impressions = product_stats_daily.impressions + EXCLUDED.impressions
+			`
+			_, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
+				"product_ids": productIDs,
+				"company_ids": companyIDs,
+				"days":        days,
+				"views":       views,
+				"impressions": impressions,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to flush product stats: %w", err)
+			}
+		}
+
+		if len(viewers) > 0 {
+			var productIDs, companyIDs []uuid.UUID
+			var days []time.Time
+			var keys []string
+			for _, v := range viewers {
+				productIDs = append(productIDs, v.ProductID)
+				companyIDs = append(companyIDs, v.CompanyID)
+				days = append(days, v.Day)
+				keys = append(keys, v.ViewerKey)
+			}
+
+			stmt := `
+				INSERT INTO product_viewers_daily (product_id, company_id, day, viewer_key)
+				SELECT u.product_id, u.company_id, u.day, u.viewer_key
+				FROM unnest(@product_ids::uuid[], @company_ids::uuid[], @days::date[], @keys::text[])
+					AS u(product_id, company_id, day, viewer_key)
+				WHERE EXISTS (SELECT 1 FROM products p WHERE p.id = u.product_id)
+				ON CONFLICT DO NOTHING
+			`
+			_, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
+				"product_ids": productIDs,
+				"company_ids": companyIDs,
+				"days":        days,
+				"keys":        keys,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to flush product viewers: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// the daily tables are filtered on the days touched by [@from, @to)
+const dailyRange = `day >= (@from::timestamptz)::date AND day <= (@to::timestamptz - interval '1 microsecond')::date`
+
+func (r *AnalyticsRepository) ProductSeries(ctx context.Context, productID uuid.UUID, from, to time.Time, groupBy stats.GroupBy) ([]analytics.ProductPoint, error) {
+	stmt := `
+		WITH ` + bucketsCTE + `,
+		daily AS (
+			SELECT date_trunc(@unit::text, day::timestamptz) AS bucket,
+				SUM(views) AS views, SUM(impressions) AS impressions
+			FROM product_stats_daily
+			WHERE product_id = @product_id AND ` + dailyRange + `
+			GROUP BY 1
+		),
+		viewers AS (
+			SELECT date_trunc(@unit::text, day::timestamptz) AS bucket,
+				COUNT(DISTINCT viewer_key) AS unique_viewers
+			FROM product_viewers_daily
+			WHERE product_id = @product_id AND ` + dailyRange + `
+			GROUP BY 1
+		),
+		favs AS (
+			SELECT date_trunc(@unit::text, created_at) AS bucket, COUNT(*) AS favorite_adds
+			FROM favorites
+			WHERE product_id = @product_id AND created_at >= @from AND created_at < @to
+			GROUP BY 1
+		)
+		SELECT
+			b.bucket,
+			COALESCE(d.views, 0)::int AS views,
+			COALESCE(d.impressions, 0)::int AS impressions,
+			COALESCE(v.unique_viewers, 0)::int AS unique_viewers,
+			COALESCE(f.favorite_adds, 0)::int AS favorite_adds
+		FROM buckets b
+		LEFT JOIN daily d ON d.bucket = b.bucket
+		LEFT JOIN viewers v ON v.bucket = b.bucket
+		LEFT JOIN favs f ON f.bucket = b.bucket
+		ORDER BY b.bucket
+	`
+	args := seriesArgs(from, to, groupBy)
+	args["product_id"] = productID
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product analytics: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[analytics.ProductPoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+func (r *AnalyticsRepository) CompanySeries(ctx context.Context, companyID uuid.UUID, from, to time.Time, groupBy stats.GroupBy) ([]analytics.CompanyPoint, error) {
+	stmt := `
+		WITH ` + bucketsCTE + `,
+		daily AS (
+			SELECT date_trunc(@unit::text, day::timestamptz) AS bucket,
+				SUM(views) AS views, SUM(impressions) AS impressions
+			FROM product_stats_daily
+			WHERE company_id = @company_id AND ` + dailyRange + `
+			GROUP BY 1
+		),
+		viewers AS (
+			SELECT date_trunc(@unit::text, day::timestamptz) AS bucket,
+				COUNT(DISTINCT viewer_key) AS unique_viewers
+			FROM product_viewers_daily
+			WHERE company_id = @company_id AND ` + dailyRange + `
+			GROUP BY 1
+		),
+		favs AS (
+			SELECT date_trunc(@unit::text, f.created_at) AS bucket, COUNT(*) AS favorite_adds
+			FROM favorites f
+			JOIN products p ON p.id = f.product_id
+			WHERE p.company_id = @company_id AND f.created_at >= @from AND f.created_at < @to
+			GROUP BY 1
+		),
+		follows AS (
+			SELECT date_trunc(@unit::text, created_at) AS bucket,
+				COUNT(*) FILTER (WHERE event = 'FOLLOW') AS follows,
+				COUNT(*) FILTER (WHERE event = 'UNFOLLOW') AS unfollows
+			FROM company_follower_events
+			WHERE company_id = @company_id AND created_at >= @from AND created_at < @to
+			GROUP BY 1
+		)
+		SELECT
+			b.bucket,
+			COALESCE(d.views, 0)::int AS views,
+			COALESCE(d.impressions, 0)::int AS impressions,
+			COALESCE(v.unique_viewers, 0)::int AS unique_viewers,
+			COALESCE(f.favorite_adds, 0)::int AS favorite_adds,
+			COALESCE(fo.follows, 0)::int AS follows,
+			COALESCE(fo.unfollows, 0)::int AS unfollows
+		FROM buckets b
+		LEFT JOIN daily d ON d.bucket = b.bucket
+		LEFT JOIN viewers v ON v.bucket = b.bucket
+		LEFT JOIN favs f ON f.bucket = b.bucket
+		LEFT JOIN follows fo ON fo.bucket = b.bucket
+		ORDER BY b.bucket
+	`
+	args := seriesArgs(from, to, groupBy)
+	args["company_id"] = companyID
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company analytics: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[analytics.CompanyPoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+// UniqueViewers counts distinct viewers over the whole range, of one
+// product when productID is set, of every product of the company otherwise.
+func (r *AnalyticsRepository) UniqueViewers(ctx context.Context, companyID uuid.UUID, productID *uuid.UUID, from, to time.Time) (int, error) {
+	stmt := `
+		SELECT COUNT(DISTINCT viewer_key)::int FROM product_viewers_daily
+		WHERE company_id = @company_id AND ` + dailyRange
+	args := pgx.NamedArgs{
+		"company_id": companyID,
+		"from":       from,
+		"to":         to,
+	}
+	if productID != nil {
+		stmt += ` AND product_id = @product_id`
+		args["product_id"] = *productID
+	}
+
+	var count int
+	if err := r.db.QueryRow(ctx, stmt, args).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unique viewers: %w", err)
+	}
+	return count, nil
+}
+
+// TopProducts ranks the company's products by detail views in the range.
+func (r *AnalyticsRepository) TopProducts(ctx context.Context, companyID uuid.UUID, from, to time.Time, limit int) ([]analytics.ProductSummary, error) {
+	stmt := `
+		SELECT
+			p.id AS product_id,
+			p.name,
+			COALESCE(s.views, 0)::int AS views,
+			COALESCE(s.impressions, 0)::int AS impressions,
+			(
+				SELECT COUNT(*) FROM favorites f
+				WHERE f.product_id = p.id AND f.created_at >= @from AND f.created_at < @to
+			)::int AS favorite_adds
+		FROM products p
+		LEFT JOIN (
+			SELECT product_id, SUM(views) AS views, SUM(impressions) AS impressions
+			FROM product_stats_daily
+			WHERE company_id = @company_id AND ` + dailyRange + `
+			GROUP BY product_id
+		) s ON s.product_id = p.id
+		WHERE p.company_id = @company_id
+		ORDER BY views DESC, impressions DESC, p.name
+		LIMIT @limit
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"from":       from,
+		"to":         to,
+		"limit":      limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top products: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[analytics.ProductSummary])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
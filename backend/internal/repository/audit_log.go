@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/audit"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditLogRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditLogRepository(db *pgxpool.Pool) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+type AuditLogFilter struct {
+	ActorID    *uuid.UUID
+	EntityType *audit.EntityType
+	EntityID   *uuid.UUID
+	Action     *audit.Action
+	RequestID  *string
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	Limit      int
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, e *audit.Entry) error {
+	stmt := `
+		INSERT INTO audit_log (
+			actor_id,
+			impersonator_id,
+			action,
+			entity_type,
+			entity_id,
+			before,
+			after,
+			request_id,
+			ip_address
+		) VALUES (
+			@actor_id,
+			@impersonator_id,
+			@action,
+			@entity_type,
+			@entity_id,
+			@before,
+			@after,
+			@request_id,
+			@ip_address
+		)
+	`
+	_, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+		"actor_id":        e.ActorID,
+		"impersonator_id": e.ImpersonatorID,
+		"action":          e.Action,
+		"entity_type":     e.EntityType,
+		"entity_id":       e.EntityID,
+		"before":          e.Before,
+		"after":           e.After,
+		"request_id":      e.RequestID,
+		"ip_address":      e.IPAddress,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create audit log entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AuditLogRepository) List(ctx context.Context, filter AuditLogFilter) (*model.PaginatedResponse[audit.Entry], error) {
+	base := `FROM audit_log WHERE 1=1`
+	args := pgx.NamedArgs{}
+
+	if filter.ActorID != nil {
+		base += ` AND (actor_id = @actor_id OR impersonator_id = @actor_id)`
+		args["actor_id"] = *filter.ActorID
+	}
+
+	if filter.EntityType != nil {
+		base += ` AND entity_type = @entity_type`
+		args["entity_type"] = *filter.EntityType
+	}
+
+	if filter.EntityID != nil {
+		base += ` AND entity_id = @entity_id`
+		args["entity_id"] = *filter.EntityID
+	}
+
+	if filter.Action != nil {
+		base += ` AND action = @action`
+		args["action"] = *filter.Action
+	}
+
+	if filter.RequestID != nil {
+		base += ` AND request_id = @request_id`
+		args["request_id"] = *filter.RequestID
+	}
+
+	if filter.From != nil {
+		base += ` AND created_at >= @from`
+		args["from"] = *filter.From
+	}
+
+	if filter.To != nil {
+		base += ` AND created_at < @to`
+		args["to"] = *filter.To
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) `+base, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count audit log: %w", err)
+	}
+
+	stmt := `SELECT * ` + base + ` ORDER BY created_at DESC LIMIT @limit OFFSET @offset`
+	args["limit"] = filter.Limit
+	args["offset"] = (filter.Page - 1) * filter.Limit
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[audit.Entry])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &model.PaginatedResponse[audit.Entry]{
+		Data:       items,
+		Total:      total,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		TotalPages: (total + filter.Limit - 1) / filter.Limit,
+	}, nil
+}
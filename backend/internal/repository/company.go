@@ -2,12 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/C0deNe0/agromart/internal/model"
 	"github.com/C0deNe0/agromart/internal/model/company"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -25,8 +28,10 @@ type CompanyFilter struct {
 	ApprovalStatus *company.ApprovalStatus
 
 	IsActive *bool
-	Page     int
-	Limit    int
+	// leaves out companies hidden by user reports or suspended
+	ExcludeHidden bool
+	Page          int
+	Limit         int
 }
 
 func (r *CompanyRepository) Create(ctx context.Context, c *company.Company) (*company.Company, error) {
@@ -34,6 +39,7 @@ func (r *CompanyRepository) Create(ctx context.Context, c *company.Company) (*co
 	INSERT INTO companies (
 		owner_id,
 		 name,
+		 slug,
 		 description,
 		 logo_url,
 		 business_email,
@@ -50,6 +56,7 @@ func (r *CompanyRepository) Create(ctx context.Context, c *company.Company) (*co
 		VALUES (
 		@owner_id,
 		@name,
+		@slug,
 		@description,
 		@logo_url,
 		@business_email,
@@ -67,6 +74,7 @@ func (r *CompanyRepository) Create(ctx context.Context, c *company.Company) (*co
 	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
 		"owner_id":           c.OwnerID,
 		"name":               c.Name,
+		"slug":               c.Slug,
 		"description":        c.Description,
 		"logo_url":           c.LogoURL,
 		"business_email":     c.BusinessEmail,
@@ -108,6 +116,106 @@ func (r *CompanyRepository) GetByID(ctx context.Context, id uuid.UUID) (*company
 	return &row, nil
 }
 
+// GetBySlug finds the company by its current slug, or by one it used
+// before. moved is true in the second case, the current slug is then in
+// the returned company.
+func (r *CompanyRepository) GetBySlug(ctx context.Context, slug string) (*company.Company, bool, error) {
+	stmt := `
+		SELECT c.*, (c.slug <> @slug) AS moved
+		FROM companies c
+		WHERE c.slug = @slug
+			OR c.id = (SELECT company_id FROM company_slug_history WHERE slug = @slug)
+		ORDER BY moved
+		LIMIT 1`
+
+	type slugRow struct {
+		company.Company
+		Moved bool `db:"moved"`
+	}
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{"slug": slug})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get company by slug:%w", err)
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[slugRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, ErrNotFound
+		}
+		return nil, false, fmt.Errorf("failed to collect row:%w", err)
+	}
+
+	return &row.Company, row.Moved, nil
+}
+
+// SlugTaken reports whether the slug is, or was, used by a company other
+// than exceptID.
+func (r *CompanyRepository) SlugTaken(ctx context.Context, slug string, exceptID uuid.UUID) (bool, error) {
+	stmt := `
+		SELECT EXISTS (SELECT 1 FROM companies WHERE slug = @slug AND id <> @except_id)
+			OR EXISTS (SELECT 1 FROM company_slug_history WHERE slug = @slug AND company_id <> @except_id)`
+
+	var taken bool
+	err := r.db.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"slug":      slug,
+		"except_id": exceptID,
+	}).Scan(&taken)
+	if err != nil {
+		return false, fmt.Errorf("failed to check slug:%w", err)
+	}
+
+	return taken, nil
+}
+
+// ChangeSlug moves the current slug into the history so old links keep
+// working. Taking back an earlier slug removes it from the history.
+func (r *CompanyRepository) ChangeSlug(ctx context.Context, companyID uuid.UUID, slug string) (*company.Company, error) {
+	var updated company.Company
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		args := pgx.NamedArgs{
+			"id":   companyID,
+			"slug": slug,
+		}
+
+		_, err := tx.Exec(ctx, `
+		INSERT INTO company_slug_history (slug, company_id)
+		SELECT slug, id FROM companies WHERE id = @id AND slug <> @slug
+		ON CONFLICT (slug) DO UPDATE SET retired_at = NOW()`, args)
+		if err != nil {
+			return fmt.Errorf("failed to keep old slug:%w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+		DELETE FROM company_slug_history WHERE slug = @slug AND company_id = @id`, args)
+		if err != nil {
+			return fmt.Errorf("failed to reclaim slug:%w", err)
+		}
+
+		rows, err := tx.Query(ctx, `
+		UPDATE companies SET slug = @slug, updated_at = NOW()
+		WHERE id = @id
+		RETURNING *`, args)
+		if err != nil {
+			return fmt.Errorf("failed to update slug:%w", err)
+		}
+		updated, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[company.Company])
+		if err != nil {
+			// another company took the slug since it was checked
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return ErrConflict
+			}
+			return fmt.Errorf("failed to collect row:%w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
 func (r *CompanyRepository) List(ctx context.Context, filter CompanyFilter) (*model.PaginatedResponse[company.Company], error) {
 	base := `FROM companies WHERE 1=1`
 	args := pgx.NamedArgs{}
@@ -132,6 +240,10 @@ func (r *CompanyRepository) List(ctx context.Context, filter CompanyFilter) (*mo
 		args["is_active"] = *filter.IsActive
 	}
 
+	if filter.ExcludeHidden {
+		base += ` AND hidden_at IS NULL AND suspended_at IS NULL`
+	}
+
 	//count
 	var total int
 	countStmt := `SELECT COUNT(*) ` + base
@@ -168,7 +280,6 @@ func (r *CompanyRepository) Update(ctx context.Context, c *company.Company) (*co
 	SET
 		name = @name,
 		description = @description,
-		logo_url = @logo_url,
 		business_email = @business_email,
 		business_phone = @business_phone,
 		city = @city,
@@ -185,7 +296,6 @@ func (r *CompanyRepository) Update(ctx context.Context, c *company.Company) (*co
 		"id":                 c.ID,
 		"name":               c.Name,
 		"description":        c.Description,
-		"logo_url":           c.LogoURL,
 		"business_email":     c.BusinessEmail,
 		"business_phone":     c.BusinessPhone,
 		"city":               c.City,
@@ -208,6 +318,150 @@ func (r *CompanyRepository) Update(ctx context.Context, c *company.Company) (*co
 	return &row, nil
 }
 
+// SetApproveFollowers changes the follower setting without touching the
+// approval state. Turning it off accepts every pending request.
+func (r *CompanyRepository) SetApproveFollowers(ctx context.Context, companyID uuid.UUID, approve bool) (*company.Company, error) {
+	var updated company.Company
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// accepted first so the returned follower_count includes them
+		if !approve {
+			_, err := tx.Exec(ctx, `
+			UPDATE company_followers
+			SET status = 'ACCEPTED', followed_at = NOW()
+			WHERE company_id = @id AND status = 'PENDING'`, pgx.NamedArgs{"id": companyID})
+			if err != nil {
+				return fmt.Errorf("failed to accept pending followers:%w", err)
+			}
+		}
+
+		stmt := `
+		UPDATE companies
+		SET approve_followers = @approve, updated_at = NOW()
+		WHERE id = @id
+		RETURNING *`
+		rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
+			"id":      companyID,
+			"approve": approve,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update follower settings:%w", err)
+		}
+		updated, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[company.Company])
+		if err != nil {
+			return fmt.Errorf("failed to collect row:%w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (r *CompanyRepository) SetBusinessHours(ctx context.Context, companyID uuid.UUID, hours company.BusinessHours) (*company.Company, error) {
+	stmt := `
+	UPDATE companies
+	SET business_hours = @business_hours, updated_at = NOW()
+	WHERE id = @id
+	RETURNING *`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"id":             companyID,
+		"business_hours": hours,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update business hours:%w", err)
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.Company])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row:%w", err)
+	}
+	return &row, nil
+}
+
+// SetVacation starts a vacation, or moves the end of the one running.
+// A nil until ends it.
+func (r *CompanyRepository) SetVacation(ctx context.Context, companyID uuid.UUID, until *time.Time, message *string) (*company.Company, error) {
+	stmt := `
+	UPDATE companies
+	SET
+		vacation_started_at = CASE
+			WHEN @until::timestamptz IS NULL THEN NULL
+			ELSE COALESCE(vacation_started_at, NOW())
+		END,
+		vacation_until = @until,
+		vacation_message = CASE WHEN @until::timestamptz IS NULL THEN NULL ELSE @message END,
+		updated_at = NOW()
+	WHERE id = @id
+	RETURNING *`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      companyID,
+		"until":   until,
+		"message": message,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update vacation:%w", err)
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.Company])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row:%w", err)
+	}
+	return &row, nil
+}
+
+// EndExpiredVacations clears every vacation whose end has passed and
+// returns the companies that are back.
+func (r *CompanyRepository) EndExpiredVacations(ctx context.Context) ([]uuid.UUID, error) {
+	stmt := `
+		UPDATE companies SET
+			vacation_started_at = NULL,
+			vacation_until = NULL,
+			vacation_message = NULL,
+			updated_at = NOW()
+		WHERE vacation_until <= NOW()
+		RETURNING id
+	`
+	rows, err := r.db.Query(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end expired vacations: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return ids, nil
+}
+
+// VacationsByIDs returns when each of the companies on vacation is back,
+// companies that are not away are left out.
+func (r *CompanyRepository) VacationsByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	stmt := `
+		SELECT id, vacation_until FROM companies
+		WHERE id = ANY(@ids) AND vacation_until > NOW()
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"ids": ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vacations: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[uuid.UUID]time.Time)
+	for rows.Next() {
+		var id uuid.UUID
+		var until time.Time
+		if err := rows.Scan(&id, &until); err != nil {
+			return nil, fmt.Errorf("failed to scan vacation: %w", err)
+		}
+		result[id] = until
+	}
+	return result, rows.Err()
+}
+
 func (r *CompanyRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	stmt := `UPDATE companies
 		SET is_active = FALSE,
@@ -225,7 +479,33 @@ func (r *CompanyRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *CompanyRepository) Approve(ctx context.Context, companyID uuid.UUID, adminID uuid.UUID, notes *string) error {
+func (r *CompanyRepository) Approve(ctx context.Context, companyID, adminID uuid.UUID, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return r.approve(ctx, tx, companyID, adminID, notes)
+	})
+}
+
+// BulkApprove approves every id inside one transaction. Each item runs in its
+// own savepoint, so the ones that fail are returned without undoing the rest.
+func (r *CompanyRepository) BulkApprove(ctx context.Context, companyIDs []uuid.UUID, adminID uuid.UUID, notes *string) (map[uuid.UUID]error, error) {
+	failed := make(map[uuid.UUID]error)
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		for _, id := range companyIDs {
+			if err := pgx.BeginFunc(ctx, tx, func(sp pgx.Tx) error {
+				return r.approve(ctx, sp, id, adminID, notes)
+			}); err != nil {
+				failed[id] = err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk approve companies: %w", err)
+	}
+	return failed, nil
+}
+
+func (r *CompanyRepository) approve(ctx context.Context, tx pgx.Tx, companyID uuid.UUID, adminID uuid.UUID, notes *string) error {
 	query := `UPDATE companies
 				SET
 					approval_status = 'APPROVED',
@@ -234,7 +514,7 @@ func (r *CompanyRepository) Approve(ctx context.Context, companyID uuid.UUID, ad
             rejection_reason = NULL,
             updated_at = NOW()
 				WHERE id = @company_id AND approval_status = 'PENDING'`
-	result, err := r.db.Exec(ctx, query, pgx.NamedArgs{
+	result, err := tx.Exec(ctx, query, pgx.NamedArgs{
 		"admin_id":   adminID,
 		"company_id": companyID,
 	})
@@ -251,7 +531,7 @@ func (r *CompanyRepository) Approve(ctx context.Context, companyID uuid.UUID, ad
 		INSERT INTO company_approval_history (company_id, action, performed_by_id, notes) 
 		VALUES (@company_id, 'APPROVED', @admin_id,@notes)
 	`
-	_, err = r.db.Exec(ctx, historyStmt, pgx.StrictNamedArgs{
+	_, err = tx.Exec(ctx, historyStmt, pgx.StrictNamedArgs{
 		"company_id": companyID,
 		"admin_id":   adminID,
 		"notes":      notes,
@@ -260,10 +540,42 @@ func (r *CompanyRepository) Approve(ctx context.Context, companyID uuid.UUID, ad
 		return fmt.Errorf("failed to log approval history: %w", err)
 	}
 
+	// the decision is made, whoever had it claimed is done with it
+	_, err = tx.Exec(ctx, `DELETE FROM moderation_claims WHERE entity_type = 'COMPANY' AND entity_id = @company_id`, pgx.NamedArgs{
+		"company_id": companyID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release moderation claim: %w", err)
+	}
+
 	return nil
 }
 
 func (r *CompanyRepository) Reject(ctx context.Context, companyID, adminID uuid.UUID, reason string, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return r.reject(ctx, tx, companyID, adminID, reason, notes)
+	})
+}
+
+func (r *CompanyRepository) BulkReject(ctx context.Context, companyIDs []uuid.UUID, adminID uuid.UUID, reason string, notes *string) (map[uuid.UUID]error, error) {
+	failed := make(map[uuid.UUID]error)
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		for _, id := range companyIDs {
+			if err := pgx.BeginFunc(ctx, tx, func(sp pgx.Tx) error {
+				return r.reject(ctx, sp, id, adminID, reason, notes)
+			}); err != nil {
+				failed[id] = err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk reject companies: %w", err)
+	}
+	return failed, nil
+}
+
+func (r *CompanyRepository) reject(ctx context.Context, tx pgx.Tx, companyID, adminID uuid.UUID, reason string, notes *string) error {
 	stmt := `
 		UPDATE companies SET 
 			approval_status = 'REJECTED',
@@ -273,7 +585,7 @@ func (r *CompanyRepository) Reject(ctx context.Context, companyID, adminID uuid.
             updated_at = NOW()
         WHERE id = @company_id AND approval_status = 'PENDING'
     `
-	result, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+	result, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
 		"company_id": companyID,
 		"admin_id":   adminID,
 		"reason":     reason,
@@ -290,7 +602,7 @@ func (r *CompanyRepository) Reject(ctx context.Context, companyID, adminID uuid.
         INSERT INTO company_approval_history (company_id, action, performed_by_id, reason, notes)
         VALUES (@company_id, 'REJECTED', @admin_id, @reason, @notes)
     `
-	_, err = r.db.Exec(ctx, historyStmt, pgx.NamedArgs{
+	_, err = tx.Exec(ctx, historyStmt, pgx.NamedArgs{
 		"company_id": companyID,
 		"admin_id":   adminID,
 		"reason":     reason,
@@ -300,6 +612,13 @@ func (r *CompanyRepository) Reject(ctx context.Context, companyID, adminID uuid.
 		return fmt.Errorf("failed to log rejection history: %w", err)
 	}
 
+	_, err = tx.Exec(ctx, `DELETE FROM moderation_claims WHERE entity_type = 'COMPANY' AND entity_id = @company_id`, pgx.NamedArgs{
+		"company_id": companyID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release moderation claim: %w", err)
+	}
+
 	return nil
 
 }
@@ -328,8 +647,9 @@ func (r *CompanyRepository) Resubmit(ctx context.Context, companyID, userID uuid
 	}
 
 	historyStmt := `
-        INSERT INTO company_approval_history (company_id, action, performed_by_id, notes)
-        VALUES (@company_id, 'RESUBMITTED', @user_id, 'Company resubmitted after rejection')
+        INSERT INTO company_approval_history (company_id, action, performed_by_id, notes, snapshot)
+        SELECT t.id, 'RESUBMITTED', @user_id::uuid, 'Company resubmitted after rejection', company_snapshot(t)
+        FROM companies t WHERE t.id = @company_id
     `
 	_, err = r.db.Exec(ctx, historyStmt, pgx.NamedArgs{
 		"company_id": companyID,
@@ -342,6 +662,117 @@ func (r *CompanyRepository) Resubmit(ctx context.Context, companyID, userID uuid
 	return nil
 }
 
+// Suspend takes an approved company offline until it is reinstated, or
+// until the sweep picks it up once until has passed.
+func (r *CompanyRepository) Suspend(ctx context.Context, companyID, adminID uuid.UUID, reason string, until *time.Time, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		stmt := `
+			UPDATE companies SET
+				is_active = FALSE,
+				suspended_at = NOW(),
+				suspended_until = @until,
+				suspension_reason = @reason,
+				updated_at = NOW()
+			WHERE id = @company_id AND approval_status = 'APPROVED' AND suspended_at IS NULL
+		`
+		result, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
+			"company_id": companyID,
+			"until":      until,
+			"reason":     reason,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to suspend company: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("company not found, not approved or already suspended")
+		}
+
+		historyStmt := `
+			INSERT INTO company_approval_history (company_id, action, performed_by_id, reason, notes)
+			VALUES (@company_id, 'SUSPENDED', @admin_id, @reason, @notes)
+		`
+		_, err = tx.Exec(ctx, historyStmt, pgx.NamedArgs{
+			"company_id": companyID,
+			"admin_id":   adminID,
+			"reason":     reason,
+			"notes":      notes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log suspension history: %w", err)
+		}
+		return nil
+	})
+}
+
+// Reinstate lifts a suspension. adminID is nil when the system does it.
+func (r *CompanyRepository) Reinstate(ctx context.Context, companyID uuid.UUID, adminID *uuid.UUID, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		stmt := `
+			UPDATE companies SET
+				is_active = TRUE,
+				suspended_at = NULL,
+				suspended_until = NULL,
+				suspension_reason = NULL,
+				updated_at = NOW()
+			WHERE id = @company_id AND suspended_at IS NOT NULL
+		`
+		result, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
+			"company_id": companyID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reinstate company: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("company not found or not suspended")
+		}
+
+		historyStmt := `
+			INSERT INTO company_approval_history (company_id, action, performed_by_id, notes)
+			VALUES (@company_id, 'REINSTATED', @admin_id, @notes)
+		`
+		_, err = tx.Exec(ctx, historyStmt, pgx.NamedArgs{
+			"company_id": companyID,
+			"admin_id":   adminID,
+			"notes":      notes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log reinstatement history: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReinstateExpired lifts every suspension whose end date has passed and
+// returns the reinstated companies.
+func (r *CompanyRepository) ReinstateExpired(ctx context.Context) ([]uuid.UUID, error) {
+	stmt := `
+		WITH reinstated AS (
+			UPDATE companies SET
+				is_active = TRUE,
+				suspended_at = NULL,
+				suspended_until = NULL,
+				suspension_reason = NULL,
+				updated_at = NOW()
+			WHERE suspended_at IS NOT NULL AND suspended_until <= NOW()
+			RETURNING id
+		), logged AS (
+			INSERT INTO company_approval_history (company_id, action, notes)
+			SELECT id, 'REINSTATED', 'Suspension period ended' FROM reinstated
+		)
+		SELECT id FROM reinstated
+	`
+	rows, err := r.db.Query(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reinstate expired company suspensions: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return ids, nil
+}
+
 func (r *CompanyRepository) GetApprovalHistory(ctx context.Context, companyID uuid.UUID) ([]company.CompanyApprovalHistory, error) {
 	stmt := `
 		SELECT * FROM company_approval_history
@@ -384,31 +815,29 @@ func (r *CompanyRepository) GetByOwnerAndName(ctx context.Context, ownerID uuid.
 	return &row, nil
 }
 
-func (r *CompanyRepository) GetApprovedCompanyByOwner(ctx context.Context, ownerID uuid.UUID) (*company.Company, error) {
+// ListByOwner returns every company the user owns, oldest first. Deleted
+// companies are left out, suspended ones are not.
+func (r *CompanyRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]company.Company, error) {
 	stmt := `
 		SELECT * FROM companies
 		WHERE owner_id = @owner_id
-		AND approval_status = 'APPROVED'
-		AND is_active = true
-		LIMIT 1
+		AND (is_active = true OR suspended_at IS NOT NULL)
+		ORDER BY created_at ASC
 	`
 
 	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
 		"owner_id": ownerID,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get approved company: %w", err)
+		return nil, fmt.Errorf("failed to list owned companies: %w", err)
 	}
 
-	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.Company])
+	companies, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.Company])
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("failed to collect row: %w", err)
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
 	}
 
-	return &row, nil
+	return companies, nil
 }
 
 func (r *CompanyRepository) CountPendingApprovals(ctx context.Context) (int, error) {
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CompanyAPIKeyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCompanyAPIKeyRepository(db *pgxpool.Pool) *CompanyAPIKeyRepository {
+	return &CompanyAPIKeyRepository{db: db}
+}
+
+func (r *CompanyAPIKeyRepository) Create(ctx context.Context, k *company.APIKey) (*company.APIKey, error) {
+	stmt := `
+		INSERT INTO company_api_keys (
+			company_id,
+			created_by_id,
+			name,
+			key_prefix,
+			key_hash,
+			scopes,
+			expires_at,
+			rotated_from_id
+		) VALUES (
+			@company_id,
+			@created_by_id,
+			@name,
+			@key_prefix,
+			@key_hash,
+			@scopes,
+			@expires_at,
+			@rotated_from_id
+		)
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id":      k.CompanyID,
+		"created_by_id":   k.CreatedByID,
+		"name":            k.Name,
+		"key_prefix":      k.KeyPrefix,
+		"key_hash":        k.KeyHash,
+		"scopes":          k.Scopes,
+		"expires_at":      k.ExpiresAt,
+		"rotated_from_id": k.RotatedFromID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.APIKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect api key: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *CompanyAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*company.APIKey, error) {
+	rows, err := r.db.Query(ctx, `SELECT * FROM company_api_keys WHERE id = @id`, pgx.NamedArgs{
+		"id": id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	k, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.APIKey])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect api key: %w", err)
+	}
+	return &k, nil
+}
+
+// GetActiveByHash only returns keys that are neither revoked nor expired.
+func (r *CompanyAPIKeyRepository) GetActiveByHash(ctx context.Context, keyHash string) (*company.APIKey, error) {
+	stmt := `
+		SELECT * FROM company_api_keys
+		WHERE key_hash = @key_hash
+		AND revoked_at IS NULL
+		AND (expires_at IS NULL OR expires_at > NOW())
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"key_hash": keyHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	k, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.APIKey])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect api key: %w", err)
+	}
+	return &k, nil
+}
+
+func (r *CompanyAPIKeyRepository) ListByCompany(ctx context.Context, companyID uuid.UUID) ([]company.APIKey, error) {
+	stmt := `
+		SELECT * FROM company_api_keys
+		WHERE company_id = @company_id
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	keys, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.APIKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *CompanyAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	stmt := `
+		UPDATE company_api_keys
+		SET revoked_at = NOW(),
+			updated_at = NOW()
+		WHERE id = @id
+		AND revoked_at IS NULL
+	`
+	ct, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ExpireAt shortens the lifetime of a key, used for the rotation grace period.
+func (r *CompanyAPIKeyRepository) ExpireAt(ctx context.Context, id uuid.UUID, at time.Time) error {
+	stmt := `
+		UPDATE company_api_keys
+		SET expires_at = LEAST(COALESCE(expires_at, @at), @at),
+			updated_at = NOW()
+		WHERE id = @id
+		AND revoked_at IS NULL
+	`
+	ct, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+		"id": id,
+		"at": at,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to expire api key: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records usage at most once a minute per key to keep writes cheap.
+func (r *CompanyAPIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, ip string) error {
+	stmt := `
+		UPDATE company_api_keys
+		SET last_used_at = NOW(),
+			last_used_ip = @ip
+		WHERE id = @id
+		AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`
+	_, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+		"id": id,
+		"ip": ip,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+	return nil
+}
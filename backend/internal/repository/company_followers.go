@@ -24,10 +24,12 @@ func NewCompanyFollowerRepository(db *pgxpool.Pool) *CompanyFollowerRepository {
 // FOLLOW COMPANY
 // =============================================
 
-func (r *CompanyFollowerRepository) Follow(ctx context.Context, companyID, userID uuid.UUID) (*company.CompanyFollower, error) {
+// Follow adds the user with the given status, an existing follow or
+// request is returned unchanged.
+func (r *CompanyFollowerRepository) Follow(ctx context.Context, companyID, userID uuid.UUID, status company.FollowStatus) (*company.CompanyFollower, error) {
 	stmt := `
-        INSERT INTO company_followers (company_id, user_id)
-        VALUES (@company_id, @user_id)
+        INSERT INTO company_followers (company_id, user_id, status)
+        VALUES (@company_id, @user_id, @status)
         ON CONFLICT (company_id, user_id) DO NOTHING
         RETURNING *
     `
@@ -35,6 +37,7 @@ func (r *CompanyFollowerRepository) Follow(ctx context.Context, companyID, userI
 	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
 		"company_id": companyID,
 		"user_id":    userID,
+		"status":     status,
 	})
 	if err != nil {
 		// Check if it's our custom trigger error
@@ -89,7 +92,7 @@ func (r *CompanyFollowerRepository) IsFollowing(ctx context.Context, companyID,
 	stmt := `
         SELECT EXISTS(
             SELECT 1 FROM company_followers
-            WHERE company_id = @company_id AND user_id = @user_id
+            WHERE company_id = @company_id AND user_id = @user_id AND status = 'ACCEPTED'
         )
     `
 
@@ -147,7 +150,7 @@ func (r *CompanyFollowerRepository) GetFollowStatusBatch(ctx context.Context, co
 	stmt := `
         SELECT company_id
         FROM company_followers
-        WHERE company_id = ANY(@company_ids) AND user_id = @user_id
+        WHERE company_id = ANY(@company_ids) AND user_id = @user_id AND status = 'ACCEPTED'
     `
 
 	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
@@ -179,12 +182,15 @@ func (r *CompanyFollowerRepository) GetFollowStatusBatch(ctx context.Context, co
 // LIST FOLLOWERS
 // =============================================
 
-func (r *CompanyFollowerRepository) ListFollowers(ctx context.Context, companyID uuid.UUID, page, limit int) (*model.PaginatedResponse[company.CompanyFollowerResponse], error) {
+// ListFollowers lists accepted followers, or pending requests when status
+// is FollowStatusPending.
+func (r *CompanyFollowerRepository) ListFollowers(ctx context.Context, companyID uuid.UUID, status company.FollowStatus, page, limit int) (*model.PaginatedResponse[company.CompanyFollowerResponse], error) {
 	// Count total
 	var total int
-	countStmt := `SELECT COUNT(*) FROM company_followers WHERE company_id = @company_id`
+	countStmt := `SELECT COUNT(*) FROM company_followers WHERE company_id = @company_id AND status = @status`
 	err := r.db.QueryRow(ctx, countStmt, pgx.NamedArgs{
 		"company_id": companyID,
+		"status":     status,
 	}).Scan(&total)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count followers: %w", err)
@@ -198,10 +204,11 @@ func (r *CompanyFollowerRepository) ListFollowers(ctx context.Context, companyID
             cf.user_id,
             u.name as user_name,
             u.email as user_email,
+            cf.status,
             cf.followed_at
         FROM company_followers cf
         JOIN users u ON cf.user_id = u.id
-        WHERE cf.company_id = @company_id
+        WHERE cf.company_id = @company_id AND cf.status = @status
         ORDER BY cf.followed_at DESC
         LIMIT @limit OFFSET @offset
     `
@@ -209,6 +216,7 @@ func (r *CompanyFollowerRepository) ListFollowers(ctx context.Context, companyID
 	offset := (page - 1) * limit
 	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
 		"company_id": companyID,
+		"status":     status,
 		"limit":      limit,
 		"offset":     offset,
 	})
@@ -226,6 +234,7 @@ func (r *CompanyFollowerRepository) ListFollowers(ctx context.Context, companyID
 			&f.UserID,
 			&f.UserName,
 			&f.UserEmail,
+			&f.Status,
 			&f.FollowedAt,
 		)
 		if err != nil {
@@ -254,7 +263,7 @@ func (r *CompanyFollowerRepository) ListFollowedCompanies(ctx context.Context, u
         SELECT COUNT(*) 
         FROM company_followers cf
         JOIN companies c ON cf.company_id = c.id
-        WHERE cf.user_id = @user_id AND c.is_active = true
+        WHERE cf.user_id = @user_id AND cf.status = 'ACCEPTED' AND c.is_active = true
     `
 	err := r.db.QueryRow(ctx, countStmt, pgx.NamedArgs{
 		"user_id": userID,
@@ -268,7 +277,7 @@ func (r *CompanyFollowerRepository) ListFollowedCompanies(ctx context.Context, u
         SELECT c.*
         FROM companies c
         JOIN company_followers cf ON c.id = cf.company_id
-        WHERE cf.user_id = @user_id AND c.is_active = true
+        WHERE cf.user_id = @user_id AND cf.status = 'ACCEPTED' AND c.is_active = true
         ORDER BY cf.followed_at DESC
         LIMIT @limit OFFSET @offset
     `
@@ -297,6 +306,183 @@ func (r *CompanyFollowerRepository) ListFollowedCompanies(ctx context.Context, u
 	}, nil
 }
 
+// =============================================
+// FOLLOW REQUESTS
+// =============================================
+
+// Accept turns a pending request into a follow, nil when there is none.
+func (r *CompanyFollowerRepository) Accept(ctx context.Context, companyID, userID uuid.UUID) (*company.CompanyFollower, error) {
+	stmt := `
+        UPDATE company_followers
+        SET status = 'ACCEPTED', followed_at = NOW()
+        WHERE company_id = @company_id AND user_id = @user_id AND status = 'PENDING'
+        RETURNING *
+    `
+
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"user_id":    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept follow request: %w", err)
+	}
+
+	follower, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.CompanyFollower])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to collect row: %w", err)
+	}
+
+	return &follower, nil
+}
+
+// Remove deletes a follow or request in the given status, it reports
+// whether there was one.
+func (r *CompanyFollowerRepository) Remove(ctx context.Context, companyID, userID uuid.UUID, status company.FollowStatus) (bool, error) {
+	stmt := `
+        DELETE FROM company_followers
+        WHERE company_id = @company_id AND user_id = @user_id AND status = @status
+    `
+
+	result, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"user_id":    userID,
+		"status":     status,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to remove follower: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// =============================================
+// BLOCKED USERS
+// =============================================
+
+// Block drops any follow or request of the user, which takes them off the
+// follower count, and keeps them from following again.
+func (r *CompanyFollowerRepository) Block(ctx context.Context, companyID, userID, blockedByID uuid.UUID, reason *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		args := pgx.NamedArgs{
+			"company_id":    companyID,
+			"user_id":       userID,
+			"blocked_by_id": blockedByID,
+			"reason":        reason,
+		}
+
+		_, err := tx.Exec(ctx, `
+            DELETE FROM company_followers
+            WHERE company_id = @company_id AND user_id = @user_id
+        `, args)
+		if err != nil {
+			return fmt.Errorf("failed to remove follower: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO company_blocked_users (company_id, user_id, blocked_by_id, reason)
+            VALUES (@company_id, @user_id, @blocked_by_id, @reason)
+            ON CONFLICT (company_id, user_id) DO UPDATE SET reason = EXCLUDED.reason
+        `, args)
+		if err != nil {
+			return fmt.Errorf("failed to block user: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *CompanyFollowerRepository) Unblock(ctx context.Context, companyID, userID uuid.UUID) error {
+	stmt := `
+        DELETE FROM company_blocked_users
+        WHERE company_id = @company_id AND user_id = @user_id
+    `
+
+	result, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"user_id":    userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("user is not blocked")
+	}
+
+	return nil
+}
+
+func (r *CompanyFollowerRepository) ListBlocked(ctx context.Context, companyID uuid.UUID, page, limit int) (*model.PaginatedResponse[company.BlockedUser], error) {
+	var total int
+	countStmt := `SELECT COUNT(*) FROM company_blocked_users WHERE company_id = @company_id`
+	err := r.db.QueryRow(ctx, countStmt, pgx.NamedArgs{
+		"company_id": companyID,
+	}).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count blocked users: %w", err)
+	}
+
+	stmt := `
+        SELECT
+            b.company_id,
+            b.user_id,
+            u.name AS user_name,
+            u.email AS user_email,
+            b.blocked_by_id,
+            b.reason,
+            b.created_at
+        FROM company_blocked_users b
+        JOIN users u ON b.user_id = u.id
+        WHERE b.company_id = @company_id
+        ORDER BY b.created_at DESC
+        LIMIT @limit OFFSET @offset
+    `
+
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"limit":      limit,
+		"offset":     (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked users: %w", err)
+	}
+
+	blocked, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.BlockedUser])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect blocked users: %w", err)
+	}
+
+	return &model.PaginatedResponse[company.BlockedUser]{
+		Data:       blocked,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+func (r *CompanyFollowerRepository) IsBlocked(ctx context.Context, companyID, userID uuid.UUID) (bool, error) {
+	stmt := `
+        SELECT EXISTS(
+            SELECT 1 FROM company_blocked_users
+            WHERE company_id = @company_id AND user_id = @user_id
+        )
+    `
+
+	var exists bool
+	err := r.db.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"user_id":    userID,
+	}).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check block status: %w", err)
+	}
+
+	return exists, nil
+}
+
 // =============================================
 // CHECK PRODUCT VIEW PERMISSION
 // =============================================
@@ -345,12 +531,21 @@ func (r *CompanyFollowerRepository) CanViewProducts(ctx context.Context, company
 		return true, nil
 	}
 
+	// blocked users only see public products
+	blocked, err := r.IsBlocked(ctx, companyID, *userID)
+	if err != nil {
+		return false, err
+	}
+	if blocked {
+		return false, nil
+	}
+
 	// PRIVATE - only owner can view
 	if visibility == company.ProductVisibilityPrivate {
 		return false, nil
 	}
 
-	// FOLLOWERS_ONLY - check if user follows
+	// FOLLOWERS_ONLY - check if user follows, pending requests do not count
 	if visibility == company.ProductVisibilityFollowersOnly {
 		return r.IsFollowing(ctx, companyID, *userID)
 	}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CompanyHolidayRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCompanyHolidayRepository(db *pgxpool.Pool) *CompanyHolidayRepository {
+	return &CompanyHolidayRepository{db: db}
+}
+
+// ListFrom returns the company's holidays on or after the given date.
+func (r *CompanyHolidayRepository) ListFrom(ctx context.Context, companyID uuid.UUID, from time.Time) ([]company.Holiday, error) {
+	stmt := `
+		SELECT * FROM company_holidays
+		WHERE company_id = @company_id AND holiday_date >= @from::date
+		ORDER BY holiday_date
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"from":       from,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list holidays: %w", err)
+	}
+
+	holidays, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.Holiday])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect holidays: %w", err)
+	}
+	return holidays, nil
+}
+
+// ReplaceFrom swaps the holidays on or after the given date, earlier ones
+// are kept as they were.
+func (r *CompanyHolidayRepository) ReplaceFrom(ctx context.Context, companyID uuid.UUID, from time.Time, holidays []company.Holiday) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			DELETE FROM company_holidays
+			WHERE company_id = @company_id AND holiday_date >= @from::date
+		`, pgx.NamedArgs{
+			"company_id": companyID,
+			"from":       from,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clear holidays: %w", err)
+		}
+
+		if len(holidays) == 0 {
+			return nil
+		}
+
+		dates := make([]string, len(holidays))
+		notes := make([]*string, len(holidays))
+		for i, h := range holidays {
+			dates[i] = h.Date.Format(time.DateOnly)
+			notes[i] = h.Note
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO company_holidays (company_id, holiday_date, note)
+			SELECT @company_id, t.holiday_date::date, t.note
+			FROM unnest(@dates::text[], @notes::text[]) AS t(holiday_date, note)
+		`, pgx.NamedArgs{
+			"company_id": companyID,
+			"dates":      dates,
+			"notes":      notes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert holidays: %w", err)
+		}
+		return nil
+	})
+}
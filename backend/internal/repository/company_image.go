@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CompanyImageRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCompanyImageRepository(db *pgxpool.Pool) *CompanyImageRepository {
+	return &CompanyImageRepository{db: db}
+}
+
+func (r *CompanyImageRepository) Create(ctx context.Context, img *company.CompanyImage) (*company.CompanyImage, error) {
+	stmt := `
+		INSERT INTO company_images (
+			id,
+			company_id,
+			uploaded_by_id,
+			kind,
+			s3_key,
+			image_url,
+			content_type
+		) VALUES (
+			@id,
+			@company_id,
+			@uploaded_by_id,
+			@kind,
+			@s3_key,
+			@image_url,
+			@content_type
+		)
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"id":             img.ID,
+		"company_id":     img.CompanyID,
+		"uploaded_by_id": img.UploadedByID,
+		"kind":           img.Kind,
+		"s3_key":         img.S3Key,
+		"image_url":      img.ImageURL,
+		"content_type":   img.ContentType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create company image: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.CompanyImage])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect company image: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *CompanyImageRepository) GetByID(ctx context.Context, id uuid.UUID) (*company.CompanyImage, error) {
+	rows, err := r.db.Query(ctx, `SELECT * FROM company_images WHERE id = @id`, pgx.NamedArgs{
+		"id": id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company image: %w", err)
+	}
+
+	img, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.CompanyImage])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect company image: %w", err)
+	}
+	return &img, nil
+}
+
+// ListByCompany returns the company's images in the given statuses, newest first.
+func (r *CompanyImageRepository) ListByCompany(ctx context.Context, companyID uuid.UUID, kind *company.ImageKind, statuses []company.ImageStatus) ([]company.CompanyImage, error) {
+	stmt := `
+		SELECT * FROM company_images
+		WHERE company_id = @company_id
+			AND (@kind::text IS NULL OR kind = @kind)
+			AND status = ANY(@statuses)
+		ORDER BY kind, created_at DESC
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"kind":       kind,
+		"statuses":   imageStatusStrings(statuses),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list company images: %w", err)
+	}
+
+	images, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.CompanyImage])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect company images: %w", err)
+	}
+	return images, nil
+}
+
+func (r *CompanyImageRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM company_images WHERE id = @id`, pgx.NamedArgs{
+		"id": id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete company image: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetUploaded records what the server read from the uploaded object.
+func (r *CompanyImageRepository) SetUploaded(ctx context.Context, id uuid.UUID, sizeBytes int64, width, height int) (*company.CompanyImage, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE company_images SET
+			size_bytes = @size_bytes,
+			width = @width,
+			height = @height,
+			updated_at = NOW()
+		WHERE id = @id AND status = 'UPLOADING'
+		RETURNING *
+	`, pgx.NamedArgs{
+		"id":         id,
+		"size_bytes": sizeBytes,
+		"width":      width,
+		"height":     height,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update company image: %w", err)
+	}
+
+	img, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.CompanyImage])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect company image: %w", err)
+	}
+	return &img, nil
+}
+
+// Queue puts an uploaded image up for review. An earlier image still
+// waiting for review is replaced and returned so its object can be removed.
+func (r *CompanyImageRepository) Queue(ctx context.Context, img *company.CompanyImage) (*company.CompanyImage, []company.CompanyImage, error) {
+	var queued company.CompanyImage
+	var replaced []company.CompanyImage
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		replaced, err = replaceImages(ctx, tx, img, company.ImageStatusPending)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `
+			UPDATE company_images SET
+				status = 'PENDING',
+				updated_at = NOW()
+			WHERE id = @id AND status = 'UPLOADING'
+			RETURNING *
+		`, pgx.NamedArgs{
+			"id": img.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue company image: %w", err)
+		}
+
+		queued, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[company.CompanyImage])
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("image not found or already confirmed")
+			}
+			return fmt.Errorf("failed to collect company image: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &queued, replaced, nil
+}
+
+// Activate makes the image the one the company shows, replacing the
+// current one and any older image waiting for review. reviewerID is set
+// when an admin approved it. The replaced images are returned so their
+// objects can be removed.
+func (r *CompanyImageRepository) Activate(ctx context.Context, img *company.CompanyImage, reviewerID *uuid.UUID) (*company.CompanyImage, []company.CompanyImage, error) {
+	var active company.CompanyImage
+	var replaced []company.CompanyImage
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		replaced, err = replaceImages(ctx, tx, img, company.ImageStatusActive, company.ImageStatusPending)
+		if err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `
+			UPDATE company_images SET
+				status = 'ACTIVE',
+				reviewed_by_id = @reviewer_id,
+				reviewed_at = CASE WHEN @reviewer_id::uuid IS NULL THEN NULL ELSE NOW() END,
+				updated_at = NOW()
+			WHERE id = @id AND status IN ('UPLOADING', 'PENDING')
+			RETURNING *
+		`, pgx.NamedArgs{
+			"id":          img.ID,
+			"reviewer_id": reviewerID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to activate company image: %w", err)
+		}
+
+		active, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[company.CompanyImage])
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("image not found or already reviewed")
+			}
+			return fmt.Errorf("failed to collect company image: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE companies SET
+				logo_url = CASE WHEN @kind = 'LOGO' THEN @image_url ELSE logo_url END,
+				banner_url = CASE WHEN @kind = 'BANNER' THEN @image_url ELSE banner_url END,
+				updated_at = NOW()
+			WHERE id = @company_id
+		`, pgx.NamedArgs{
+			"company_id": active.CompanyID,
+			"kind":       string(active.Kind),
+			"image_url":  active.ImageURL,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set company image: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &active, replaced, nil
+}
+
+func replaceImages(ctx context.Context, tx pgx.Tx, img *company.CompanyImage, statuses ...company.ImageStatus) ([]company.CompanyImage, error) {
+	rows, err := tx.Query(ctx, `
+		UPDATE company_images SET
+			status = 'REPLACED',
+			updated_at = NOW()
+		WHERE company_id = @company_id
+			AND kind = @kind
+			AND status = ANY(@statuses)
+			AND id <> @id
+		RETURNING *
+	`, pgx.NamedArgs{
+		"id":         img.ID,
+		"company_id": img.CompanyID,
+		"kind":       img.Kind,
+		"statuses":   imageStatusStrings(statuses),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace company images: %w", err)
+	}
+
+	replaced, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.CompanyImage])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect company images: %w", err)
+	}
+	return replaced, nil
+}
+
+func imageStatusStrings(statuses []company.ImageStatus) []string {
+	out := make([]string, len(statuses))
+	for i, s := range statuses {
+		out[i] = string(s)
+	}
+	return out
+}
+
+func (r *CompanyImageRepository) Reject(ctx context.Context, id, adminID uuid.UUID, reason string) (*company.CompanyImage, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE company_images SET
+			status = 'REJECTED',
+			reviewed_by_id = @admin_id,
+			reviewed_at = NOW(),
+			rejection_reason = @reason,
+			updated_at = NOW()
+		WHERE id = @id AND status = 'PENDING'
+		RETURNING *
+	`, pgx.NamedArgs{
+		"id":       id,
+		"admin_id": adminID,
+		"reason":   reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject company image: %w", err)
+	}
+
+	img, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.CompanyImage])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("image not found or not in pending status")
+		}
+		return nil, fmt.Errorf("failed to collect company image: %w", err)
+	}
+	return &img, nil
+}
+
+// ListPending is the admin queue of logo changes, oldest first.
+func (r *CompanyImageRepository) ListPending(ctx context.Context, page, limit int) (*model.PaginatedResponse[company.PendingCompanyImage], error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM company_images WHERE status = 'PENDING'`).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count pending company images: %w", err)
+	}
+
+	stmt := `
+		SELECT
+			ci.*,
+			c.name AS company_name,
+			c.logo_url AS current_logo_url
+		FROM company_images ci
+		JOIN companies c ON c.id = ci.company_id
+		WHERE ci.status = 'PENDING'
+		ORDER BY ci.created_at ASC
+		LIMIT @limit OFFSET @offset
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"limit":  limit,
+		"offset": (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending company images: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.PendingCompanyImage])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &model.PaginatedResponse[company.PendingCompanyImage]{
+		Data:       items,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CompanyKYCRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCompanyKYCRepository(db *pgxpool.Pool) *CompanyKYCRepository {
+	return &CompanyKYCRepository{db: db}
+}
+
+func (r *CompanyKYCRepository) Create(ctx context.Context, d *company.KYCDocument) (*company.KYCDocument, error) {
+	stmt := `
+		INSERT INTO company_kyc_documents (
+			id,
+			company_id,
+			uploaded_by_id,
+			document_type,
+			document_number,
+			s3_key,
+			file_name,
+			content_type
+		) VALUES (
+			@id,
+			@company_id,
+			@uploaded_by_id,
+			@document_type,
+			@document_number,
+			@s3_key,
+			@file_name,
+			@content_type
+		)
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"id":              d.ID,
+		"company_id":      d.CompanyID,
+		"uploaded_by_id":  d.UploadedByID,
+		"document_type":   d.DocumentType,
+		"document_number": d.DocumentNumber,
+		"s3_key":          d.S3Key,
+		"file_name":       d.FileName,
+		"content_type":    d.ContentType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kyc document: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.KYCDocument])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect kyc document: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *CompanyKYCRepository) GetByID(ctx context.Context, id uuid.UUID) (*company.KYCDocument, error) {
+	rows, err := r.db.Query(ctx, `SELECT * FROM company_kyc_documents WHERE id = @id`, pgx.NamedArgs{
+		"id": id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kyc document: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.KYCDocument])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect kyc document: %w", err)
+	}
+	return &d, nil
+}
+
+// ListByCompany returns the confirmed documents, rows still waiting for
+// their upload are left out.
+func (r *CompanyKYCRepository) ListByCompany(ctx context.Context, companyID uuid.UUID) ([]company.KYCDocument, error) {
+	stmt := `
+		SELECT * FROM company_kyc_documents
+		WHERE company_id = @company_id AND status = 'UPLOADED'
+		ORDER BY document_type, created_at DESC
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kyc documents: %w", err)
+	}
+
+	docs, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.KYCDocument])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect kyc documents: %w", err)
+	}
+	return docs, nil
+}
+
+// SetUploaded marks the document as uploaded once its object was found.
+func (r *CompanyKYCRepository) SetUploaded(ctx context.Context, id uuid.UUID, sizeBytes int64) (*company.KYCDocument, error) {
+	rows, err := r.db.Query(ctx, `
+		UPDATE company_kyc_documents SET
+			status = 'UPLOADED',
+			size_bytes = @size_bytes,
+			updated_at = NOW()
+		WHERE id = @id AND status = 'UPLOADING'
+		RETURNING *
+	`, pgx.NamedArgs{
+		"id":         id,
+		"size_bytes": sizeBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update kyc document: %w", err)
+	}
+
+	d, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.KYCDocument])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect kyc document: %w", err)
+	}
+	return &d, nil
+}
+
+func (r *CompanyKYCRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ct, err := r.db.Exec(ctx, `DELETE FROM company_kyc_documents WHERE id = @id`, pgx.NamedArgs{
+		"id": id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete kyc document: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
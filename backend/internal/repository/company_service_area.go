@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CompanyServiceAreaRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCompanyServiceAreaRepository(db *pgxpool.Pool) *CompanyServiceAreaRepository {
+	return &CompanyServiceAreaRepository{db: db}
+}
+
+func (r *CompanyServiceAreaRepository) ListByCompany(ctx context.Context, companyID uuid.UUID) ([]company.ServiceArea, error) {
+	stmt := `
+		SELECT * FROM company_service_areas
+		WHERE company_id = @company_id
+		ORDER BY area_type, value
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service areas: %w", err)
+	}
+
+	areas, err := pgx.CollectRows(rows, pgx.RowToStructByName[company.ServiceArea])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect service areas: %w", err)
+	}
+	return areas, nil
+}
+
+// Replace swaps the company's areas for the given ones in one go.
+func (r *CompanyServiceAreaRepository) Replace(ctx context.Context, companyID uuid.UUID, areas []company.ServiceArea) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `DELETE FROM company_service_areas WHERE company_id = @company_id`, pgx.NamedArgs{
+			"company_id": companyID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clear service areas: %w", err)
+		}
+
+		if len(areas) == 0 {
+			return nil
+		}
+
+		types := make([]string, len(areas))
+		values := make([]string, len(areas))
+		labels := make([]*string, len(areas))
+		for i, a := range areas {
+			types[i] = string(a.Type)
+			values[i] = a.Value
+			labels[i] = a.Label
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO company_service_areas (company_id, area_type, value, label)
+			SELECT @company_id, t.area_type, t.value, t.label
+			FROM unnest(@types::text[], @values::text[], @labels::text[]) AS t(area_type, value, label)
+		`, pgx.NamedArgs{
+			"company_id": companyID,
+			"types":      types,
+			"values":     values,
+			"labels":     labels,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert service areas: %w", err)
+		}
+		return nil
+	})
+}
+
+// Match returns the most specific area of the company covering the
+// pincode. hasAreas is false when the company has not limited where it
+// delivers.
+func (r *CompanyServiceAreaRepository) Match(ctx context.Context, companyID uuid.UUID, lookup company.ServiceAreaLookup) (area *company.ServiceArea, hasAreas bool, err error) {
+	stmt := `
+		SELECT * FROM company_service_areas
+		WHERE company_id = @company_id AND (
+			(area_type = 'PINCODE' AND value = @pincode)
+			OR (area_type = 'PINCODE_PREFIX' AND value = ANY(@prefixes))
+			OR (area_type = 'STATE' AND value = @state_code)
+		)
+		ORDER BY
+			CASE area_type WHEN 'PINCODE' THEN 0 WHEN 'PINCODE_PREFIX' THEN 1 ELSE 2 END,
+			length(value) DESC
+		LIMIT 1
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"pincode":    lookup.Pincode,
+		"prefixes":   lookup.Prefixes,
+		"state_code": lookup.StateCode,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to match service area: %w", err)
+	}
+
+	a, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[company.ServiceArea])
+	if err == nil {
+		return &a, true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, fmt.Errorf("failed to collect service area: %w", err)
+	}
+
+	err = r.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM company_service_areas WHERE company_id = @company_id)
+	`, pgx.NamedArgs{
+		"company_id": companyID,
+	}).Scan(&hasAreas)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check service areas: %w", err)
+	}
+	return nil, hasAreas, nil
+}
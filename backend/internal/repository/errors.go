@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrNotFound = errors.New("repository: not found")
+	ErrConflict = errors.New("repository: conflict")
 )
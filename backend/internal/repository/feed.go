@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model/feed"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type FeedRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewFeedRepository(db *pgxpool.Pool) *FeedRepository {
+	return &FeedRepository{db: db}
+}
+
+// List returns up to limit events from companies the user follows, newest
+// first and starting after cursor when it is set. Each product appears
+// once, with its latest event, and only while the user may see it.
+func (r *FeedRepository) List(ctx context.Context, userID uuid.UUID, cursor *feed.Cursor, limit int) ([]feed.Event, error) {
+	stmt := `
+		SELECT
+			e.id,
+			e.event,
+			e.product_id,
+			e.company_id,
+			c.name AS company_name,
+			e.variant_id,
+			v.label AS variant_label,
+			e.old_price,
+			e.new_price,
+			e.created_at
+		FROM product_feed_events e
+		JOIN company_followers cf ON cf.company_id = e.company_id
+			AND cf.user_id = @user_id AND cf.status = 'ACCEPTED'
+		JOIN companies c ON c.id = e.company_id
+		JOIN products p ON p.id = e.product_id
+		LEFT JOIN product_variants v ON v.id = e.variant_id
+		WHERE c.approval_status = 'APPROVED' AND c.is_active
+			AND c.hidden_at IS NULL AND c.suspended_at IS NULL
+			AND p.approval_status = 'APPROVED' AND p.is_active
+			AND p.hidden_at IS NULL AND p.suspended_at IS NULL
+			-- followers see PUBLIC and FOLLOWERS_ONLY products
+			AND COALESCE(p.visibility, c.product_visibility) <> 'PRIVATE'
+			AND NOT EXISTS (
+				SELECT 1 FROM company_blocked_users b
+				WHERE b.company_id = e.company_id AND b.user_id = @user_id
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM product_feed_events n
+				WHERE n.product_id = e.product_id
+					AND (n.created_at, n.id) > (e.created_at, e.id)
+			)
+	`
+	args := pgx.NamedArgs{
+		"user_id": userID,
+		"limit":   limit,
+	}
+	if cursor != nil {
+		stmt += ` AND (e.created_at, e.id) < (@cursor_at, @cursor_id)`
+		args["cursor_at"] = cursor.CreatedAt
+		args["cursor_id"] = cursor.ID
+	}
+	stmt += ` ORDER BY e.created_at DESC, e.id DESC LIMIT @limit`
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[feed.Event])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
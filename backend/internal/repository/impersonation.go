@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/auth"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ImpersonationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewImpersonationRepository(db *pgxpool.Pool) *ImpersonationRepository {
+	return &ImpersonationRepository{db: db}
+}
+
+type ImpersonationFilter struct {
+	AdminID      *uuid.UUID
+	TargetUserID *uuid.UUID
+	ActiveOnly   bool
+	Page         int
+	Limit        int
+}
+
+func (r *ImpersonationRepository) Create(ctx context.Context, s *auth.ImpersonationSession) (*auth.ImpersonationSession, error) {
+	stmt := `
+		INSERT INTO impersonation_sessions (
+			admin_id,
+			target_user_id,
+			reason,
+			read_only,
+			ip_address,
+			user_agent,
+			expires_at
+		) VALUES (
+			@admin_id,
+			@target_user_id,
+			@reason,
+			@read_only,
+			@ip_address,
+			@user_agent,
+			@expires_at
+		)
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"admin_id":       s.AdminID,
+		"target_user_id": s.TargetUserID,
+		"reason":         s.Reason,
+		"read_only":      s.ReadOnly,
+		"ip_address":     s.IPAddress,
+		"user_agent":     s.UserAgent,
+		"expires_at":     s.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create impersonation session: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[auth.ImpersonationSession])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect impersonation session: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *ImpersonationRepository) GetByID(ctx context.Context, id uuid.UUID) (*auth.ImpersonationSession, error) {
+	rows, err := r.db.Query(ctx, `SELECT * FROM impersonation_sessions WHERE id = @id`, pgx.NamedArgs{
+		"id": id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get impersonation session: %w", err)
+	}
+
+	s, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[auth.ImpersonationSession])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect impersonation session: %w", err)
+	}
+	return &s, nil
+}
+
+// IsActive is hit on every request made with an impersonation token.
+func (r *ImpersonationRepository) IsActive(ctx context.Context, id uuid.UUID) (bool, error) {
+	stmt := `
+		SELECT EXISTS (
+			SELECT 1 FROM impersonation_sessions
+			WHERE id = @id
+			AND ended_at IS NULL
+			AND expires_at > NOW()
+		)
+	`
+	var active bool
+	if err := r.db.QueryRow(ctx, stmt, pgx.NamedArgs{"id": id}).Scan(&active); err != nil {
+		return false, fmt.Errorf("failed to check impersonation session: %w", err)
+	}
+	return active, nil
+}
+
+func (r *ImpersonationRepository) End(ctx context.Context, id uuid.UUID, endedBy uuid.UUID) (*auth.ImpersonationSession, error) {
+	stmt := `
+		UPDATE impersonation_sessions
+		SET ended_at = NOW(),
+			ended_by = @ended_by,
+			updated_at = NOW()
+		WHERE id = @id
+		AND ended_at IS NULL
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"id":       id,
+		"ended_by": endedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to end impersonation session: %w", err)
+	}
+
+	s, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[auth.ImpersonationSession])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect impersonation session: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *ImpersonationRepository) List(ctx context.Context, filter ImpersonationFilter) (*model.PaginatedResponse[auth.ImpersonationSession], error) {
+	base := `FROM impersonation_sessions WHERE 1=1`
+	args := pgx.NamedArgs{}
+
+	if filter.AdminID != nil {
+		base += ` AND admin_id = @admin_id`
+		args["admin_id"] = *filter.AdminID
+	}
+
+	if filter.TargetUserID != nil {
+		base += ` AND target_user_id = @target_user_id`
+		args["target_user_id"] = *filter.TargetUserID
+	}
+
+	if filter.ActiveOnly {
+		base += ` AND ended_at IS NULL AND expires_at > NOW()`
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) `+base, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count impersonation sessions: %w", err)
+	}
+
+	stmt := `SELECT * ` + base + ` ORDER BY created_at DESC LIMIT @limit OFFSET @offset`
+	args["limit"] = filter.Limit
+	args["offset"] = (filter.Page - 1) * filter.Limit
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list impersonation sessions: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[auth.ImpersonationSession])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &model.PaginatedResponse[auth.ImpersonationSession]{
+		Data:       items,
+		Total:      total,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		TotalPages: (total + filter.Limit - 1) / filter.Limit,
+	}, nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/auth"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LoginEventRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewLoginEventRepository(db *pgxpool.Pool) *LoginEventRepository {
+	return &LoginEventRepository{db: db}
+}
+
+type LoginEventFilter struct {
+	UserID      uuid.UUID
+	Success     *bool
+	FlaggedOnly bool
+	Page        int
+	Limit       int
+}
+
+func (r *LoginEventRepository) Create(ctx context.Context, e *auth.LoginEvent) (*auth.LoginEvent, error) {
+	stmt := `
+		INSERT INTO login_events (
+			user_id,
+			email,
+			auth_provider,
+			success,
+			failure_reason,
+			ip_address,
+			user_agent,
+			device_fingerprint,
+			country,
+			region,
+			is_new_device,
+			is_new_location
+		) VALUES (
+			@user_id,
+			@email,
+			@auth_provider,
+			@success,
+			@failure_reason,
+			@ip_address,
+			@user_agent,
+			@device_fingerprint,
+			@country,
+			@region,
+			@is_new_device,
+			@is_new_location
+		)
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":            e.UserID,
+		"email":              e.Email,
+		"auth_provider":      e.AuthProvider,
+		"success":            e.Success,
+		"failure_reason":     e.FailureReason,
+		"ip_address":         e.IPAddress,
+		"user_agent":         e.UserAgent,
+		"device_fingerprint": e.DeviceFingerprint,
+		"country":            e.Country,
+		"region":             e.Region,
+		"is_new_device":      e.IsNewDevice,
+		"is_new_location":    e.IsNewLocation,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create login event: %w", err)
+	}
+
+	created, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[auth.LoginEvent])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect login event: %w", err)
+	}
+	return &created, nil
+}
+
+func (r *LoginEventRepository) CountSuccessful(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	stmt := `SELECT COUNT(*) FROM login_events WHERE user_id = @user_id AND success`
+	if err := r.db.QueryRow(ctx, stmt, pgx.NamedArgs{"user_id": userID}).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count login events: %w", err)
+	}
+	return count, nil
+}
+
+func (r *LoginEventRepository) HasSeenDevice(ctx context.Context, userID uuid.UUID, fingerprint string) (bool, error) {
+	stmt := `
+		SELECT EXISTS (
+			SELECT 1 FROM login_events
+			WHERE user_id = @user_id
+			AND success
+			AND device_fingerprint = @fingerprint
+		)
+	`
+	var seen bool
+	err := r.db.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"user_id":     userID,
+		"fingerprint": fingerprint,
+	}).Scan(&seen)
+	if err != nil {
+		return false, fmt.Errorf("failed to check known devices: %w", err)
+	}
+	return seen, nil
+}
+
+func (r *LoginEventRepository) HasSeenLocation(ctx context.Context, userID uuid.UUID, country, region string) (bool, error) {
+	stmt := `
+		SELECT EXISTS (
+			SELECT 1 FROM login_events
+			WHERE user_id = @user_id
+			AND success
+			AND country = @country
+			AND COALESCE(region, '') = @region
+		)
+	`
+	var seen bool
+	err := r.db.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+		"country": country,
+		"region":  region,
+	}).Scan(&seen)
+	if err != nil {
+		return false, fmt.Errorf("failed to check known locations: %w", err)
+	}
+	return seen, nil
+}
+
+func (r *LoginEventRepository) List(ctx context.Context, filter LoginEventFilter) (*model.PaginatedResponse[auth.LoginEvent], error) {
+	base := `FROM login_events WHERE user_id = @user_id`
+	args := pgx.NamedArgs{"user_id": filter.UserID}
+
+	if filter.Success != nil {
+		base += ` AND success = @success`
+		args["success"] = *filter.Success
+	}
+
+	if filter.FlaggedOnly {
+		base += ` AND (is_new_device OR is_new_location)`
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) `+base, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count login events: %w", err)
+	}
+
+	stmt := `SELECT * ` + base + ` ORDER BY created_at DESC LIMIT @limit OFFSET @offset`
+	args["limit"] = filter.Limit
+	args["offset"] = (filter.Page - 1) * filter.Limit
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login events: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[auth.LoginEvent])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &model.PaginatedResponse[auth.LoginEvent]{
+		Data:       items,
+		Total:      total,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		TotalPages: (total + filter.Limit - 1) / filter.Limit,
+	}, nil
+}
@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/moderation"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ModerationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewModerationRepository(db *pgxpool.Pool) *ModerationRepository {
+	return &ModerationRepository{db: db}
+}
+
+type ModerationQueueFilter struct {
+	SubmittedBefore *time.Time
+	SubmittedAfter  *time.Time
+
+	CategoryID *uuid.UUID
+	Flagged    *bool
+	State      *string
+
+	MinResubmissions *int
+	MaxResubmissions *int
+
+	ReviewerID *uuid.UUID
+	Unclaimed  bool
+
+	// items submitted before this are counted as SLA breaches
+	SLACutoff time.Time
+
+	Page  int
+	Limit int
+}
+
+// queueConditions appends the filters shared by both queues. alias is the
+// table holding submitted_at, stateColumn the column the state filter hits.
+func queueConditions(base string, args pgx.NamedArgs, filter ModerationQueueFilter, alias, stateColumn string) string {
+	if filter.SubmittedBefore != nil {
+		base += ` AND ` + alias + `.submitted_at <= @submitted_before`
+		args["submitted_before"] = *filter.SubmittedBefore
+	}
+
+	if filter.SubmittedAfter != nil {
+		base += ` AND ` + alias + `.submitted_at >= @submitted_after`
+		args["submitted_after"] = *filter.SubmittedAfter
+	}
+
+	if filter.State != nil {
+		base += ` AND lower(` + stateColumn + `) = lower(@state)`
+		args["state"] = *filter.State
+	}
+
+	if filter.MinResubmissions != nil {
+		base += ` AND rs.resubmission_count >= @min_resubmissions`
+		args["min_resubmissions"] = *filter.MinResubmissions
+	}
+
+	if filter.MaxResubmissions != nil {
+		base += ` AND rs.resubmission_count <= @max_resubmissions`
+		args["max_resubmissions"] = *filter.MaxResubmissions
+	}
+
+	if filter.ReviewerID != nil {
+		base += ` AND mc.reviewer_id = @reviewer_id`
+		args["reviewer_id"] = *filter.ReviewerID
+	}
+
+	if filter.Unclaimed {
+		base += ` AND mc.id IS NULL`
+	}
+
+	return base
+}
+
+func (r *ModerationRepository) queueSLA(ctx context.Context, base string, args pgx.NamedArgs, alias string) (int, moderation.QueueSLA, error) {
+	var (
+		total int
+		sla   moderation.QueueSLA
+	)
+	stmt := `SELECT COUNT(*), MIN(` + alias + `.submitted_at), COUNT(*) FILTER (WHERE ` + alias + `.submitted_at < @sla_cutoff) ` + base
+	if err := r.db.QueryRow(ctx, stmt, args).Scan(&total, &sla.OldestSubmittedAt, &sla.BreachedCount); err != nil {
+		return 0, sla, fmt.Errorf("failed to count moderation queue: %w", err)
+	}
+	return total, sla, nil
+}
+
+// ListCompanyQueue returns pending companies oldest first, with their
+// resubmission count and the active claim if someone is reviewing them.
+func (r *ModerationRepository) ListCompanyQueue(ctx context.Context, filter ModerationQueueFilter) (*moderation.QueueResponse[moderation.CompanyQueueItem], error) {
+	base := `
+		FROM companies c
+		LEFT JOIN moderation_claims mc
+			ON mc.entity_type = 'COMPANY' AND mc.entity_id = c.id AND mc.expires_at > NOW()
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*)::int AS resubmission_count
+			FROM company_approval_history h
+			WHERE h.company_id = c.id AND h.action = 'RESUBMITTED'
+		) rs
+		WHERE c.approval_status = 'PENDING' AND c.is_active = TRUE`
+	args := pgx.NamedArgs{"sla_cutoff": filter.SLACutoff}
+
+	base = queueConditions(base, args, filter, "c", "c.state")
+
+	total, sla, err := r.queueSLA(ctx, base, args, "c")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := `
+		SELECT
+			c.id,
+			c.owner_id,
+			c.name,
+			c.city,
+			c.state,
+			c.submitted_at,
+			(EXTRACT(EPOCH FROM NOW() - c.submitted_at) / 60)::int AS waiting_minutes,
+			rs.resubmission_count,
+			mc.reviewer_id,
+			mc.expires_at AS claim_expires_at
+		` + base + ` ORDER BY c.submitted_at ASC LIMIT @limit OFFSET @offset`
+	args["limit"] = filter.Limit
+	args["offset"] = (filter.Page - 1) * filter.Limit
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list company queue: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[moderation.CompanyQueueItem])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &moderation.QueueResponse[moderation.CompanyQueueItem]{
+		PaginatedResponse: model.PaginatedResponse[moderation.CompanyQueueItem]{
+			Data:       items,
+			Total:      total,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: (total + filter.Limit - 1) / filter.Limit,
+		},
+		SLA: sla,
+	}, nil
+}
+
+// ListProductQueue is the product counterpart of ListCompanyQueue, the
+// state filter applies to the owning company.
+func (r *ModerationRepository) ListProductQueue(ctx context.Context, filter ModerationQueueFilter) (*moderation.QueueResponse[moderation.ProductQueueItem], error) {
+	base := `
+		FROM products p
+		JOIN companies co ON co.id = p.company_id
+		LEFT JOIN categories cat ON cat.id = p.category_id
+		LEFT JOIN moderation_claims mc
+			ON mc.entity_type = 'PRODUCT' AND mc.entity_id = p.id AND mc.expires_at > NOW()
+		CROSS JOIN LATERAL (
+			SELECT COUNT(*)::int AS resubmission_count
+			FROM product_approval_history h
+			WHERE h.product_id = p.id AND h.action = 'RESUBMITTED'
+		) rs
+		CROSS JOIN LATERAL (
+			SELECT EXISTS (
+				SELECT 1 FROM product_approval_history f
+				WHERE f.product_id = p.id AND f.action = 'AUTO_FLAGGED' AND f.created_at >= p.submitted_at
+			) AS flagged
+		) fl
+		WHERE p.approval_status = 'PENDING' AND p.is_active = TRUE`
+	args := pgx.NamedArgs{"sla_cutoff": filter.SLACutoff}
+
+	if filter.CategoryID != nil {
+		base += ` AND p.category_id = @category_id`
+		args["category_id"] = *filter.CategoryID
+	}
+
+	if filter.Flagged != nil {
+		base += ` AND fl.flagged = @flagged`
+		args["flagged"] = *filter.Flagged
+	}
+
+	base = queueConditions(base, args, filter, "p", "co.state")
+
+	total, sla, err := r.queueSLA(ctx, base, args, "p")
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := `
+		SELECT
+			p.id,
+			p.company_id,
+			co.name AS company_name,
+			p.category_id,
+			cat.name AS category_name,
+			p.name,
+			co.state,
+			p.submitted_at,
+			(EXTRACT(EPOCH FROM NOW() - p.submitted_at) / 60)::int AS waiting_minutes,
+			rs.resubmission_count,
+			fl.flagged,
+			mc.reviewer_id,
+			mc.expires_at AS claim_expires_at
+		` + base + ` ORDER BY p.submitted_at ASC LIMIT @limit OFFSET @offset`
+	args["limit"] = filter.Limit
+	args["offset"] = (filter.Page - 1) * filter.Limit
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product queue: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[moderation.ProductQueueItem])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &moderation.QueueResponse[moderation.ProductQueueItem]{
+		PaginatedResponse: model.PaginatedResponse[moderation.ProductQueueItem]{
+			Data:       items,
+			Total:      total,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			TotalPages: (total + filter.Limit - 1) / filter.Limit,
+		},
+		SLA: sla,
+	}, nil
+}
+
+// =============================================
+// CLAIMS
+// =============================================
+
+// Claim takes the lock for the reviewer. It succeeds when the item is free,
+// the previous claim has expired or the reviewer already holds it (which
+// extends it). Otherwise ErrConflict is returned.
+func (r *ModerationRepository) Claim(ctx context.Context, c *moderation.Claim) (*moderation.Claim, error) {
+	return r.upsertClaim(ctx, c, `
+		WHERE moderation_claims.expires_at <= NOW()
+		OR moderation_claims.reviewer_id = EXCLUDED.reviewer_id`)
+}
+
+// Assign hands the item to a reviewer regardless of who holds it now.
+func (r *ModerationRepository) Assign(ctx context.Context, c *moderation.Claim) (*moderation.Claim, error) {
+	return r.upsertClaim(ctx, c, "")
+}
+
+func (r *ModerationRepository) upsertClaim(ctx context.Context, c *moderation.Claim, condition string) (*moderation.Claim, error) {
+	stmt := `
+		INSERT INTO moderation_claims (
+			entity_type,
+			entity_id,
+			reviewer_id,
+			assigned_by_id,
+			expires_at
+		)
+		VALUES (
+			@entity_type,
+			@entity_id,
+			@reviewer_id,
+			@assigned_by_id,
+			@expires_at
+		)
+		ON CONFLICT (entity_type, entity_id) DO UPDATE SET
+			reviewer_id = EXCLUDED.reviewer_id,
+			assigned_by_id = EXCLUDED.assigned_by_id,
+			expires_at = EXCLUDED.expires_at,
+			updated_at = NOW()
+		` + condition + `
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"entity_type":    c.EntityType,
+		"entity_id":      c.EntityID,
+		"reviewer_id":    c.ReviewerID,
+		"assigned_by_id": c.AssignedByID,
+		"expires_at":     c.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim moderation item: %w", err)
+	}
+
+	claim, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[moderation.Claim])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrConflict
+		}
+		return nil, fmt.Errorf("failed to collect moderation claim: %w", err)
+	}
+	return &claim, nil
+}
+
+// GetActiveClaim returns ErrNotFound when nobody holds the item.
+func (r *ModerationRepository) GetActiveClaim(ctx context.Context, entityType moderation.EntityType, entityID uuid.UUID) (*moderation.Claim, error) {
+	stmt := `
+		SELECT * FROM moderation_claims
+		WHERE entity_type = @entity_type
+		AND entity_id = @entity_id
+		AND expires_at > NOW()
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"entity_type": entityType,
+		"entity_id":   entityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation claim: %w", err)
+	}
+
+	claim, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[moderation.Claim])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to collect moderation claim: %w", err)
+	}
+	return &claim, nil
+}
+
+// Release drops the reviewer's own active claim.
+func (r *ModerationRepository) Release(ctx context.Context, entityType moderation.EntityType, entityID, reviewerID uuid.UUID) error {
+	stmt := `
+		DELETE FROM moderation_claims
+		WHERE entity_type = @entity_type
+		AND entity_id = @entity_id
+		AND reviewer_id = @reviewer_id
+		AND expires_at > NOW()
+	`
+	res, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+		"entity_type": entityType,
+		"entity_id":   entityID,
+		"reviewer_id": reviewerID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release moderation claim: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package productRepo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/C0deNe0/agromart/internal/model"
 	"github.com/C0deNe0/agromart/internal/model/company"
@@ -26,8 +27,19 @@ type ProductFilter struct {
 	Search         *string
 	ApprovalStatus *company.ApprovalStatus
 	IsActive       *bool
-	Page           int
-	Limit          int
+	// leaves out products hidden by user reports or suspended, and those of
+	// hidden or suspended companies
+	ExcludeHidden bool
+	// leaves out products whose visibility, the product override or else
+	// the company default, does not let ViewerID see them. a nil ViewerID,
+	// or one the company blocked, only sees PUBLIC products.
+	ApplyVisibility bool
+	ViewerID        *uuid.UUID
+	// only companies whose service areas cover the pincode, or that have
+	// none. the service fills in the prefixes and state.
+	DeliversTo *company.ServiceAreaLookup
+	Page       int
+	Limit      int
 }
 
 func (r *ProductRepository) Create(ctx context.Context, p *product.Product) (*product.Product, error) {
@@ -42,7 +54,8 @@ func (r *ProductRepository) Create(ctx context.Context, p *product.Product) (*pr
 			origin,
 			base_price,
 			approval_status,
-			is_active
+			is_active,
+			visibility
 		)
 		VALUES (
 			@company_id,
@@ -53,7 +66,8 @@ func (r *ProductRepository) Create(ctx context.Context, p *product.Product) (*pr
 			@origin,
 			@price,
 			@approval_status,
-			@is_active
+			@is_active,
+			@visibility
 		)
 		RETURNING *`
 
@@ -67,6 +81,7 @@ func (r *ProductRepository) Create(ctx context.Context, p *product.Product) (*pr
 		"price":           p.BasePrice,
 		"approval_status": p.ApprovalStatus,
 		"is_active":       p.IsActive,
+		"visibility":      p.Visibility,
 	})
 	if err != nil {
 		if pgErr, ok := err.(*pgx.ScanArgError); ok {
@@ -104,7 +119,36 @@ func (r *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*product
 	return &row, nil
 }
 
-func (r *ProductRepository) List(ctx context.Context, filter ProductFilter) (*model.PaginatedResponse[product.Product], error) {
+func (r *ProductRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]product.Product, error) {
+	result := make(map[uuid.UUID]product.Product)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	stmt := `
+		SELECT * FROM products
+		WHERE id = ANY(@ids)
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"ids": ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products by ids: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[product.Product])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	for _, p := range items {
+		result[p.ID] = p
+	}
+	return result, nil
+}
+
+// listBase builds the FROM and WHERE part shared by List and
+// CountByCategory.
+func listBase(filter ProductFilter) (string, pgx.NamedArgs) {
 	base := `FROM products WHERE 1=1`
 	args := pgx.NamedArgs{}
 
@@ -133,6 +177,62 @@ func (r *ProductRepository) List(ctx context.Context, filter ProductFilter) (*mo
 		args["is_active"] = *filter.IsActive
 	}
 
+	if filter.ExcludeHidden {
+		base += ` AND hidden_at IS NULL AND suspended_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM companies c
+				WHERE c.id = products.company_id
+					AND (c.hidden_at IS NOT NULL OR c.suspended_at IS NOT NULL)
+			)`
+	}
+
+	if filter.ApplyVisibility {
+		base += ` AND EXISTS (
+				SELECT 1 FROM companies c
+				WHERE c.id = products.company_id AND (
+					COALESCE(products.visibility, c.product_visibility) = 'PUBLIC'
+					OR c.owner_id = @viewer_id::uuid
+					OR (
+						COALESCE(products.visibility, c.product_visibility) = 'FOLLOWERS_ONLY'
+						AND EXISTS (
+							SELECT 1 FROM company_followers cf
+							WHERE cf.company_id = c.id AND cf.user_id = @viewer_id::uuid
+								AND cf.status = 'ACCEPTED'
+						)
+						AND NOT EXISTS (
+							SELECT 1 FROM company_blocked_users b
+							WHERE b.company_id = c.id AND b.user_id = @viewer_id::uuid
+						)
+					)
+				)
+			)`
+		args["viewer_id"] = filter.ViewerID
+	}
+
+	if filter.DeliversTo != nil {
+		base += ` AND (
+				products.company_id IN (
+					SELECT sa.company_id FROM company_service_areas sa
+					WHERE (sa.area_type = 'PINCODE' AND sa.value = @delivers_pincode)
+						OR (sa.area_type = 'PINCODE_PREFIX' AND sa.value = ANY(@delivers_prefixes))
+						OR (sa.area_type = 'STATE' AND sa.value = @delivers_state)
+				)
+				OR NOT EXISTS (
+					SELECT 1 FROM company_service_areas sa
+					WHERE sa.company_id = products.company_id
+				)
+			)`
+		args["delivers_pincode"] = filter.DeliversTo.Pincode
+		args["delivers_prefixes"] = filter.DeliversTo.Prefixes
+		args["delivers_state"] = filter.DeliversTo.StateCode
+	}
+
+	return base, args
+}
+
+func (r *ProductRepository) List(ctx context.Context, filter ProductFilter) (*model.PaginatedResponse[product.Product], error) {
+	base, args := listBase(filter)
+
 	// Count total
 	var total int
 	countStmt := `SELECT COUNT(*) ` + base
@@ -163,6 +263,31 @@ func (r *ProductRepository) List(ctx context.Context, filter ProductFilter) (*mo
 		TotalPages: (total + filter.Limit - 1) / filter.Limit,
 	}, nil
 }
+
+// CountByCategory counts the products matching filter per category,
+// products without a category are counted under a nil id.
+func (r *ProductRepository) CountByCategory(ctx context.Context, filter ProductFilter) ([]product.CategoryCount, error) {
+	base, args := listBase(filter)
+	stmt := `
+		SELECT p.category_id, cat.name AS category_name, COUNT(*)::int AS count
+		FROM (SELECT products.category_id ` + base + `) p
+		LEFT JOIN categories cat ON cat.id = p.category_id
+		GROUP BY p.category_id, cat.name
+		ORDER BY count DESC, cat.name
+	`
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count products per category: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[product.CategoryCount])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
 func (r *ProductRepository) Update(ctx context.Context, p *product.Product) (*product.Product, error) {
 
 	stmt := `
@@ -202,6 +327,31 @@ func (r *ProductRepository) Update(ctx context.Context, p *product.Product) (*pr
 	return &row, nil
 }
 
+// SetVisibility changes the override in place, approved products stay
+// approved. A nil visibility goes back to the company default.
+func (r *ProductRepository) SetVisibility(ctx context.Context, id uuid.UUID, visibility *company.ProductVisibility) (*product.Product, error) {
+	stmt := `
+		UPDATE products SET visibility=@visibility, updated_at=NOW()
+		WHERE id=@id
+		RETURNING *
+	`
+
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"id":         id,
+		"visibility": visibility,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product visibility: %w", err)
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[product.Product])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect one row: %w", err)
+	}
+
+	return &row, nil
+}
+
 func (r *ProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	stmt := `
 		UPDATE products SET is_active=false, updated_at=NOW() WHERE id=@id
@@ -219,6 +369,32 @@ func (r *ProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *ProductRepository) Approve(ctx context.Context, productID, adminID uuid.UUID, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return r.approve(ctx, tx, productID, adminID, notes)
+	})
+}
+
+// BulkApprove uses one transaction with a savepoint per product, failed
+// products come back in the map and the others still commit.
+func (r *ProductRepository) BulkApprove(ctx context.Context, productIDs []uuid.UUID, adminID uuid.UUID, notes *string) (map[uuid.UUID]error, error) {
+	failed := make(map[uuid.UUID]error)
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		for _, id := range productIDs {
+			if err := pgx.BeginFunc(ctx, tx, func(sp pgx.Tx) error {
+				return r.approve(ctx, sp, id, adminID, notes)
+			}); err != nil {
+				failed[id] = err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk approve products: %w", err)
+	}
+	return failed, nil
+}
+
+func (r *ProductRepository) approve(ctx context.Context, tx pgx.Tx, productID, adminID uuid.UUID, notes *string) error {
 	stmt := `
 		UPDATE products SET
 			approval_status = 'APPROVED',
@@ -227,7 +403,7 @@ func (r *ProductRepository) Approve(ctx context.Context, productID, adminID uuid
 			rejection_reason = NULL
 		WHERE id = @product_id AND approval_status = 'PENDING'
 	`
-	res, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+	res, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
 		"product_id": productID,
 		"admin_id":   adminID,
 	})
@@ -243,7 +419,7 @@ func (r *ProductRepository) Approve(ctx context.Context, productID, adminID uuid
         INSERT INTO product_approval_history (product_id, action, performed_by_id, notes)
         VALUES (@product_id, 'APPROVED', @admin_id, @notes)
     `
-	_, err = r.db.Exec(ctx, historyStmt, pgx.NamedArgs{
+	_, err = tx.Exec(ctx, historyStmt, pgx.NamedArgs{
 		"product_id": productID,
 		"admin_id":   adminID,
 		"notes":      notes,
@@ -252,10 +428,41 @@ func (r *ProductRepository) Approve(ctx context.Context, productID, adminID uuid
 		return fmt.Errorf("failed to log approval history: %w", err)
 	}
 
+	_, err = tx.Exec(ctx, `DELETE FROM moderation_claims WHERE entity_type = 'PRODUCT' AND entity_id = @product_id`, pgx.NamedArgs{
+		"product_id": productID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release moderation claim: %w", err)
+	}
+
 	return nil
 }
 
 func (r *ProductRepository) Reject(ctx context.Context, productID, adminID uuid.UUID, reason string, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		return r.reject(ctx, tx, productID, adminID, reason, notes)
+	})
+}
+
+func (r *ProductRepository) BulkReject(ctx context.Context, productIDs []uuid.UUID, adminID uuid.UUID, reason string, notes *string) (map[uuid.UUID]error, error) {
+	failed := make(map[uuid.UUID]error)
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		for _, id := range productIDs {
+			if err := pgx.BeginFunc(ctx, tx, func(sp pgx.Tx) error {
+				return r.reject(ctx, sp, id, adminID, reason, notes)
+			}); err != nil {
+				failed[id] = err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to bulk reject products: %w", err)
+	}
+	return failed, nil
+}
+
+func (r *ProductRepository) reject(ctx context.Context, tx pgx.Tx, productID, adminID uuid.UUID, reason string, notes *string) error {
 	stmt := `
         UPDATE products SET
             approval_status = 'REJECTED',
@@ -266,7 +473,7 @@ func (r *ProductRepository) Reject(ctx context.Context, productID, adminID uuid.
         WHERE id = @product_id AND approval_status = 'PENDING'
     `
 
-	result, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+	result, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
 		"product_id": productID,
 		"admin_id":   adminID,
 		"reason":     reason,
@@ -284,7 +491,7 @@ func (r *ProductRepository) Reject(ctx context.Context, productID, adminID uuid.
         INSERT INTO product_approval_history (product_id, action, performed_by_id, reason, notes)
         VALUES (@product_id, 'REJECTED', @admin_id, @reason, @notes)
     `
-	_, err = r.db.Exec(ctx, historyStmt, pgx.NamedArgs{
+	_, err = tx.Exec(ctx, historyStmt, pgx.NamedArgs{
 		"product_id": productID,
 		"admin_id":   adminID,
 		"reason":     reason,
@@ -294,6 +501,13 @@ func (r *ProductRepository) Reject(ctx context.Context, productID, adminID uuid.
 		return fmt.Errorf("failed to log rejection history: %w", err)
 	}
 
+	_, err = tx.Exec(ctx, `DELETE FROM moderation_claims WHERE entity_type = 'PRODUCT' AND entity_id = @product_id`, pgx.NamedArgs{
+		"product_id": productID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release moderation claim: %w", err)
+	}
+
 	return nil
 }
 
@@ -322,8 +536,9 @@ func (r *ProductRepository) Resubmit(ctx context.Context, productID, userID uuid
 
 	// Log resubmission
 	historyStmt := `
-        INSERT INTO product_approval_history (product_id, action, performed_by_id, notes)
-        VALUES (@product_id, 'RESUBMITTED', @user_id, 'Product resubmitted after rejection')
+        INSERT INTO product_approval_history (product_id, action, performed_by_id, notes, snapshot)
+        SELECT t.id, 'RESUBMITTED', @user_id::uuid, 'Product resubmitted after rejection', product_snapshot(t)
+        FROM products t WHERE t.id = @product_id
     `
 	_, err = r.db.Exec(ctx, historyStmt, pgx.NamedArgs{
 		"product_id": productID,
@@ -336,6 +551,117 @@ func (r *ProductRepository) Resubmit(ctx context.Context, productID, userID uuid
 	return nil
 }
 
+// Suspend takes an approved product offline until it is reinstated, or
+// until the sweep picks it up once until has passed.
+func (r *ProductRepository) Suspend(ctx context.Context, productID, adminID uuid.UUID, reason string, until *time.Time, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		stmt := `
+			UPDATE products SET
+				is_active = FALSE,
+				suspended_at = NOW(),
+				suspended_until = @until,
+				suspension_reason = @reason,
+				updated_at = NOW()
+			WHERE id = @product_id AND approval_status = 'APPROVED' AND suspended_at IS NULL
+		`
+		result, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
+			"product_id": productID,
+			"until":      until,
+			"reason":     reason,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to suspend product: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("product not found, not approved or already suspended")
+		}
+
+		historyStmt := `
+			INSERT INTO product_approval_history (product_id, action, performed_by_id, reason, notes)
+			VALUES (@product_id, 'SUSPENDED', @admin_id, @reason, @notes)
+		`
+		_, err = tx.Exec(ctx, historyStmt, pgx.NamedArgs{
+			"product_id": productID,
+			"admin_id":   adminID,
+			"reason":     reason,
+			"notes":      notes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log suspension history: %w", err)
+		}
+		return nil
+	})
+}
+
+// Reinstate lifts a suspension. adminID is nil when the system does it.
+func (r *ProductRepository) Reinstate(ctx context.Context, productID uuid.UUID, adminID *uuid.UUID, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		stmt := `
+			UPDATE products SET
+				is_active = TRUE,
+				suspended_at = NULL,
+				suspended_until = NULL,
+				suspension_reason = NULL,
+				updated_at = NOW()
+			WHERE id = @product_id AND suspended_at IS NOT NULL
+		`
+		result, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
+			"product_id": productID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reinstate product: %w", err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("product not found or not suspended")
+		}
+
+		historyStmt := `
+			INSERT INTO product_approval_history (product_id, action, performed_by_id, notes)
+			VALUES (@product_id, 'REINSTATED', @admin_id, @notes)
+		`
+		_, err = tx.Exec(ctx, historyStmt, pgx.NamedArgs{
+			"product_id": productID,
+			"admin_id":   adminID,
+			"notes":      notes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log reinstatement history: %w", err)
+		}
+		return nil
+	})
+}
+
+// ReinstateExpired lifts every suspension whose end date has passed and
+// returns the reinstated products.
+func (r *ProductRepository) ReinstateExpired(ctx context.Context) ([]uuid.UUID, error) {
+	stmt := `
+		WITH reinstated AS (
+			UPDATE products SET
+				is_active = TRUE,
+				suspended_at = NULL,
+				suspended_until = NULL,
+				suspension_reason = NULL,
+				updated_at = NOW()
+			WHERE suspended_at IS NOT NULL AND suspended_until <= NOW()
+			RETURNING id
+		), logged AS (
+			INSERT INTO product_approval_history (product_id, action, notes)
+			SELECT id, 'REINSTATED', 'Suspension period ended' FROM reinstated
+		)
+		SELECT id FROM reinstated
+	`
+	rows, err := r.db.Query(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reinstate expired product suspensions: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return ids, nil
+}
+
 func (r *ProductRepository) GetApprovalHistory(ctx context.Context, productID uuid.UUID) ([]product.ProductApprovalHistory, error) {
 	stmt := `
         SELECT * FROM product_approval_history
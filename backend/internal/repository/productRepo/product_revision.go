@@ -0,0 +1,287 @@
+package productRepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ProductRevisionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewProductRevisionRepository(db *pgxpool.Pool) *ProductRevisionRepository {
+	return &ProductRevisionRepository{db: db}
+}
+
+// SubmitDraft stores the draft for the product, replacing the open one if
+// the seller edits again before review, and logs REVISION_SUBMITTED.
+func (r *ProductRevisionRepository) SubmitDraft(ctx context.Context, rev *product.ProductRevision) (*product.ProductRevision, error) {
+	var saved product.ProductRevision
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		stmt := `
+			INSERT INTO product_revisions (
+				product_id,
+				submitted_by_id,
+				category_id,
+				name,
+				description,
+				unit,
+				origin,
+				base_price,
+				changes
+			)
+			VALUES (
+				@product_id,
+				@submitted_by_id,
+				@category_id,
+				@name,
+				@description,
+				@unit,
+				@origin,
+				@base_price,
+				@changes
+			)
+			ON CONFLICT (product_id) WHERE status = 'PENDING' DO UPDATE SET
+				submitted_by_id = EXCLUDED.submitted_by_id,
+				category_id = EXCLUDED.category_id,
+				name = EXCLUDED.name,
+				description = EXCLUDED.description,
+				unit = EXCLUDED.unit,
+				origin = EXCLUDED.origin,
+				base_price = EXCLUDED.base_price,
+				changes = EXCLUDED.changes,
+				updated_at = NOW()
+			RETURNING *
+		`
+		rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
+			"product_id":      rev.ProductID,
+			"submitted_by_id": rev.SubmittedByID,
+			"category_id":     rev.CategoryID,
+			"name":            rev.Name,
+			"description":     rev.Description,
+			"unit":            rev.Unit,
+			"origin":          rev.Origin,
+			"base_price":      rev.BasePrice,
+			"changes":         rev.Changes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save product revision: %w", err)
+		}
+
+		saved, err = pgx.CollectOneRow(rows, pgx.RowToStructByName[product.ProductRevision])
+		if err != nil {
+			return fmt.Errorf("failed to collect product revision: %w", err)
+		}
+
+		historyStmt := `
+			INSERT INTO product_approval_history (product_id, action, performed_by_id, revision_id, changes)
+			VALUES (@product_id, 'REVISION_SUBMITTED', @user_id, @revision_id, @changes)
+		`
+		_, err = tx.Exec(ctx, historyStmt, pgx.NamedArgs{
+			"product_id":  saved.ProductID,
+			"user_id":     saved.SubmittedByID,
+			"revision_id": saved.ID,
+			"changes":     saved.Changes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log revision history: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+func (r *ProductRevisionRepository) GetByID(ctx context.Context, id uuid.UUID) (*product.ProductRevision, error) {
+	stmt := `SELECT * FROM product_revisions WHERE id = @id`
+
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{"id": id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product revision: %w", err)
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[product.ProductRevision])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect product revision: %w", err)
+	}
+	return &row, nil
+}
+
+// GetPendingByProductID returns nil when the product has no open draft.
+func (r *ProductRevisionRepository) GetPendingByProductID(ctx context.Context, productID uuid.UUID) (*product.ProductRevision, error) {
+	stmt := `SELECT * FROM product_revisions WHERE product_id = @product_id AND status = 'PENDING'`
+
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{"product_id": productID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending revision: %w", err)
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[product.ProductRevision])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to collect pending revision: %w", err)
+	}
+	return &row, nil
+}
+
+func (r *ProductRevisionRepository) ListPending(ctx context.Context, page, limit int) (*model.PaginatedResponse[product.ProductRevision], error) {
+	var total int
+	if err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM product_revisions WHERE status = 'PENDING'`).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count pending revisions: %w", err)
+	}
+
+	stmt := `
+		SELECT * FROM product_revisions
+		WHERE status = 'PENDING'
+		ORDER BY updated_at ASC
+		LIMIT @limit OFFSET @offset
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"limit":  limit,
+		"offset": (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending revisions: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[product.ProductRevision])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &model.PaginatedResponse[product.ProductRevision]{
+		Data:       items,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+// Approve applies the draft onto the live product and logs the diff.
+func (r *ProductRevisionRepository) Approve(ctx context.Context, revisionID, adminID uuid.UUID, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			UPDATE product_revisions SET
+				status = 'APPROVED',
+				reviewed_by_id = @admin_id,
+				reviewed_at = NOW(),
+				updated_at = NOW()
+			WHERE id = @revision_id AND status = 'PENDING'
+			RETURNING *
+		`, pgx.NamedArgs{
+			"revision_id": revisionID,
+			"admin_id":    adminID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to approve revision: %w", err)
+		}
+
+		rev, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[product.ProductRevision])
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("revision not found or not in pending status")
+			}
+			return fmt.Errorf("failed to collect revision: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE products SET
+				category_id = @category_id,
+				name = @name,
+				description = @description,
+				unit = @unit,
+				origin = @origin,
+				base_price = @base_price,
+				reviewed_by_id = @admin_id,
+				reviewed_at = NOW(),
+				updated_at = NOW()
+			WHERE id = @product_id
+		`, pgx.NamedArgs{
+			"product_id":  rev.ProductID,
+			"category_id": rev.CategoryID,
+			"name":        rev.Name,
+			"description": rev.Description,
+			"unit":        rev.Unit,
+			"origin":      rev.Origin,
+			"base_price":  rev.BasePrice,
+			"admin_id":    adminID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply revision: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO product_approval_history (product_id, action, performed_by_id, notes, revision_id, changes)
+			VALUES (@product_id, 'REVISION_APPROVED', @admin_id, @notes, @revision_id, @changes)
+		`, pgx.NamedArgs{
+			"product_id":  rev.ProductID,
+			"admin_id":    adminID,
+			"notes":       notes,
+			"revision_id": rev.ID,
+			"changes":     rev.Changes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log revision history: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *ProductRevisionRepository) Reject(ctx context.Context, revisionID, adminID uuid.UUID, reason string, notes *string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			UPDATE product_revisions SET
+				status = 'REJECTED',
+				reviewed_by_id = @admin_id,
+				reviewed_at = NOW(),
+				rejection_reason = @reason,
+				updated_at = NOW()
+			WHERE id = @revision_id AND status = 'PENDING'
+			RETURNING *
+		`, pgx.NamedArgs{
+			"revision_id": revisionID,
+			"admin_id":    adminID,
+			"reason":      reason,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reject revision: %w", err)
+		}
+
+		rev, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[product.ProductRevision])
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("revision not found or not in pending status")
+			}
+			return fmt.Errorf("failed to collect revision: %w", err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO product_approval_history (product_id, action, performed_by_id, reason, notes, revision_id, changes)
+			VALUES (@product_id, 'REVISION_REJECTED', @admin_id, @reason, @notes, @revision_id, @changes)
+		`, pgx.NamedArgs{
+			"product_id":  rev.ProductID,
+			"admin_id":    adminID,
+			"reason":      reason,
+			"notes":       notes,
+			"revision_id": rev.ID,
+			"changes":     rev.Changes,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log revision history: %w", err)
+		}
+		return nil
+	})
+}
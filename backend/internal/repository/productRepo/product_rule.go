@@ -0,0 +1,160 @@
+package productRepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// =============================================
+// PRE-MODERATION LOOKUPS
+// =============================================
+
+// CategoryMedianPrice returns the median base price of the approved products
+// in the category, leaving out the product being checked. sample is the
+// number of products the median was taken over.
+func (r *ProductRepository) CategoryMedianPrice(ctx context.Context, categoryID, excludeID uuid.UUID) (median decimal.NullDecimal, sample int, err error) {
+	stmt := `
+		SELECT
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY base_price)::numeric(10,2),
+			COUNT(*)
+		FROM products
+		WHERE category_id = @category_id
+		AND id <> @exclude_id
+		AND approval_status = 'APPROVED'
+		AND is_active = TRUE
+	`
+	err = r.db.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"category_id": categoryID,
+		"exclude_id":  excludeID,
+	}).Scan(&median, &sample)
+	if err != nil {
+		return median, 0, fmt.Errorf("failed to get category median price: %w", err)
+	}
+	return median, sample, nil
+}
+
+// FindByCompanyAndName looks for another active product of the company with
+// the same name, ignoring case. Returns nil when there is none.
+func (r *ProductRepository) FindByCompanyAndName(ctx context.Context, companyID uuid.UUID, name string, excludeID uuid.UUID) (*product.Product, error) {
+	stmt := `
+		SELECT * FROM products
+		WHERE company_id = @company_id
+		AND lower(name) = lower(@name)
+		AND id <> @exclude_id
+		AND is_active = TRUE
+		ORDER BY created_at ASC
+		LIMIT 1
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"name":       name,
+		"exclude_id": excludeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by name: %w", err)
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[product.Product])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to collect product: %w", err)
+	}
+	return &row, nil
+}
+
+// SellerTrackRecord counts the company's approved products and the
+// rejections (manual or automatic) its products received since the given time.
+func (r *ProductRepository) SellerTrackRecord(ctx context.Context, companyID uuid.UUID, since time.Time) (approved, rejected int, err error) {
+	stmt := `
+		SELECT
+			(SELECT COUNT(*) FROM products
+				WHERE company_id = @company_id AND approval_status = 'APPROVED' AND is_active = TRUE),
+			(SELECT COUNT(*) FROM product_approval_history h
+				JOIN products p ON p.id = h.product_id
+				WHERE p.company_id = @company_id
+				AND h.action IN ('REJECTED', 'AUTO_REJECTED')
+				AND h.created_at >= @since)
+	`
+	err = r.db.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"company_id": companyID,
+		"since":      since,
+	}).Scan(&approved, &rejected)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get seller track record: %w", err)
+	}
+	return approved, rejected, nil
+}
+
+// RecordRuleVerdict writes one history row per rule decision. When decide is
+// set a rejecting or approving verdict is also applied to the pending product,
+// so it never reaches the manual queue.
+func (r *ProductRepository) RecordRuleVerdict(ctx context.Context, productID uuid.UUID, verdict *product.RuleVerdict, decide bool) error {
+	if len(verdict.Decisions) == 0 {
+		return nil
+	}
+
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		for _, d := range verdict.Decisions {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO product_approval_history (product_id, action, rule, reason)
+				VALUES (@product_id, @action, @rule, @reason)
+			`, pgx.NamedArgs{
+				"product_id": productID,
+				"action":     d.Action(),
+				"rule":       d.Rule,
+				"reason":     d.Reason,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to log rule decision: %w", err)
+			}
+		}
+
+		if !decide {
+			return nil
+		}
+
+		var stmt string
+		switch {
+		case verdict.IsRejected():
+			stmt = `
+				UPDATE products SET
+					approval_status = 'REJECTED',
+					reviewed_by_id = NULL,
+					reviewed_at = NOW(),
+					rejection_reason = @reason,
+					updated_at = NOW()
+				WHERE id = @product_id AND approval_status = 'PENDING'
+			`
+		case verdict.IsApproved():
+			stmt = `
+				UPDATE products SET
+					approval_status = 'APPROVED',
+					reviewed_by_id = NULL,
+					reviewed_at = NOW(),
+					rejection_reason = NULL,
+					updated_at = NOW()
+				WHERE id = @product_id AND approval_status = 'PENDING'
+			`
+		default:
+			return nil
+		}
+
+		_, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
+			"product_id": productID,
+			"reason":     verdict.RejectionReason(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply rule verdict: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/report"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReportRepository(db *pgxpool.Pool) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+type ReportQueueFilter struct {
+	EntityType *report.EntityType
+	Reason     *report.Reason
+	HiddenOnly bool
+	Page       int
+	Limit      int
+}
+
+// Create files a report. A user has at most one open report per entity,
+// reporting the same entity again replaces its reason and comment.
+func (r *ReportRepository) Create(ctx context.Context, rep *report.Report) (*report.Report, error) {
+	stmt := `
+		INSERT INTO reports (
+			reporter_id,
+			entity_type,
+			entity_id,
+			reason,
+			comment
+		) VALUES (
+			@reporter_id,
+			@entity_type,
+			@entity_id,
+			@reason,
+			@comment
+		)
+		ON CONFLICT (reporter_id, entity_type, entity_id) WHERE status = 'OPEN'
+		DO UPDATE SET
+			reason = EXCLUDED.reason,
+			comment = EXCLUDED.comment,
+			updated_at = NOW()
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"reporter_id": rep.ReporterID,
+		"entity_type": rep.EntityType,
+		"entity_id":   rep.EntityID,
+		"reason":      rep.Reason,
+		"comment":     rep.Comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report: %w", err)
+	}
+
+	row, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[report.Report])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row: %w", err)
+	}
+	return &row, nil
+}
+
+func (r *ReportRepository) CountOpen(ctx context.Context, entityType report.EntityType, entityID uuid.UUID) (int, error) {
+	stmt := `
+		SELECT COUNT(*) FROM reports
+		WHERE entity_type = @entity_type AND entity_id = @entity_id AND status = 'OPEN'
+	`
+	var count int
+	err := r.db.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"entity_type": entityType,
+		"entity_id":   entityID,
+	}).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count open reports: %w", err)
+	}
+	return count, nil
+}
+
+// Hide marks the entity hidden and reports whether it was visible before,
+// so only the first crossing of the threshold triggers side effects.
+func (r *ReportRepository) Hide(ctx context.Context, entityType report.EntityType, entityID uuid.UUID) (bool, error) {
+	stmt := fmt.Sprintf(`
+		UPDATE %s SET hidden_at = NOW(), updated_at = NOW()
+		WHERE id = @id AND hidden_at IS NULL
+	`, reportedTable(entityType))
+	tag, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{"id": entityID})
+	if err != nil {
+		return false, fmt.Errorf("failed to hide reported entity: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// Unhide makes the entity visible again and reports whether it was hidden.
+func (r *ReportRepository) Unhide(ctx context.Context, entityType report.EntityType, entityID uuid.UUID) (bool, error) {
+	stmt := fmt.Sprintf(`
+		UPDATE %s SET hidden_at = NULL, updated_at = NOW()
+		WHERE id = @id AND hidden_at IS NOT NULL
+	`, reportedTable(entityType))
+	tag, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{"id": entityID})
+	if err != nil {
+		return false, fmt.Errorf("failed to restore reported entity: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *ReportRepository) ListByReporter(ctx context.Context, reporterID uuid.UUID, page, limit int) (*model.PaginatedResponse[report.Report], error) {
+	var total int
+	countStmt := `SELECT COUNT(*) FROM reports WHERE reporter_id = @reporter_id`
+	if err := r.db.QueryRow(ctx, countStmt, pgx.NamedArgs{"reporter_id": reporterID}).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count reports: %w", err)
+	}
+
+	stmt := `
+		SELECT * FROM reports
+		WHERE reporter_id = @reporter_id
+		ORDER BY created_at DESC
+		LIMIT @limit OFFSET @offset
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"reporter_id": reporterID,
+		"limit":       limit,
+		"offset":      (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[report.Report])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &model.PaginatedResponse[report.Report]{
+		Data:       items,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+// ListQueue groups open reports by entity, most reported first.
+func (r *ReportRepository) ListQueue(ctx context.Context, filter ReportQueueFilter) (*model.PaginatedResponse[report.QueueItem], error) {
+	base := `
+		FROM reports rp
+		LEFT JOIN products p ON rp.entity_type = 'PRODUCT' AND p.id = rp.entity_id
+		LEFT JOIN companies c ON rp.entity_type = 'COMPANY' AND c.id = rp.entity_id
+		WHERE rp.status = 'OPEN'`
+	args := pgx.NamedArgs{}
+
+	if filter.EntityType != nil {
+		base += ` AND rp.entity_type = @entity_type`
+		args["entity_type"] = *filter.EntityType
+	}
+
+	base += ` GROUP BY rp.entity_type, rp.entity_id HAVING 1=1`
+
+	if filter.Reason != nil {
+		base += ` AND bool_or(rp.reason = @reason)`
+		args["reason"] = *filter.Reason
+	}
+
+	if filter.HiddenOnly {
+		base += ` AND max(COALESCE(p.hidden_at, c.hidden_at)) IS NOT NULL`
+	}
+
+	var total int
+	countStmt := `SELECT COUNT(*) FROM (SELECT 1 ` + base + `) q`
+	if err := r.db.QueryRow(ctx, countStmt, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to count report queue: %w", err)
+	}
+
+	stmt := `
+		SELECT
+			rp.entity_type,
+			rp.entity_id,
+			max(COALESCE(p.name, c.name)) AS entity_name,
+			COUNT(*) AS open_reports,
+			array_agg(DISTINCT rp.reason) AS reasons,
+			min(rp.created_at) AS first_reported_at,
+			max(rp.updated_at) AS last_reported_at,
+			max(COALESCE(p.hidden_at, c.hidden_at)) AS hidden_at
+		` + base + `
+		ORDER BY open_reports DESC, first_reported_at ASC
+		LIMIT @limit OFFSET @offset`
+	args["limit"] = filter.Limit
+	args["offset"] = (filter.Page - 1) * filter.Limit
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report queue: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[report.QueueItem])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	return &model.PaginatedResponse[report.QueueItem]{
+		Data:       items,
+		Total:      total,
+		Page:       filter.Page,
+		Limit:      filter.Limit,
+		TotalPages: (total + filter.Limit - 1) / filter.Limit,
+	}, nil
+}
+
+func (r *ReportRepository) ListByEntity(ctx context.Context, entityType report.EntityType, entityID uuid.UUID, status *report.Status) ([]report.Report, error) {
+	stmt := `
+		SELECT * FROM reports
+		WHERE entity_type = @entity_type AND entity_id = @entity_id
+	`
+	args := pgx.NamedArgs{
+		"entity_type": entityType,
+		"entity_id":   entityID,
+	}
+	if status != nil {
+		stmt += ` AND status = @status`
+		args["status"] = *status
+	}
+	stmt += ` ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[report.Report])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+// Close moves every open report of the entity to status and returns them.
+func (r *ReportRepository) Close(ctx context.Context, entityType report.EntityType, entityID uuid.UUID, status report.Status, adminID uuid.UUID, note *string) ([]report.Report, error) {
+	stmt := `
+		UPDATE reports
+		SET
+			status = @status,
+			resolved_by_id = @admin_id,
+			resolved_at = NOW(),
+			resolution_note = @note,
+			updated_at = NOW()
+		WHERE entity_type = @entity_type AND entity_id = @entity_id AND status = 'OPEN'
+		RETURNING *
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"status":      status,
+		"admin_id":    adminID,
+		"note":        note,
+		"entity_type": entityType,
+		"entity_id":   entityID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to close reports: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[report.Report])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+func reportedTable(entityType report.EntityType) string {
+	if entityType == report.EntityCompany {
+		return "companies"
+	}
+	return "products"
+}
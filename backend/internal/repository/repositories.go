@@ -6,30 +6,58 @@ import (
 )
 
 type Repositories struct {
-	User             *UserRepository
-	UserAuthMethod   *UserAuthMethodRepository
-	Company          *CompanyRepository
-	CompanyFollower  *CompanyFollowerRepository
-	Category         *CategoryRepository
-	Product          *productRepo.ProductRepository
-	ProductImage     *productRepo.ProductImageRepository
-	ProductVariant   *productRepo.ProductVariantRepository
-	RefreshToken     *RefreshTokenRepository
+	User               *UserRepository
+	UserAuthMethod     *UserAuthMethodRepository
+	Company            *CompanyRepository
+	CompanyFollower    *CompanyFollowerRepository
+	CompanyAPIKey      *CompanyAPIKeyRepository
+	CompanyKYC         *CompanyKYCRepository
+	CompanyImage       *CompanyImageRepository
+	CompanyServiceArea *CompanyServiceAreaRepository
+	CompanyHoliday     *CompanyHolidayRepository
+	Category           *CategoryRepository
+	Product            *productRepo.ProductRepository
+	ProductImage       *productRepo.ProductImageRepository
+	ProductVariant     *productRepo.ProductVariantRepository
+	ProductRevision    *productRepo.ProductRevisionRepository
+	RefreshToken       *RefreshTokenRepository
+	LoginEvent         *LoginEventRepository
+	Impersonation      *ImpersonationRepository
+	Moderation         *ModerationRepository
+	AuditLog           *AuditLogRepository
+	Report             *ReportRepository
+	Stats              *StatsRepository
+	Analytics          *AnalyticsRepository
+	Feed               *FeedRepository
 	// Favorite         *FavoriteRepository
 	SubscriptionPlan *SubscriptionPlanRepository
 }
 
 func NewRepositories(db *pgxpool.Pool) *Repositories {
 	return &Repositories{
-		User:             NewUserRepository(db),
-		UserAuthMethod:   NewUserAuthMethodRepository(db),
-		Company:          NewCompanyRepository(db),
-		CompanyFollower:  NewCompanyFollowerRepository(db),
-		Category:         NewCategoryRepository(db),
-		Product:          productRepo.NewProductRepository(db),
-		ProductImage:     productRepo.NewProductImageRepository(db),
-		ProductVariant:   productRepo.NewProductVariantRepository(db),
-		RefreshToken:     NewRefreshTokenRepository(db),
+		User:               NewUserRepository(db),
+		UserAuthMethod:     NewUserAuthMethodRepository(db),
+		Company:            NewCompanyRepository(db),
+		CompanyFollower:    NewCompanyFollowerRepository(db),
+		CompanyAPIKey:      NewCompanyAPIKeyRepository(db),
+		CompanyKYC:         NewCompanyKYCRepository(db),
+		CompanyImage:       NewCompanyImageRepository(db),
+		CompanyServiceArea: NewCompanyServiceAreaRepository(db),
+		CompanyHoliday:     NewCompanyHolidayRepository(db),
+		Category:           NewCategoryRepository(db),
+		Product:            productRepo.NewProductRepository(db),
+		ProductImage:       productRepo.NewProductImageRepository(db),
+		ProductVariant:     productRepo.NewProductVariantRepository(db),
+		ProductRevision:    productRepo.NewProductRevisionRepository(db),
+		RefreshToken:       NewRefreshTokenRepository(db),
+		LoginEvent:         NewLoginEventRepository(db),
+		Impersonation:      NewImpersonationRepository(db),
+		Moderation:         NewModerationRepository(db),
+		AuditLog:           NewAuditLogRepository(db),
+		Report:             NewReportRepository(db),
+		Stats:              NewStatsRepository(db),
+		Analytics:          NewAnalyticsRepository(db),
+		Feed:               NewFeedRepository(db),
 		// Favorite:         NewFavoriteRepository(db),
 		SubscriptionPlan: NewSubscriptionPlanRepository(db),
 	}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/stats"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StatsRepository runs the aggregate queries behind the admin dashboard.
+// Time series cover [from, to) and return every bucket, empty ones included.
+type StatsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewStatsRepository(db *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// bucketsCTE lists the buckets between @from and @to, @unit is the
+// date_trunc unit (day, week or month).
+const bucketsCTE = `
+	buckets AS (
+		SELECT generate_series(
+			date_trunc(@unit::text, @from::timestamptz),
+			@to::timestamptz - interval '1 microsecond',
+			('1 ' || @unit::text)::interval
+		) AS bucket
+	)`
+
+func seriesArgs(from, to time.Time, groupBy stats.GroupBy) pgx.NamedArgs {
+	return pgx.NamedArgs{
+		"from": from,
+		"to":   to,
+		"unit": string(groupBy),
+	}
+}
+
+func (r *StatsRepository) Signups(ctx context.Context, from, to time.Time, groupBy stats.GroupBy) ([]stats.CountPoint, error) {
+	stmt := `
+		WITH ` + bucketsCTE + `,
+		signups AS (
+			SELECT date_trunc(@unit::text, created_at) AS bucket
+			FROM users
+			WHERE created_at >= @from AND created_at < @to
+		)
+		SELECT b.bucket, COUNT(s.bucket)::int AS count
+		FROM buckets b
+		LEFT JOIN signups s ON s.bucket = b.bucket
+		GROUP BY b.bucket
+		ORDER BY b.bucket
+	`
+	rows, err := r.db.Query(ctx, stmt, seriesArgs(from, to, groupBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signup stats: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[stats.CountPoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+func (r *StatsRepository) Companies(ctx context.Context, from, to time.Time, groupBy stats.GroupBy) ([]stats.CompanyPoint, error) {
+	stmt := `
+		WITH ` + bucketsCTE + `,
+		events AS (
+			SELECT date_trunc(@unit::text, created_at) AS bucket, 'CREATED' AS kind
+			FROM companies
+			WHERE created_at >= @from AND created_at < @to
+			UNION ALL
+			SELECT date_trunc(@unit::text, created_at), action
+			FROM company_approval_history
+			WHERE action IN ('APPROVED', 'REJECTED') AND created_at >= @from AND created_at < @to
+		)
+		SELECT
+			b.bucket,
+			COUNT(*) FILTER (WHERE e.kind = 'CREATED')::int AS created,
+			COUNT(*) FILTER (WHERE e.kind = 'APPROVED')::int AS approved,
+			COUNT(*) FILTER (WHERE e.kind = 'REJECTED')::int AS rejected
+		FROM buckets b
+		LEFT JOIN events e ON e.bucket = b.bucket
+		GROUP BY b.bucket
+		ORDER BY b.bucket
+	`
+	rows, err := r.db.Query(ctx, stmt, seriesArgs(from, to, groupBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company stats: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[stats.CompanyPoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+func (r *StatsRepository) Followers(ctx context.Context, from, to time.Time, groupBy stats.GroupBy) ([]stats.FollowerPoint, error) {
+	stmt := `
+		WITH ` + bucketsCTE + `,
+		events AS (
+			SELECT date_trunc(@unit::text, created_at) AS bucket, event
+			FROM company_follower_events
+			WHERE created_at >= @from AND created_at < @to
+		)
+		SELECT
+			b.bucket,
+			COUNT(*) FILTER (WHERE e.event = 'FOLLOW')::int AS follows,
+			COUNT(*) FILTER (WHERE e.event = 'UNFOLLOW')::int AS unfollows,
+			(COUNT(*) FILTER (WHERE e.event = 'FOLLOW') - COUNT(*) FILTER (WHERE e.event = 'UNFOLLOW'))::int AS net
+		FROM buckets b
+		LEFT JOIN events e ON e.bucket = b.bucket
+		GROUP BY b.bucket
+		ORDER BY b.bucket
+	`
+	rows, err := r.db.Query(ctx, stmt, seriesArgs(from, to, groupBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get follower stats: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[stats.FollowerPoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+// ApprovalTime measures each approval against the latest submission of the
+// same entity before it, so time spent rejected is not counted.
+func (r *StatsRepository) ApprovalTime(ctx context.Context, from, to time.Time, groupBy stats.GroupBy) ([]stats.ApprovalTimePoint, error) {
+	stmt := `
+		WITH ` + bucketsCTE + `,
+		company_waits AS (
+			SELECT
+				date_trunc(@unit::text, h.created_at) AS bucket,
+				EXTRACT(EPOCH FROM h.created_at - s.submitted_at)::float8 AS seconds
+			FROM company_approval_history h
+			CROSS JOIN LATERAL (
+				SELECT max(created_at) AS submitted_at
+				FROM company_approval_history p
+				WHERE p.company_id = h.company_id
+					AND p.action IN ('SUBMITTED', 'RESUBMITTED')
+					AND p.created_at <= h.created_at
+			) s
+			WHERE h.action = 'APPROVED' AND h.created_at >= @from AND h.created_at < @to
+				AND s.submitted_at IS NOT NULL
+		),
+		product_waits AS (
+			SELECT
+				date_trunc(@unit::text, h.created_at) AS bucket,
+				EXTRACT(EPOCH FROM h.created_at - s.submitted_at)::float8 AS seconds
+			FROM product_approval_history h
+			CROSS JOIN LATERAL (
+				SELECT max(created_at) AS submitted_at
+				FROM product_approval_history p
+				WHERE p.product_id = h.product_id
+					AND p.action IN ('SUBMITTED', 'RESUBMITTED')
+					AND p.created_at <= h.created_at
+			) s
+			WHERE h.action IN ('APPROVED', 'AUTO_APPROVED') AND h.created_at >= @from AND h.created_at < @to
+				AND s.submitted_at IS NOT NULL
+		)
+		SELECT
+			b.bucket,
+			COALESCE(c.approvals, 0)::int AS company_approvals,
+			c.median AS company_median_seconds,
+			COALESCE(p.approvals, 0)::int AS product_approvals,
+			p.median AS product_median_seconds
+		FROM buckets b
+		LEFT JOIN (
+			SELECT bucket, COUNT(*) AS approvals,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds) AS median
+			FROM company_waits GROUP BY bucket
+		) c ON c.bucket = b.bucket
+		LEFT JOIN (
+			SELECT bucket, COUNT(*) AS approvals,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds) AS median
+			FROM product_waits GROUP BY bucket
+		) p ON p.bucket = b.bucket
+		ORDER BY b.bucket
+	`
+	rows, err := r.db.Query(ctx, stmt, seriesArgs(from, to, groupBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval time stats: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[stats.ApprovalTimePoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+// ProductsPerCategory is a snapshot of the current catalogue.
+func (r *StatsRepository) ProductsPerCategory(ctx context.Context) ([]stats.CategoryCount, error) {
+	stmt := `
+		SELECT
+			p.category_id,
+			c.name AS category_name,
+			COUNT(*)::int AS total,
+			COUNT(*) FILTER (WHERE p.approval_status = 'APPROVED')::int AS approved,
+			COUNT(*) FILTER (WHERE p.approval_status = 'PENDING')::int AS pending,
+			COUNT(*) FILTER (WHERE p.approval_status = 'REJECTED')::int AS rejected
+		FROM products p
+		LEFT JOIN categories c ON c.id = p.category_id
+		GROUP BY p.category_id, c.name
+		ORDER BY total DESC, c.name
+	`
+	rows, err := r.db.Query(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products per category: %w", err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[stats.CategoryCount])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+	return items, nil
+}
+
+// Subscriptions is a snapshot of the subscriptions per plan.
+func (r *StatsRepository) Subscriptions(ctx context.Context) (*stats.SubscriptionDistribution, error) {
+	stmt := `
+		SELECT
+			sp.id AS plan_id,
+			sp.name AS plan_name,
+			COUNT(cs.id) FILTER (WHERE cs.status = 'ACTIVE')::int AS active,
+			COUNT(cs.id) FILTER (WHERE cs.status = 'PAUSED')::int AS paused,
+			COUNT(cs.id) FILTER (WHERE cs.status = 'EXPIRED')::int AS expired,
+			COUNT(cs.id) FILTER (WHERE cs.status = 'CANCELLED')::int AS cancelled
+		FROM subscription_plans sp
+		LEFT JOIN company_subscriptions cs ON cs.plan_id = sp.id
+		GROUP BY sp.id, sp.name
+		ORDER BY active DESC, sp.name
+	`
+	rows, err := r.db.Query(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription stats: %w", err)
+	}
+
+	plans, err := pgx.CollectRows(rows, pgx.RowToStructByName[stats.PlanCount])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows: %w", err)
+	}
+
+	withoutPlanStmt := `
+		SELECT COUNT(*) FROM companies c
+		WHERE c.approval_status = 'APPROVED'
+			AND NOT EXISTS (
+				SELECT 1 FROM company_subscriptions cs
+				WHERE cs.company_id = c.id AND cs.status = 'ACTIVE'
+			)
+	`
+	dist := &stats.SubscriptionDistribution{Plans: plans}
+	if err := r.db.QueryRow(ctx, withoutPlanStmt).Scan(&dist.CompaniesWithoutPlan); err != nil {
+		return nil, fmt.Errorf("failed to count companies without plan: %w", err)
+	}
+	return dist, nil
+}
@@ -150,7 +150,16 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.Us
 		&user.CreatedAt,
 		&user.UpdatedAt)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
 		return nil, err
 	}
 	return &user, nil
 }
+
+func (r *UserRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID) error {
+	stmt := `UPDATE users SET last_login_at = NOW() WHERE id = @id`
+	_, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{"id": id})
+	return err
+}
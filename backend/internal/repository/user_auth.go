@@ -115,3 +115,62 @@ func (r *UserAuthMethodRepository) EnsureOAuth(ctx context.Context, userID uuid.
 	}
 	return &method, nil
 }
+
+func (r *UserAuthMethodRepository) GetByProviderSub(ctx context.Context, provider string, sub string) (*UserAuthMethod, error) {
+	stmt := `
+		SELECT * FROM user_auth_methods
+		WHERE auth_provider = @auth_provider AND oauth_sub = @oauth_sub
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"auth_provider": provider,
+		"oauth_sub":     sub,
+	})
+	if err != nil {
+		return nil, err
+	}
+	method, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[UserAuthMethod])
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &method, nil
+}
+
+func (r *UserAuthMethodRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]UserAuthMethod, error) {
+	stmt := `
+		SELECT * FROM user_auth_methods
+		WHERE user_id = @user_id
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	methods, err := pgx.CollectRows(rows, pgx.RowToStructByName[UserAuthMethod])
+	if err != nil {
+		return nil, err
+	}
+	return methods, nil
+}
+
+func (r *UserAuthMethodRepository) Delete(ctx context.Context, userID uuid.UUID, provider string) error {
+	stmt := `
+		DELETE FROM user_auth_methods
+		WHERE user_id = @user_id AND auth_provider = @auth_provider
+	`
+	ct, err := r.db.Exec(ctx, stmt, pgx.NamedArgs{
+		"user_id":       userID,
+		"auth_provider": provider,
+	})
+	if err != nil {
+		return err
+	}
+	if ct.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -9,10 +9,10 @@ import (
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 )
 
-func NewRouter(h *handler.Handlers, tokenManager *utils.TokenManager) *echo.Echo {
+func NewRouter(h *handler.Handlers, tokenManager *utils.TokenManager, impersonation middleware.ImpersonationChecker, apiKeys middleware.APIKeyAuthenticator) *echo.Echo {
 	e := echo.New()
 
-	authMiddleware := middleware.NewAuthMiddleware(tokenManager)
+	authMiddleware := middleware.NewAuthMiddleware(tokenManager, impersonation, apiKeys)
 	adminMiddleware := middleware.NewAdminMiddleware()
 	//--GLOBAL MIDDLEWARES
 	e.Use(
@@ -20,13 +20,13 @@ func NewRouter(h *handler.Handlers, tokenManager *utils.TokenManager) *echo.Echo
 		echoMiddleware.RequestID(),
 		echoMiddleware.CORS(),
 		echoMiddleware.BodyLimit("10MB"),
+		middleware.ClientInfo(),
+		middleware.ActiveCompany(),
 	)
 
 	//----REGISTERING THE SYSTEM ROUTES
 	RegisterSystemRoutes(e, h)
 
-	
-	
 	//----REGISTERING THE V1 ROUTES
 	v1Route := e.Group("/api/v1")
 	v1.RegisterV1Routes(e.AcquireContext(), v1Route, h, authMiddleware, adminMiddleware)
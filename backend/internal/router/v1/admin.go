@@ -12,11 +12,49 @@ func RegisterAdminRoutes(r *echo.Group, h *handler.Handlers, admin *middleware.A
 	adminGroup.Use(admin.RequireAdmin)
 
 	adminGroup.GET("/companies/pending", h.Admin.CountPendingCompanyApprovals())
+	adminGroup.GET("/companies/queue", h.Admin.CompanyQueue())
+	adminGroup.POST("/companies/:id/claim", h.Admin.ClaimCompany())
+	adminGroup.DELETE("/companies/:id/claim", h.Admin.ReleaseCompany())
+	adminGroup.PUT("/companies/:id/assign", h.Admin.AssignCompany())
+	adminGroup.GET("/companies/:id/review", h.Admin.GetCompanyReview())
 	adminGroup.PUT("/companies/:id/approve", h.Admin.ApproveCompany())
 	adminGroup.PUT("/companies/:id/reject", h.Admin.RejectCompany())
+	adminGroup.PUT("/companies/bulk/approve", h.Admin.BulkApproveCompanies())
+	adminGroup.PUT("/companies/bulk/reject", h.Admin.BulkRejectCompanies())
+	adminGroup.PUT("/companies/:id/suspend", h.Admin.SuspendCompany())
+	adminGroup.PUT("/companies/:id/reinstate", h.Admin.ReinstateCompany())
+	adminGroup.GET("/companies/images/pending", h.Admin.ListPendingCompanyImages())
+	adminGroup.PUT("/companies/images/:imageId/approve", h.Admin.ApproveCompanyImage())
+	adminGroup.PUT("/companies/images/:imageId/reject", h.Admin.RejectCompanyImage())
 	// adminGroup.DELETE("/companies/:id", h.Admin.())
 
 	adminGroup.PUT("/products/:id/approve", h.Admin.ApproveProduct())
 	adminGroup.PUT("/products/:id/reject", h.Admin.RejectProduct())
+	adminGroup.PUT("/products/bulk/approve", h.Admin.BulkApproveProducts())
+	adminGroup.PUT("/products/bulk/reject", h.Admin.BulkRejectProducts())
+	adminGroup.PUT("/products/:id/suspend", h.Admin.SuspendProduct())
+	adminGroup.PUT("/products/:id/reinstate", h.Admin.ReinstateProduct())
+	adminGroup.GET("/products/revisions", h.Admin.ListPendingRevisions())
+	adminGroup.PUT("/products/revisions/:revisionId/approve", h.Admin.ApproveRevision())
+	adminGroup.PUT("/products/revisions/:revisionId/reject", h.Admin.RejectRevision())
 	adminGroup.GET("/products/pending", h.Admin.CountPendingProducts())
+	adminGroup.GET("/products/queue", h.Admin.ProductQueue())
+	adminGroup.POST("/products/:id/claim", h.Admin.ClaimProduct())
+	adminGroup.DELETE("/products/:id/claim", h.Admin.ReleaseProduct())
+	adminGroup.PUT("/products/:id/assign", h.Admin.AssignProduct())
+
+	adminGroup.GET("/users/:id/login-events", h.User.ListUserLoginEvents())
+	adminGroup.GET("/audit-log", h.Admin.ListAuditLog())
+	adminGroup.GET("/stats", h.Stats.GetStats())
+
+	//user reports
+	adminGroup.GET("/reports", h.Report.ReportQueue())
+	adminGroup.GET("/reports/:entityType/:entityId", h.Report.ListEntityReports())
+	adminGroup.PUT("/reports/:entityType/:entityId/resolve", h.Report.ResolveReports())
+	adminGroup.PUT("/reports/:entityType/:entityId/dismiss", h.Report.DismissReports())
+
+	//support impersonation
+	adminGroup.POST("/users/:id/impersonate", h.Impersonation.Start())
+	adminGroup.GET("/impersonations", h.Impersonation.List())
+	adminGroup.POST("/impersonations/:id/stop", h.Impersonation.Stop())
 }
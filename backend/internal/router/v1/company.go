@@ -9,13 +9,17 @@ import (
 func RegisterCompanyRoutes(r *echo.Group, h *handler.Handlers, auth *middleware.AuthMiddleware) {
 	company := r.Group("/companies")
 
-	company.GET("", h.Company.ListCompanies())      //all companies
-	company.GET("/:id", h.Company.GetCompanyByID()) //by id
+	company.GET("", h.Company.ListCompanies())        //all companies
+	company.GET("/:id", h.Company.GetCompanyByID())   //by id
+	company.GET("/mine", h.Company.ListMyCompanies()) //owned by the caller
+	company.GET("/by-slug/:slug", h.Company.GetCompanyBySlug())
+	company.GET("/by-slug/:slug/storefront", h.Storefront.GetStorefront())
 
 	company.POST("", h.Company.CreateCompany())
 	company.PUT("/:id", h.Company.UpdateCompany())
 	company.DELETE("/:id", h.Company.DeleteCompany())
 	company.POST("/:id/resubmit", h.Company.ResubmitCompany())
+	company.PUT("/:id/slug", h.Company.UpdateSlug())
 
 	company.GET("/:id/histroy", h.Company.GetApprovalHistory()) // all approval histroy
 
@@ -24,5 +28,50 @@ func RegisterCompanyRoutes(r *echo.Group, h *handler.Handlers, auth *middleware.
 	company.GET("/:id/follow-status", h.Company.GetFollowStatus())
 	company.GET("/:id/followers", h.Company.ListFollowers())
 
+	//follower management, owner only
+	company.PUT("/:id/follower-settings", h.Company.UpdateFollowerSettings())
+	company.GET("/:id/follow-requests", h.Company.ListFollowRequests())
+	company.PUT("/:id/follow-requests/:userId/accept", h.Company.AcceptFollowRequest())
+	company.PUT("/:id/follow-requests/:userId/reject", h.Company.RejectFollowRequest())
+	company.DELETE("/:id/followers/:userId", h.Company.RemoveFollower())
+	company.POST("/:id/followers/:userId/block", h.Company.BlockUser())
+
+	//block list, any user can be blocked
+	company.GET("/:id/blocked-users", h.Company.ListBlockedUsers())
+	company.PUT("/:id/blocked-users/:userId", h.Company.BlockUser())
+	company.DELETE("/:id/blocked-users/:userId", h.Company.UnblockUser())
+
 	company.GET("/followed/me", h.Company.ListFollowedCompanies())
+
+	//kyc documents (GST certificate, PAN card, FSSAI licence)
+	company.POST("/:id/kyc-documents/upload-url", h.Company.GenerateKYCUploadURL())
+	company.POST("/:id/kyc-documents/:documentId/confirm", h.Company.ConfirmKYCDocument())
+	company.GET("/:id/kyc-documents", h.Company.ListKYCDocuments())
+	company.DELETE("/:id/kyc-documents/:documentId", h.Company.DeleteKYCDocument())
+
+	//logo and banner, logo changes on approved companies are reviewed
+	company.POST("/:id/images/upload-url", h.Company.GenerateImageUploadURL())
+	company.POST("/:id/images/:imageId/confirm", h.Company.ConfirmImage())
+	company.GET("/:id/images", h.Company.ListImages())
+
+	//where the company delivers, a company without areas delivers everywhere
+	company.GET("/:id/service-areas", h.Company.ListServiceAreas())
+	company.PUT("/:id/service-areas", h.Company.UpdateServiceAreas())
+
+	//business hours (IST), holidays and vacation mode
+	company.GET("/:id/availability", h.Company.GetAvailability())
+	company.PUT("/:id/business-hours", h.Company.UpdateBusinessHours())
+	company.PUT("/:id/holidays", h.Company.UpdateHolidays())
+	company.PUT("/:id/vacation", h.Company.StartVacation())
+	company.DELETE("/:id/vacation", h.Company.EndVacation())
+
+	//api keys (ERP sync), managed by the owner with a normal login
+	company.POST("/:id/api-keys", h.APIKey.CreateAPIKey())
+	company.GET("/:id/api-keys", h.APIKey.ListAPIKeys())
+	company.POST("/:id/api-keys/:keyId/rotate", h.APIKey.RotateAPIKey())
+	company.DELETE("/:id/api-keys/:keyId", h.APIKey.RevokeAPIKey())
+
+	//seller analytics
+	company.GET("/:id/analytics", h.Analytics.GetCompanyAnalytics())
+	company.GET("/:id/products/:productId/analytics", h.Analytics.GetProductAnalytics())
 }
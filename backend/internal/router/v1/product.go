@@ -2,30 +2,41 @@ package v1
 
 import (
 	"github.com/C0deNe0/agromart/internal/handler"
+	"github.com/C0deNe0/agromart/internal/middleware"
+	"github.com/C0deNe0/agromart/internal/model/company"
 	"github.com/labstack/echo/v4"
 )
 
-func RegisterProductRoutes(r *echo.Group, h *handler.Handlers) {
+// product routes also accept company API keys, writes need the matching scope
+func RegisterProductRoutes(r *echo.Group, h *handler.Handlers, auth *middleware.AuthMiddleware) {
 
-	product := r.Group("/products")
+	product := r.Group("/products", auth.RequireAuthOrAPIKey())
+
+	productsWrite := auth.RequireScope(company.ScopeProductsWrite)
+	imagesWrite := auth.RequireScope(company.ScopeImagesWrite)
+	variantsStock := auth.RequireScope(company.ScopeVariantsStock)
 
 	product.GET("", h.Product.ListProducts())
 	product.GET("/:id", h.Product.GetProductByID())
+	product.GET("/:id/serviceability", h.Product.CheckServiceability())
 
-	product.POST("", h.Product.CreateProduct())
-	product.PUT("/:id", h.Product.UpdateProduct())
-	product.DELETE("/:id", h.Product.DeleteProduct())
-	product.POST("/:id/resubmit", h.Product.ResubmitProduct())
+	product.POST("", h.Product.CreateProduct(), productsWrite)
+	product.PUT("/:id", h.Product.UpdateProduct(), productsWrite)
+	product.DELETE("/:id", h.Product.DeleteProduct(), productsWrite)
+	product.PUT("/:id/visibility", h.Product.UpdateProductVisibility(), productsWrite)
+	product.POST("/:id/resubmit", h.Product.ResubmitProduct(), productsWrite)
 	product.GET("/:id/history", h.Product.GetApprovalHistory())
+	product.GET("/:id/revision", h.Product.GetPendingRevision())
 
 	//IMage
-	product.POST("/:id/images/upload-url", h.Product.GenerateImageUploadURL())
-	product.DELETE("/:id/images/:imageId", h.Product.DeleteImage())
-	product.PUT("/:id/images/:imageId/primary", h.Product.SetPrimaryImage())
+	product.POST("/:id/images/upload-url", h.Product.GenerateImageUploadURL(), imagesWrite)
+	product.DELETE("/:id/images/:imageId", h.Product.DeleteImage(), imagesWrite)
+	product.PUT("/:id/images/:imageId/primary", h.Product.SetPrimaryImage(), imagesWrite)
 
 	//variant
-	product.POST("/:id/variants", h.Product.CreateVariant())
-	product.PUT("/:id/variants/:variantId", h.Product.UpdateVariant())
-	product.DELETE("/:id/variants/:variantId", h.Product.DeleteVariant())
+	product.POST("/:id/variants", h.Product.CreateVariant(), productsWrite)
+	product.PUT("/:id/variants/:variantId", h.Product.UpdateVariant(), productsWrite)
+	product.PATCH("/:id/variants/:variantId/stock", h.Product.UpdateVariantStock(), variantsStock)
+	product.DELETE("/:id/variants/:variantId", h.Product.DeleteVariant(), productsWrite)
 
 }
@@ -16,6 +16,18 @@ func RegisterV1Routes(c echo.Context, r *echo.Group, h *handler.Handlers, auth *
 	authRoutes.POST("/refresh", h.Auth.Refresh())
 	//googleLogin
 	authRoutes.POST("/google/login", h.Auth.LoginWithGoogleIDToken())
+	//web admin panel (authorization code + PKCE, refresh token in cookie)
+	authRoutes.GET("/google/web/login", h.Auth.GoogleLogin())
+	authRoutes.GET("/google/callback", h.Auth.GoogleCallback())
+	authRoutes.POST("/web/refresh", h.Auth.WebRefresh())
+	authRoutes.POST("/web/logout", h.Auth.WebLogout())
+	//any configured OIDC provider
+	authRoutes.POST("/oidc/:provider/login", h.Auth.LoginWithOIDC())
+	//ends an impersonation session using its own (possibly read-only) token
+	authRoutes.POST("/impersonation/stop", h.Impersonation.StopCurrent())
+
+	//products take either a JWT or a company API key
+	RegisterProductRoutes(r, h, auth)
 
 	//----PROTECTED ROUTES
 	api := r.Group("")
@@ -23,13 +35,21 @@ func RegisterV1Routes(c echo.Context, r *echo.Group, h *handler.Handlers, auth *
 
 	//USER
 	api.GET("/user/me", h.User.Me())
+	api.GET("/user/me/login-events", h.User.ListMyLoginEvents())
+	api.GET("/user/me/auth-methods", h.Auth.ListAuthMethods())
+	api.POST("/user/me/auth-methods/:provider", h.Auth.LinkProvider())
+	api.DELETE("/user/me/auth-methods/:provider", h.Auth.UnlinkProvider())
+
+	//REPORTS
+	api.POST("/reports", h.Report.CreateReport())
+	api.GET("/user/me/reports", h.Report.ListMyReports())
+
+	//FEED (products from followed companies)
+	api.GET("/feed", h.Feed.GetFeed())
 
 	//COMPANIES
 	RegisterCompanyRoutes(api, h, auth)
 
-	//products
-	RegisterProductRoutes(api, h)
-
 	//admin
 	RegisterAdminRoutes(api, h, admin)
 
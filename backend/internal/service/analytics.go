@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/analytics"
+	"github.com/C0deNe0/agromart/internal/model/stats"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/C0deNe0/agromart/internal/repository/productRepo"
+	"github.com/google/uuid"
+)
+
+// products listed on the company dashboard
+const AnalyticsTopProducts = 10
+
+// AnalyticsService serves the seller dashboards. Conversion needs orders,
+// which the platform does not have yet.
+type AnalyticsService struct {
+	analyticsRepo *repository.AnalyticsRepository
+	companyRepo   *repository.CompanyRepository
+	productRepo   *productRepo.ProductRepository
+}
+
+func NewAnalyticsService(analyticsRepo *repository.AnalyticsRepository, companyRepo *repository.CompanyRepository, productRepo *productRepo.ProductRepository) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		companyRepo:   companyRepo,
+		productRepo:   productRepo,
+	}
+}
+
+func (s *AnalyticsService) Company(ctx context.Context, userID, companyID uuid.UUID, from, to *time.Time, groupBy stats.GroupBy) (*analytics.CompanyAnalyticsResponse, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to view analytics of this company")
+	}
+
+	start, end, err := analyticsRange(from, to, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := s.analyticsRepo.CompanySeries(ctx, companyID, start, end, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	unique, err := s.analyticsRepo.UniqueViewers(ctx, companyID, nil, start, end)
+	if err != nil {
+		return nil, err
+	}
+	top, err := s.analyticsRepo.TopProducts(ctx, companyID, start, end, AnalyticsTopProducts)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := analytics.Totals{UniqueViewers: unique}
+	for _, p := range series {
+		totals.Views += p.Views
+		totals.Impressions += p.Impressions
+		totals.FavoriteAdds += p.FavoriteAdds
+		totals.Follows += p.Follows
+		totals.Unfollows += p.Unfollows
+	}
+
+	return &analytics.CompanyAnalyticsResponse{
+		CompanyID:     companyID,
+		From:          start,
+		To:            end,
+		GroupBy:       groupBy,
+		FollowerCount: comp.FollowerCount,
+		Totals:        totals,
+		Series:        series,
+		TopProducts:   top,
+	}, nil
+}
+
+func (s *AnalyticsService) Product(ctx context.Context, userID, companyID, productID uuid.UUID, from, to *time.Time, groupBy stats.GroupBy) (*analytics.ProductAnalyticsResponse, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to view analytics of this company")
+	}
+	p, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil || p.CompanyID != companyID {
+		return nil, errors.New("product not found")
+	}
+
+	start, end, err := analyticsRange(from, to, groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := s.analyticsRepo.ProductSeries(ctx, productID, start, end, groupBy)
+	if err != nil {
+		return nil, err
+	}
+	unique, err := s.analyticsRepo.UniqueViewers(ctx, companyID, &productID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := analytics.Totals{UniqueViewers: unique}
+	for _, p := range series {
+		totals.Views += p.Views
+		totals.Impressions += p.Impressions
+		totals.FavoriteAdds += p.FavoriteAdds
+	}
+
+	return &analytics.ProductAnalyticsResponse{
+		ProductID: productID,
+		CompanyID: companyID,
+		From:      start,
+		To:        end,
+		GroupBy:   groupBy,
+		Totals:    totals,
+		Series:    series,
+	}, nil
+}
+
+// analyticsRange applies the same defaults and limits as the admin stats.
+func analyticsRange(from, to *time.Time, groupBy stats.GroupBy) (time.Time, time.Time, error) {
+	end := time.Now().UTC()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-DefaultStatsRange)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		return start, end, fmt.Errorf("from must be before to")
+	}
+	if buckets := approxBuckets(start, end, groupBy); buckets > MaxStatsBuckets {
+		return start, end, fmt.Errorf("range spans %d %s buckets, at most %d are allowed", buckets, groupBy, MaxStatsBuckets)
+	}
+	return start, end, nil
+}
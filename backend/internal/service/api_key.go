@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/model/audit"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/google/uuid"
+)
+
+type APIKeyService struct {
+	apiKeyRepo  *repository.CompanyAPIKeyRepository
+	companyRepo *repository.CompanyRepository
+	audit       *AuditService
+}
+
+func NewAPIKeyService(apiKeyRepo *repository.CompanyAPIKeyRepository, companyRepo *repository.CompanyRepository, audit *AuditService) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo:  apiKeyRepo,
+		companyRepo: companyRepo,
+		audit:       audit,
+	}
+}
+
+func (s *APIKeyService) Create(ctx context.Context, userID uuid.UUID, req *company.CreateAPIKeyRequest) (*company.CreatedAPIKeyResponse, error) {
+	comp, err := s.ownedCompany(ctx, userID, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if !comp.CanCreateProducts() {
+		return nil, errors.New("api keys are only available for approved, active companies")
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, errors.New("expiresAt must be in the future")
+	}
+
+	created, err := s.issue(ctx, &company.APIKey{
+		CompanyID:   comp.ID,
+		CreatedByID: userID,
+		Name:        req.Name,
+		Scopes:      req.Scopes,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// only the key metadata, never the raw key
+	s.audit.Record(ctx, userID, audit.ActionCreate, audit.EntityCompanyAPIKey, created.ID, nil, created.APIKey)
+	return created, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context, userID, companyID uuid.UUID) ([]company.APIKey, error) {
+	if _, err := s.ownedCompany(ctx, userID, companyID); err != nil {
+		return nil, err
+	}
+	return s.apiKeyRepo.ListByCompany(ctx, companyID)
+}
+
+// Rotate issues a new key with the same name and scopes. The old key is
+// revoked right away, or keeps working until the grace period runs out.
+func (s *APIKeyService) Rotate(ctx context.Context, userID uuid.UUID, req *company.RotateAPIKeyRequest) (*company.CreatedAPIKeyResponse, error) {
+	if _, err := s.ownedCompany(ctx, userID, req.CompanyID); err != nil {
+		return nil, err
+	}
+
+	old, err := s.companyKey(ctx, req.CompanyID, req.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if !old.IsActive() {
+		return nil, errors.New("cannot rotate a revoked or expired api key")
+	}
+
+	created, err := s.issue(ctx, &company.APIKey{
+		CompanyID:     old.CompanyID,
+		CreatedByID:   userID,
+		Name:          old.Name,
+		Scopes:        old.Scopes,
+		ExpiresAt:     old.ExpiresAt,
+		RotatedFromID: &old.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GracePeriodMinutes > 0 {
+		err = s.apiKeyRepo.ExpireAt(ctx, old.ID, time.Now().Add(time.Duration(req.GracePeriodMinutes)*time.Minute))
+	} else {
+		err = s.apiKeyRepo.Revoke(ctx, old.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to retire old api key: %w", err)
+	}
+
+	s.audit.Record(ctx, userID, audit.ActionRotate, audit.EntityCompanyAPIKey, old.ID, old, created.APIKey)
+	return created, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, userID uuid.UUID, companyID, keyID uuid.UUID) error {
+	if _, err := s.ownedCompany(ctx, userID, companyID); err != nil {
+		return err
+	}
+	key, err := s.companyKey(ctx, companyID, keyID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, keyID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return errors.New("api key already revoked")
+		}
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionRevoke, audit.EntityCompanyAPIKey, keyID, key, nil)
+	return nil
+}
+
+// AuthenticateAPIKey resolves a raw key into the company it acts for and
+// records its usage.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, rawKey string, ip string) (*company.APIKeyPrincipal, error) {
+	key, err := s.apiKeyRepo.GetActiveByHash(ctx, utils.HashToken(rawKey))
+	if err != nil {
+		return nil, errors.New("invalid api key")
+	}
+
+	comp, err := s.companyRepo.GetByID(ctx, key.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !comp.CanCreateProducts() {
+		return nil, errors.New("company is not approved or inactive")
+	}
+
+	if err := s.apiKeyRepo.TouchLastUsed(ctx, key.ID, ip); err != nil {
+		return nil, err
+	}
+
+	return &company.APIKeyPrincipal{
+		KeyID:     key.ID,
+		CompanyID: comp.ID,
+		OwnerID:   comp.OwnerID,
+		Scopes:    key.Scopes,
+	}, nil
+}
+
+func (s *APIKeyService) issue(ctx context.Context, k *company.APIKey) (*company.CreatedAPIKeyResponse, error) {
+	raw, prefix, err := utils.GenerateAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+	k.KeyPrefix = prefix
+	k.KeyHash = utils.HashToken(raw)
+
+	created, err := s.apiKeyRepo.Create(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return &company.CreatedAPIKeyResponse{
+		APIKey: *created,
+		Key:    raw,
+	}, nil
+}
+
+func (s *APIKeyService) ownedCompany(ctx context.Context, userID, companyID uuid.UUID) (*company.Company, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if comp.OwnerID != userID {
+		return nil, errors.New("not authorized to manage api keys for this company")
+	}
+	return comp, nil
+}
+
+func (s *APIKeyService) companyKey(ctx context.Context, companyID, keyID uuid.UUID) (*company.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("api key not found: %w", err)
+	}
+	if key.CompanyID != companyID {
+		return nil, errors.New("api key does not belong to this company")
+	}
+	return key, nil
+}
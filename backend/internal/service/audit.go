@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/audit"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// AuditService writes the platform-wide audit log. Like the login trail it
+// is best effort: a failing insert is logged and never fails the action.
+type AuditService struct {
+	auditLogRepo *repository.AuditLogRepository
+	log          *zerolog.Logger
+}
+
+func NewAuditService(auditLogRepo *repository.AuditLogRepository, log *zerolog.Logger) *AuditService {
+	return &AuditService{
+		auditLogRepo: auditLogRepo,
+		log:          log,
+	}
+}
+
+// Record stores one action. actorID is uuid.Nil for system actions, before
+// and after are stored as JSON and may be nil. Request id, IP and the
+// impersonating admin are taken from the request context.
+func (s *AuditService) Record(ctx context.Context, actorID uuid.UUID, action audit.Action, entityType audit.EntityType, entityID uuid.UUID, before, after any) {
+	entry := &audit.Entry{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	if actorID != uuid.Nil {
+		entry.ActorID = &actorID
+	}
+	if adminID, ok := utils.ImpersonatorFromContext(ctx); ok {
+		entry.ImpersonatorID = &adminID
+	}
+
+	info := utils.ClientInfoFromContext(ctx)
+	if info.RequestID != "" {
+		entry.RequestID = &info.RequestID
+	}
+	if info.IPAddress != "" {
+		entry.IPAddress = &info.IPAddress
+	}
+
+	var err error
+	if entry.Before, err = auditJSON(before); err == nil {
+		entry.After, err = auditJSON(after)
+	}
+	if err == nil {
+		err = s.auditLogRepo.Create(ctx, entry)
+	}
+	if err != nil {
+		s.log.Error().Err(err).
+			Str("action", string(action)).
+			Str("entityType", string(entityType)).
+			Str("entityId", entityID.String()).
+			Msg("failed to record audit log entry")
+	}
+}
+
+// RecordBulk records the same change for every id that did not fail.
+func (s *AuditService) RecordBulk(ctx context.Context, actorID uuid.UUID, action audit.Action, entityType audit.EntityType, ids []uuid.UUID, failed map[uuid.UUID]error, before, after any) {
+	for _, id := range ids {
+		if _, ok := failed[id]; ok {
+			continue
+		}
+		s.Record(ctx, actorID, action, entityType, id, before, after)
+	}
+}
+
+func (s *AuditService) List(ctx context.Context, filter repository.AuditLogFilter) (*model.PaginatedResponse[audit.Entry], error) {
+	result, err := s.auditLogRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	return result, nil
+}
+
+// auditDecision is the state stored around approval status changes.
+type auditDecision struct {
+	ApprovalStatus company.ApprovalStatus `json:"approvalStatus"`
+	Reason         *string                `json:"reason,omitempty"`
+	Notes          *string                `json:"notes,omitempty"`
+}
+
+func auditJSON(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit state: %w", err)
+	}
+	return b, nil
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/audit"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// how often Run looks for vacations whose end has passed
+const VacationSweepInterval = time.Minute
+
+// AvailabilityService covers when a seller is around: weekly business
+// hours, holidays and vacation mode. A vacation only changes how products
+// are shown, their approval state is left alone.
+type AvailabilityService struct {
+	companyRepo *repository.CompanyRepository
+	holidayRepo *repository.CompanyHolidayRepository
+	audit       *AuditService
+	log         *zerolog.Logger
+}
+
+func NewAvailabilityService(companyRepo *repository.CompanyRepository, holidayRepo *repository.CompanyHolidayRepository, audit *AuditService, log *zerolog.Logger) *AvailabilityService {
+	return &AvailabilityService{
+		companyRepo: companyRepo,
+		holidayRepo: holidayRepo,
+		audit:       audit,
+		log:         log,
+	}
+}
+
+func (s *AvailabilityService) Get(ctx context.Context, companyID uuid.UUID) (*company.AvailabilityResponse, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+
+	today := businessDate(time.Now())
+	holidays, err := s.holidayRepo.ListFrom(ctx, comp.ID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &company.AvailabilityResponse{
+		CompanyID:        comp.ID,
+		BusinessHours:    comp.BusinessHours,
+		Timezone:         company.BusinessTimezone.String(),
+		UpcomingHolidays: holidays,
+	}
+	if resp.BusinessHours == nil {
+		resp.BusinessHours = company.BusinessHours{}
+	}
+	if comp.IsOnVacation() {
+		resp.OnVacation = true
+		resp.VacationUntil = comp.VacationUntil
+		resp.VacationMessage = comp.VacationMessage
+	}
+	if len(comp.BusinessHours) > 0 {
+		open := !resp.OnVacation && comp.BusinessHours.IsOpenAt(time.Now())
+		if open && len(holidays) > 0 && holidays[0].Date.Equal(today) {
+			open = false
+		}
+		resp.OpenNow = &open
+	}
+	return resp, nil
+}
+
+func (s *AvailabilityService) UpdateBusinessHours(ctx context.Context, userID uuid.UUID, req *company.UpdateBusinessHoursRequest) (*company.Company, error) {
+	comp, err := s.ownedCompany(ctx, userID, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	hours := req.Hours
+	if hours == nil {
+		hours = company.BusinessHours{}
+	}
+
+	updated, err := s.companyRepo.SetBusinessHours(ctx, comp.ID, hours)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityCompany, comp.ID,
+		map[string]any{"businessHours": comp.BusinessHours},
+		map[string]any{"businessHours": updated.BusinessHours},
+	)
+	return updated, nil
+}
+
+// UpdateHolidays replaces the holidays from today on.
+func (s *AvailabilityService) UpdateHolidays(ctx context.Context, userID uuid.UUID, req *company.UpdateHolidaysRequest) ([]company.Holiday, error) {
+	comp, err := s.ownedCompany(ctx, userID, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := businessDate(time.Now())
+	byDate := make(map[time.Time]company.Holiday, len(req.Holidays))
+	for _, in := range req.Holidays {
+		date, err := time.Parse(time.DateOnly, in.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date: %s", in.Date)
+		}
+		if date.Before(today) {
+			return nil, fmt.Errorf("holiday %s is in the past", in.Date)
+		}
+		byDate[date] = company.Holiday{CompanyID: comp.ID, Date: date, Note: in.Note}
+	}
+	holidays := make([]company.Holiday, 0, len(byDate))
+	for _, h := range byDate {
+		holidays = append(holidays, h)
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+
+	before, err := s.holidayRepo.ListFrom(ctx, comp.ID, today)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.holidayRepo.ReplaceFrom(ctx, comp.ID, today, holidays); err != nil {
+		return nil, err
+	}
+	after, err := s.holidayRepo.ListFrom(ctx, comp.ID, today)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityCompany, comp.ID,
+		map[string]any{"holidays": before},
+		map[string]any{"holidays": after},
+	)
+	return after, nil
+}
+
+// StartVacation shows every product of the company as unavailable until
+// the given time, when the sweep in Run ends the vacation.
+func (s *AvailabilityService) StartVacation(ctx context.Context, userID uuid.UUID, req *company.StartVacationRequest) (*company.Company, error) {
+	comp, err := s.ownedCompany(ctx, userID, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !req.Until.After(now) {
+		return nil, errors.New("vacation end must be in the future")
+	}
+	if req.Until.After(now.AddDate(0, 0, company.MaxVacationDays)) {
+		return nil, fmt.Errorf("vacation can be at most %d days", company.MaxVacationDays)
+	}
+
+	updated, err := s.companyRepo.SetVacation(ctx, comp.ID, &req.Until, req.Message)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityCompany, comp.ID,
+		auditVacation{Until: comp.VacationUntil, Message: comp.VacationMessage},
+		auditVacation{Until: updated.VacationUntil, Message: updated.VacationMessage},
+	)
+	return updated, nil
+}
+
+// EndVacation brings the company back before the planned date.
+func (s *AvailabilityService) EndVacation(ctx context.Context, userID uuid.UUID, req *company.EndVacationRequest) (*company.Company, error) {
+	comp, err := s.ownedCompany(ctx, userID, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if comp.VacationUntil == nil {
+		return nil, errors.New("company is not on vacation")
+	}
+
+	updated, err := s.companyRepo.SetVacation(ctx, comp.ID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityCompany, comp.ID,
+		auditVacation{Until: comp.VacationUntil, Message: comp.VacationMessage},
+		auditVacation{},
+	)
+	return updated, nil
+}
+
+// EndExpiredVacations ends every vacation whose end has passed.
+func (s *AvailabilityService) EndExpiredVacations(ctx context.Context) error {
+	companies, err := s.companyRepo.EndExpiredVacations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range companies {
+		s.audit.Record(ctx, uuid.Nil, audit.ActionUpdate, audit.EntityCompany, id, nil, auditVacation{})
+	}
+
+	if len(companies) > 0 {
+		s.log.Info().Int("companies", len(companies)).Msg("ended expired vacations")
+	}
+	return nil
+}
+
+// Run sweeps expired vacations every interval until ctx is done.
+func (s *AvailabilityService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.EndExpiredVacations(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			s.log.Error().Err(err).Msg("failed to end expired vacations")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AvailabilityService) ownedCompany(ctx context.Context, userID, companyID uuid.UUID) (*company.Company, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("unauthorized to update the company")
+	}
+	return comp, nil
+}
+
+// what the audit log keeps of a vacation change
+type auditVacation struct {
+	Until   *time.Time `json:"vacationUntil"`
+	Message *string    `json:"vacationMessage,omitempty"`
+}
+
+// businessDate is the calendar day of t in the business timezone, as a
+// UTC midnight so it compares with parsed dates.
+func businessDate(t time.Time) time.Time {
+	y, m, d := t.In(company.BusinessTimezone).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// applyVacations marks the products of companies on vacation as
+// temporarily unavailable and says when they are back.
+func applyVacations(ctx context.Context, companyRepo *repository.CompanyRepository, products ...*product.ProductResponse) error {
+	if len(products) == 0 {
+		return nil
+	}
+	seen := make(map[uuid.UUID]bool)
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, p := range products {
+		if !seen[p.CompanyID] {
+			seen[p.CompanyID] = true
+			ids = append(ids, p.CompanyID)
+		}
+	}
+
+	vacations, err := companyRepo.VacationsByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range products {
+		if until, ok := vacations[p.CompanyID]; ok {
+			p.TemporarilyUnavailable = true
+			p.BackOn = &until
+		}
+	}
+	return nil
+}
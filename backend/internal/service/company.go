@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"strings"
 
+	"github.com/C0deNe0/agromart/internal/lib/utils"
 	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/audit"
 	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/model/moderation"
 	"github.com/C0deNe0/agromart/internal/repository"
 	"github.com/google/uuid"
 )
@@ -14,12 +19,18 @@ import (
 type CompanyService struct {
 	companyRepo         *repository.CompanyRepository
 	companyFollowerRepo *repository.CompanyFollowerRepository
+	moderationRepo      *repository.ModerationRepository
+	serviceAreaRepo     *repository.CompanyServiceAreaRepository
+	audit               *AuditService
 }
 
-func NewCompanyService(companyRepo *repository.CompanyRepository, companyFollowerRepo *repository.CompanyFollowerRepository) *CompanyService {
+func NewCompanyService(companyRepo *repository.CompanyRepository, companyFollowerRepo *repository.CompanyFollowerRepository, moderationRepo *repository.ModerationRepository, serviceAreaRepo *repository.CompanyServiceAreaRepository, audit *AuditService) *CompanyService {
 	return &CompanyService{
 		companyRepo:         companyRepo,
 		companyFollowerRepo: companyFollowerRepo,
+		moderationRepo:      moderationRepo,
+		serviceAreaRepo:     serviceAreaRepo,
+		audit:               audit,
 	}
 }
 
@@ -42,10 +53,28 @@ func (s *CompanyService) Create(ctx context.Context, userID uuid.UUID, c company
 		c.ProductVisibility = company.ProductVisibilityPublic
 	}
 
+	if c.Slug != "" {
+		if err := s.checkSlug(ctx, c.Slug, uuid.Nil); err != nil {
+			return nil, err
+		}
+	} else {
+		slug, err := s.uniqueSlug(ctx, c.Name)
+		if err != nil {
+			return nil, err
+		}
+		c.Slug = slug
+	}
+
+	normalizeTaxIDs(&c)
+	if check := checkTaxIDs(&c); !check.OK() {
+		return nil, errors.New(strings.Join(check.Errors, "; "))
+	}
+
 	created, err := s.companyRepo.Create(ctx, &c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create company: %w", err)
 	}
+	s.audit.Record(ctx, userID, audit.ActionCreate, audit.EntityCompany, created.ID, nil, created)
 	return created, nil
 
 }
@@ -54,6 +83,13 @@ func (s *CompanyService) GetByID(ctx context.Context, id uuid.UUID, userID *uuid
 	if err != nil {
 		return nil, fmt.Errorf("company not found: %w", err)
 	}
+	isOwner := userID != nil && c.OwnerID == *userID
+	if c.IsSuspended() && !isOwner {
+		return nil, errors.New("company is suspended")
+	}
+	if c.IsHidden() && !isOwner {
+		return nil, errors.New("company is under review")
+	}
 
 	var isFollowing *bool
 	if userID != nil {
@@ -70,7 +106,101 @@ func (s *CompanyService) GetByID(ctx context.Context, id uuid.UUID, userID *uuid
 
 	return company.ToCompanyResponse(c, isFollowing), nil
 }
+
+// GetBySlug resolves current and earlier slugs. For an earlier one moved
+// is true and the response carries the current slug to redirect to.
+func (s *CompanyService) GetBySlug(ctx context.Context, slug string, userID *uuid.UUID) (*company.CompanyResponse, bool, error) {
+	c, moved, err := s.companyRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, false, errors.New("company not found")
+	}
+
+	resp, err := s.GetByID(ctx, c.ID, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp, moved, nil
+}
+
+// =============================================
+// SLUGS
+// =============================================
+
+func (s *CompanyService) UpdateSlug(ctx context.Context, userID uuid.UUID, req *company.UpdateSlugRequest) (*company.Company, error) {
+	existing, err := s.companyRepo.GetByID(ctx, req.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !canManageCompany(ctx, existing, userID) {
+		return nil, errors.New("unauthorized to update the company")
+	}
+	if existing.Slug == req.Slug {
+		return existing, nil
+	}
+	if err := s.checkSlug(ctx, req.Slug, existing.ID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.companyRepo.ChangeSlug(ctx, existing.ID, req.Slug)
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, errors.New("slug is already taken")
+		}
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityCompany, existing.ID,
+		map[string]string{"slug": existing.Slug},
+		map[string]string{"slug": updated.Slug},
+	)
+	return updated, nil
+}
+
+func (s *CompanyService) checkSlug(ctx context.Context, slug string, companyID uuid.UUID) error {
+	if !utils.IsValidSlug(slug) {
+		return fmt.Errorf("slug must be %d-%d lowercase letters or digits separated by single hyphens", utils.SlugMinLength, utils.SlugMaxLength)
+	}
+	taken, err := s.companyRepo.SlugTaken(ctx, slug, companyID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return errors.New("slug is already taken")
+	}
+	return nil
+}
+
+// uniqueSlug derives a free slug from the name, adding -2, -3, ... when
+// the plain one is taken.
+func (s *CompanyService) uniqueSlug(ctx context.Context, name string) (string, error) {
+	base := utils.Slugify(name)
+	if len(base) < utils.SlugMinLength {
+		base = strings.Trim(base+"-company", "-")
+	}
+	// leaves room for the suffix
+	if len(base) > utils.SlugMaxLength-4 {
+		base = strings.TrimRight(base[:utils.SlugMaxLength-4], "-")
+	}
+
+	for i := 1; i <= 100; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken, err := s.companyRepo.SlugTaken(ctx, candidate, uuid.Nil)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+	return "", errors.New("could not find a free slug, please choose one")
+}
+
 func (s *CompanyService) List(ctx context.Context, userID *uuid.UUID, filter repository.CompanyFilter) (*model.PaginatedResponse[company.CompanyResponse], error) {
+	// owners keep seeing their own companies while reports are reviewed
+	filter.ExcludeHidden = userID == nil || filter.OwnerID == nil || *filter.OwnerID != *userID
+
 	result, err := s.companyRepo.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list companies:%w", err)
@@ -107,6 +237,7 @@ func (s *CompanyService) Update(ctx context.Context, userID uuid.UUID, companyID
 	if !existing.CanBeModified() {
 		return nil, fmt.Errorf("cannot modify company with status: %s. Only PENDING and REJECTED companies can be modified", existing.ApprovalStatus)
 	}
+	before := *existing
 
 	if updates.Name != nil {
 		duplicate, err := s.companyRepo.GetByOwnerAndName(ctx, userID, *updates.Name)
@@ -123,9 +254,6 @@ func (s *CompanyService) Update(ctx context.Context, userID uuid.UUID, companyID
 	if updates.Description != nil {
 		existing.Description = updates.Description
 	}
-	if updates.LogoURL != nil {
-		existing.LogoURL = updates.LogoURL
-	}
 	if updates.BusinessEmail != nil {
 		existing.BusinessEmail = updates.BusinessEmail
 	}
@@ -154,7 +282,17 @@ func (s *CompanyService) Update(ctx context.Context, userID uuid.UUID, companyID
 		existing.IsActive = *updates.IsActive
 	}
 
-	return s.companyRepo.Update(ctx, existing)
+	normalizeTaxIDs(existing)
+	if check := checkTaxIDs(existing); !check.OK() {
+		return nil, errors.New(strings.Join(check.Errors, "; "))
+	}
+
+	updated, err := s.companyRepo.Update(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityCompany, companyID, before, updated)
+	return updated, nil
 }
 
 func (s *CompanyService) Delete(ctx context.Context, userID uuid.UUID, companyID uuid.UUID) error {
@@ -168,7 +306,11 @@ func (s *CompanyService) Delete(ctx context.Context, userID uuid.UUID, companyID
 	if !existing.CanBeModified() {
 		return fmt.Errorf("cannot delete company with status: %s", existing.ApprovalStatus)
 	}
-	return s.companyRepo.Delete(ctx, companyID)
+	if err := s.companyRepo.Delete(ctx, companyID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionDelete, audit.EntityCompany, companyID, existing, nil)
+	return nil
 }
 
 func (s *CompanyService) Resubmit(ctx context.Context, userID uuid.UUID, companyID uuid.UUID) error {
@@ -185,10 +327,100 @@ func (s *CompanyService) Resubmit(ctx context.Context, userID uuid.UUID, company
 		return errors.New("only rejected companies can be resubmitted")
 	}
 
-	return s.companyRepo.Resubmit(ctx, companyID, userID)
+	if err := s.companyRepo.Resubmit(ctx, companyID, userID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionResubmit, audit.EntityCompany, companyID,
+		auditDecision{ApprovalStatus: existing.ApprovalStatus, Reason: existing.RejectionReason},
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending})
+	return nil
 }
 
 func (s *CompanyService) Approve(ctx context.Context, companyID uuid.UUID, adminID uuid.UUID, notes *string) error {
+	if err := s.checkApprovable(ctx, companyID, adminID); err != nil {
+		return err
+	}
+	if err := s.companyRepo.Approve(ctx, companyID, adminID, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionApprove, audit.EntityCompany, companyID,
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+		auditDecision{ApprovalStatus: company.ApprovalStatusApproved, Notes: notes})
+	return nil
+}
+
+func (s *CompanyService) Reject(ctx context.Context, companyID, adminID uuid.UUID, reason string, notes *string) error {
+	if err := s.checkRejectable(ctx, companyID, adminID); err != nil {
+		return err
+	}
+	if err := s.companyRepo.Reject(ctx, companyID, adminID, reason, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionReject, audit.EntityCompany, companyID,
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+		auditDecision{ApprovalStatus: company.ApprovalStatusRejected, Reason: &reason, Notes: notes})
+	return nil
+}
+
+// BulkApprove runs the same checks as Approve for every id, then approves
+// the ones that passed in a single transaction.
+func (s *CompanyService) BulkApprove(ctx context.Context, companyIDs []uuid.UUID, adminID uuid.UUID, notes *string) (*moderation.BulkResult, error) {
+	companyIDs = uniqueIDs(companyIDs)
+	failed := make(map[uuid.UUID]error)
+
+	valid := make([]uuid.UUID, 0, len(companyIDs))
+	for _, id := range companyIDs {
+		if err := s.checkApprovable(ctx, id, adminID); err != nil {
+			failed[id] = err
+			continue
+		}
+		valid = append(valid, id)
+	}
+
+	if len(valid) > 0 {
+		repoFailed, err := s.companyRepo.BulkApprove(ctx, valid, adminID, notes)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(failed, repoFailed)
+	}
+
+	s.audit.RecordBulk(ctx, adminID, audit.ActionApprove, audit.EntityCompany, valid, failed,
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+		auditDecision{ApprovalStatus: company.ApprovalStatusApproved, Notes: notes})
+
+	return bulkResult(companyIDs, failed), nil
+}
+
+func (s *CompanyService) BulkReject(ctx context.Context, companyIDs []uuid.UUID, adminID uuid.UUID, reason string, notes *string) (*moderation.BulkResult, error) {
+	companyIDs = uniqueIDs(companyIDs)
+	failed := make(map[uuid.UUID]error)
+
+	valid := make([]uuid.UUID, 0, len(companyIDs))
+	for _, id := range companyIDs {
+		if err := s.checkRejectable(ctx, id, adminID); err != nil {
+			failed[id] = err
+			continue
+		}
+		valid = append(valid, id)
+	}
+
+	if len(valid) > 0 {
+		repoFailed, err := s.companyRepo.BulkReject(ctx, valid, adminID, reason, notes)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(failed, repoFailed)
+	}
+
+	s.audit.RecordBulk(ctx, adminID, audit.ActionReject, audit.EntityCompany, valid, failed,
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+		auditDecision{ApprovalStatus: company.ApprovalStatusRejected, Reason: &reason, Notes: notes})
+
+	return bulkResult(companyIDs, failed), nil
+}
+
+func (s *CompanyService) checkApprovable(ctx context.Context, companyID, adminID uuid.UUID) error {
 	existing, err := s.companyRepo.GetByID(ctx, companyID)
 	if err != nil {
 		return err
@@ -198,10 +430,15 @@ func (s *CompanyService) Approve(ctx context.Context, companyID uuid.UUID, admin
 		return fmt.Errorf("only pending companies can be approved. Current status: %s", existing.ApprovalStatus)
 	}
 
-	return s.companyRepo.Approve(ctx, companyID, adminID, notes)
+	// older records were never validated, don't approve them blind
+	if check := checkTaxIDs(existing); !check.OK() {
+		return fmt.Errorf("cannot approve company with invalid tax ids: %s", strings.Join(check.Errors, "; "))
+	}
+
+	return ensureReviewer(ctx, s.moderationRepo, moderation.EntityCompany, companyID, adminID)
 }
 
-func (s *CompanyService) Reject(ctx context.Context, companyID, adminID uuid.UUID, reason string, notes *string) error {
+func (s *CompanyService) checkRejectable(ctx context.Context, companyID, adminID uuid.UUID) error {
 	existing, err := s.companyRepo.GetByID(ctx, companyID)
 	if err != nil {
 		return fmt.Errorf("company not found :%w", err)
@@ -212,11 +449,61 @@ func (s *CompanyService) Reject(ctx context.Context, companyID, adminID uuid.UUI
 		return fmt.Errorf("only pending companies can be rejected. Current status: %s", existing.ApprovalStatus)
 	}
 
-	return s.companyRepo.Reject(ctx, companyID, adminID, reason, notes)
+	return ensureReviewer(ctx, s.moderationRepo, moderation.EntityCompany, companyID, adminID)
+}
+
+// GetApprovalHistory is available to the owner and to admins, submission
+// snapshots carry the tax ids and contact details.
+func (s *CompanyService) GetApprovalHistory(ctx context.Context, userID uuid.UUID, isAdmin bool, companyID uuid.UUID) ([]company.CompanyApprovalHistory, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !isAdmin && !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to view the history of this company")
+	}
+
+	history, err := s.companyRepo.GetApprovalHistory(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := diffSubmissions(history); err != nil {
+		return nil, err
+	}
+	return history, nil
 }
 
-func (s *CompanyService) GetApprovalHistory(ctx context.Context, companyID uuid.UUID) ([]company.CompanyApprovalHistory, error) {
-	return s.companyRepo.GetApprovalHistory(ctx, companyID)
+// approvalSubmission is a company or product approval history row.
+type approvalSubmission interface {
+	IsSubmission() bool
+	GetSnapshot() map[string]any
+	SetChanges(model.FieldChanges)
+}
+
+// diffSubmissions sets Changes on each resubmission to what differs from
+// the submission before it. history comes newest first, rows that are not
+// submissions keep their changes.
+func diffSubmissions[H any, P interface {
+	*H
+	approvalSubmission
+}](history []H) error {
+	var previous map[string]any
+	for i := len(history) - 1; i >= 0; i-- {
+		h := P(&history[i])
+		snapshot := h.GetSnapshot()
+		if !h.IsSubmission() || snapshot == nil {
+			continue
+		}
+		if previous != nil {
+			changes, err := model.Diff(previous, snapshot)
+			if err != nil {
+				return fmt.Errorf("failed to diff submissions: %w", err)
+			}
+			h.SetChanges(changes)
+		}
+		previous = snapshot
+	}
+	return nil
 }
 
 func (s *CompanyService) CountPendingApprovals(ctx context.Context) (int, error) {
@@ -225,33 +512,58 @@ func (s *CompanyService) CountPendingApprovals(ctx context.Context) (int, error)
 
 //follow methids
 
-func (s *CompanyService) Follow(ctx context.Context, companID, userID uuid.UUID) error {
+// Follow follows the company, or sends a follow request when the company
+// approves its followers.
+func (s *CompanyService) Follow(ctx context.Context, companID, userID uuid.UUID) (*company.CompanyFollower, error) {
 	comp, err := s.companyRepo.GetByID(ctx, companID)
 	if err != nil {
-		return fmt.Errorf("company not found: %w", err)
+		return nil, fmt.Errorf("company not found: %w", err)
 	}
 
 	if !comp.CanBeFollowed() {
-		return fmt.Errorf("cannot follow compnay with status:%s", comp.ApprovalStatus)
+		return nil, fmt.Errorf("cannot follow compnay with status:%s", comp.ApprovalStatus)
 	}
 	if !comp.IsActive {
-		return errors.New("connot follow inactive company")
+		return nil, errors.New("connot follow inactive company")
+	}
+
+	if comp.IsHidden() {
+		return nil, errors.New("cannot follow company under review")
 	}
 
 	if comp.OwnerID == userID {
-		return errors.New("connot follow own company")
+		return nil, errors.New("connot follow own company")
 	}
 
-	_, err = s.companyFollowerRepo.Follow(ctx, companID, userID)
+	blocked, err := s.companyFollowerRepo.IsBlocked(ctx, companID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to follow company: %w", err)
+		return nil, err
+	}
+	if blocked {
+		return nil, errors.New("cannot follow this company")
 	}
 
-	return nil
+	status := company.FollowStatusAccepted
+	if comp.ApproveFollowers {
+		status = company.FollowStatusPending
+	}
+
+	follower, err := s.companyFollowerRepo.Follow(ctx, companID, userID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow company: %w", err)
+	}
+
+	s.audit.Record(ctx, userID, audit.ActionFollow, audit.EntityCompany, companID, nil, follower)
+	return follower, nil
 }
 
+// Unfollow also withdraws a pending follow request.
 func (s *CompanyService) Unfollow(ctx context.Context, companID, userID uuid.UUID) error {
-	return s.companyFollowerRepo.Unfollow(ctx, companID, userID)
+	if err := s.companyFollowerRepo.Unfollow(ctx, companID, userID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUnfollow, audit.EntityCompany, companID, nil, nil)
+	return nil
 }
 
 func (s *CompanyService) GetFollowStatus(ctx context.Context, companyID uuid.UUID, userID uuid.UUID) (*company.FollowStatusResponse, error) {
@@ -263,17 +575,143 @@ func (s *CompanyService) GetFollowStatus(ctx context.Context, companyID uuid.UUI
 
 	resp := &company.FollowStatusResponse{
 		CompanyID:   companyID,
-		IsFollowing: follower != nil,
+		IsFollowing: follower != nil && follower.IsAccepted(),
+		IsPending:   follower != nil && !follower.IsAccepted(),
 	}
 
-	if follower != nil {
+	if resp.IsFollowing {
 		resp.FollowedAt = &follower.FollowedAt
 	}
 	return resp, nil
 }
 
 func (s *CompanyService) ListFollowers(ctx context.Context, companyID uuid.UUID, page, limit int) (*model.PaginatedResponse[company.CompanyFollowerResponse], error) {
-	return s.companyFollowerRepo.ListFollowers(ctx, companyID, page, limit)
+	return s.companyFollowerRepo.ListFollowers(ctx, companyID, company.FollowStatusAccepted, page, limit)
+}
+
+// =============================================
+// FOLLOWER MANAGEMENT (OWNER)
+// =============================================
+
+func (s *CompanyService) UpdateFollowerSettings(ctx context.Context, userID uuid.UUID, req *company.UpdateFollowerSettingsRequest) (*company.Company, error) {
+	existing, err := s.ownedCompany(ctx, userID, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.companyRepo.SetApproveFollowers(ctx, req.CompanyID, *req.ApproveFollowers)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityCompany, req.CompanyID,
+		map[string]bool{"approveFollowers": existing.ApproveFollowers},
+		map[string]bool{"approveFollowers": updated.ApproveFollowers},
+	)
+	return updated, nil
+}
+
+func (s *CompanyService) ListFollowRequests(ctx context.Context, userID, companyID uuid.UUID, page, limit int) (*model.PaginatedResponse[company.CompanyFollowerResponse], error) {
+	if _, err := s.ownedCompany(ctx, userID, companyID); err != nil {
+		return nil, err
+	}
+	return s.companyFollowerRepo.ListFollowers(ctx, companyID, company.FollowStatusPending, page, limit)
+}
+
+func (s *CompanyService) AcceptFollower(ctx context.Context, userID, companyID, followerID uuid.UUID) (*company.CompanyFollower, error) {
+	if _, err := s.ownedCompany(ctx, userID, companyID); err != nil {
+		return nil, err
+	}
+
+	follower, err := s.companyFollowerRepo.Accept(ctx, companyID, followerID)
+	if err != nil {
+		return nil, err
+	}
+	if follower == nil {
+		return nil, errors.New("no pending follow request from this user")
+	}
+
+	s.audit.Record(ctx, userID, audit.ActionApprove, audit.EntityCompany, companyID, nil, follower)
+	return follower, nil
+}
+
+func (s *CompanyService) RejectFollower(ctx context.Context, userID, companyID, followerID uuid.UUID) error {
+	return s.removeFollower(ctx, userID, companyID, followerID, company.FollowStatusPending, audit.ActionReject)
+}
+
+func (s *CompanyService) RemoveFollower(ctx context.Context, userID, companyID, followerID uuid.UUID) error {
+	return s.removeFollower(ctx, userID, companyID, followerID, company.FollowStatusAccepted, audit.ActionUnfollow)
+}
+
+func (s *CompanyService) removeFollower(ctx context.Context, userID, companyID, followerID uuid.UUID, status company.FollowStatus, action audit.Action) error {
+	if _, err := s.ownedCompany(ctx, userID, companyID); err != nil {
+		return err
+	}
+
+	removed, err := s.companyFollowerRepo.Remove(ctx, companyID, followerID, status)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		if status == company.FollowStatusPending {
+			return errors.New("no pending follow request from this user")
+		}
+		return errors.New("user is not following this company")
+	}
+
+	s.audit.Record(ctx, userID, action, audit.EntityCompany, companyID, map[string]any{"userId": followerID, "status": status}, nil)
+	return nil
+}
+
+// BlockUser removes the user's follow or request, keeps them from following
+// again and hides the company's non-public products from them.
+func (s *CompanyService) BlockUser(ctx context.Context, userID uuid.UUID, req *company.BlockUserRequest) error {
+	comp, err := s.ownedCompany(ctx, userID, req.CompanyID)
+	if err != nil {
+		return err
+	}
+	if req.UserID == comp.OwnerID {
+		return errors.New("cannot block the company owner")
+	}
+
+	if err := s.companyFollowerRepo.Block(ctx, req.CompanyID, req.UserID, userID, req.Reason); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, audit.ActionBlock, audit.EntityCompany, req.CompanyID, nil, map[string]any{"userId": req.UserID, "reason": req.Reason})
+	return nil
+}
+
+// UnblockUser lets the user follow again, a follow removed by the block is
+// not restored.
+func (s *CompanyService) UnblockUser(ctx context.Context, userID, companyID, blockedID uuid.UUID) error {
+	if _, err := s.ownedCompany(ctx, userID, companyID); err != nil {
+		return err
+	}
+
+	if err := s.companyFollowerRepo.Unblock(ctx, companyID, blockedID); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, audit.ActionUnblock, audit.EntityCompany, companyID, map[string]uuid.UUID{"userId": blockedID}, nil)
+	return nil
+}
+
+func (s *CompanyService) ListBlockedUsers(ctx context.Context, userID, companyID uuid.UUID, page, limit int) (*model.PaginatedResponse[company.BlockedUser], error) {
+	if _, err := s.ownedCompany(ctx, userID, companyID); err != nil {
+		return nil, err
+	}
+	return s.companyFollowerRepo.ListBlocked(ctx, companyID, page, limit)
+}
+
+func (s *CompanyService) ownedCompany(ctx context.Context, userID, companyID uuid.UUID) (*company.Company, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to manage followers of this company")
+	}
+	return comp, nil
 }
 
 func (s *CompanyService) ListFollowedCompanies(ctx context.Context, userID uuid.UUID, page, limit int) (*model.PaginatedResponse[company.CompanyResponse], error) {
@@ -294,23 +732,250 @@ func (s *CompanyService) CanViewProducts(ctx context.Context, companyID uuid.UUI
 	return s.companyFollowerRepo.CanViewProducts(ctx, companyID, userID)
 }
 
+// ListMine returns every company the user owns, whatever its approval state.
+func (s *CompanyService) ListMine(ctx context.Context, userID uuid.UUID) ([]company.CompanyResponse, error) {
+	companies, err := s.companyRepo.ListByOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]company.CompanyResponse, len(companies))
+	for i := range companies {
+		responses[i] = *company.ToCompanyResponse(&companies[i], nil)
+	}
+	return responses, nil
+}
+
 func (s *CompanyService) UserHasApprovedCompany(ctx context.Context, userID uuid.UUID) (bool, error) {
-	approvedCompany, err := s.companyRepo.GetApprovedCompanyByOwner(ctx, userID)
+	companies, err := s.companyRepo.ListByOwner(ctx, userID)
 	if err != nil {
 		return false, fmt.Errorf("failed to check approved company: %w", err)
 	}
 
-	return approvedCompany != nil, nil
+	for i := range companies {
+		if companies[i].CanCreateProducts() {
+			return true, nil
+		}
+	}
+	return false, nil
 }
+
+// GetUserApprovedCompany returns the approved company the request acts
+// for, see approvedCompanyFor.
 func (s *CompanyService) GetUserApprovedCompany(ctx context.Context, userID uuid.UUID) (*company.Company, error) {
-	approvedCompany, err := s.companyRepo.GetApprovedCompanyByOwner(ctx, userID)
+	return approvedCompanyFor(ctx, s.companyRepo, userID, nil)
+}
+
+// approvedCompanyFor picks the approved company a seller acts for: the one
+// asked for, else the request's active company or API key company, else
+// their only approved company. Owners of several have to choose.
+func approvedCompanyFor(ctx context.Context, companyRepo *repository.CompanyRepository, userID uuid.UUID, requested *uuid.UUID) (*company.Company, error) {
+	if requested == nil {
+		if id, ok := utils.ActiveCompanyFromContext(ctx); ok {
+			requested = &id
+		} else if id, ok := utils.APIKeyCompanyFromContext(ctx); ok {
+			requested = &id
+		}
+	}
+
+	companies, err := companyRepo.ListByOwner(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approved companies: %w", err)
+	}
+	approved := make([]company.Company, 0, len(companies))
+	for _, c := range companies {
+		if c.CanCreateProducts() {
+			approved = append(approved, c)
+		}
+	}
+
+	if len(approved) == 0 {
+		return nil, errors.New("you must have an approved company before creating products. Please create a company and wait for admin approval")
+	}
+	if requested == nil {
+		if len(approved) > 1 {
+			return nil, fmt.Errorf("you own several approved companies, choose one with companyId or the %s header", utils.ActiveCompanyHeader)
+		}
+		requested = &approved[0].ID
+	}
+
+	for i := range approved {
+		if approved[i].ID == *requested && canManageCompany(ctx, &approved[i], userID) {
+			return &approved[i], nil
+		}
+	}
+	return nil, errors.New("you can only act for your own approved companies")
+}
+
+// =============================================
+// TAX IDS (GSTIN / PAN)
+// =============================================
+
+func normalizeTaxIDs(c *company.Company) {
+	normalize := func(v *string) *string {
+		if v == nil {
+			return nil
+		}
+		n := utils.NormalizeTaxID(*v)
+		if n == "" {
+			return nil
+		}
+		return &n
+	}
+	c.GSTNumber = normalize(c.GSTNumber)
+	c.PANNumber = normalize(c.PANNumber)
+}
+
+// checkTaxIDs validates the GSTIN checksum and that it agrees with the
+// company's state and PAN. Missing values are not errors here.
+func checkTaxIDs(c *company.Company) company.TaxIDCheck {
+	check := company.TaxIDCheck{
+		GSTNumber: c.GSTNumber,
+		PANNumber: c.PANNumber,
+		Errors:    []string{},
+	}
+
+	if c.PANNumber != nil {
+		err := utils.ValidatePAN(*c.PANNumber)
+		valid := err == nil
+		check.PANValid = &valid
+		if err != nil {
+			check.Errors = append(check.Errors, err.Error())
+		}
+	}
+
+	if c.GSTNumber == nil {
+		return check
+	}
+
+	gstin := *c.GSTNumber
+	err := utils.ValidateGSTIN(gstin)
+	valid := err == nil
+	check.GSTINValid = &valid
+	if err != nil {
+		check.Errors = append(check.Errors, err.Error())
+		return check
+	}
+
+	if c.State != nil && strings.TrimSpace(*c.State) != "" {
+		matches, err := utils.GSTINMatchesState(gstin, *c.State)
+		if err != nil {
+			check.Errors = append(check.Errors, err.Error())
+		} else {
+			check.StateMatches = &matches
+			if !matches {
+				check.Errors = append(check.Errors, fmt.Sprintf("GSTIN state code %s does not match state %s", utils.GSTINStateCode(gstin), *c.State))
+			}
+		}
+	}
+
+	if c.PANNumber != nil {
+		matches := utils.GSTINPAN(gstin) == *c.PANNumber
+		check.PANMatches = &matches
+		if !matches {
+			check.Errors = append(check.Errors, "PAN embedded in GSTIN does not match PAN number")
+		}
+	}
+
+	return check
+}
+
+// =============================================
+// SERVICE AREAS
+// =============================================
+
+// ListServiceAreas is public, buyers use it to see where a seller delivers.
+func (s *CompanyService) ListServiceAreas(ctx context.Context, companyID uuid.UUID) ([]company.ServiceArea, error) {
+	if _, err := s.companyRepo.GetByID(ctx, companyID); err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	return s.serviceAreaRepo.ListByCompany(ctx, companyID)
+}
+
+// UpdateServiceAreas replaces the company's service areas. Duplicates are
+// dropped and states are stored as their GST state code.
+func (s *CompanyService) UpdateServiceAreas(ctx context.Context, userID uuid.UUID, req *company.UpdateServiceAreasRequest) ([]company.ServiceArea, error) {
+	comp, err := s.ownedCompany(ctx, userID, req.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.serviceAreaRepo.ListByCompany(ctx, comp.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	areas := make([]company.ServiceArea, 0, len(req.Areas))
+	seen := make(map[string]bool, len(req.Areas))
+	for _, in := range req.Areas {
+		area, err := normalizeServiceArea(in)
+		if err != nil {
+			return nil, err
+		}
+		key := string(area.Type) + ":" + area.Value
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		areas = append(areas, area)
+	}
+
+	if err := s.serviceAreaRepo.Replace(ctx, comp.ID, areas); err != nil {
+		return nil, err
+	}
+	after, err := s.serviceAreaRepo.ListByCompany(ctx, comp.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get approved company: %w", err)
+		return nil, err
 	}
 
-	if approvedCompany == nil {
-		return nil, errors.New("you don't have an approved company. Please create and get approval first")
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityCompany, comp.ID,
+		map[string]any{"serviceAreas": before},
+		map[string]any{"serviceAreas": after},
+	)
+	return after, nil
+}
+
+func normalizeServiceArea(in company.ServiceAreaInput) (company.ServiceArea, error) {
+	value := strings.TrimSpace(in.Value)
+	area := company.ServiceArea{Type: in.Type, Value: value}
+
+	switch in.Type {
+	case company.ServiceAreaPincode:
+		if err := utils.ValidatePincode(value); err != nil {
+			return area, err
+		}
+	case company.ServiceAreaPincodePrefix:
+		if err := utils.ValidatePincodePrefix(value); err != nil {
+			return area, err
+		}
+	case company.ServiceAreaState:
+		code := value
+		if !utils.IsGSTStateCode(code) {
+			var ok bool
+			if code, ok = utils.GSTStateCode(value); !ok {
+				return area, fmt.Errorf("unknown state: %s", value)
+			}
+		}
+		area.Value = code
+		area.Label = &value
+	default:
+		return area, fmt.Errorf("unknown service area type: %s", in.Type)
 	}
+	return area, nil
+}
 
-	return approvedCompany, nil
+// newServiceAreaLookup lists the area values a pincode matches. The state
+// is left out when the pincode cannot be placed in one.
+func newServiceAreaLookup(pincode string) (*company.ServiceAreaLookup, error) {
+	if err := utils.ValidatePincode(pincode); err != nil {
+		return nil, err
+	}
+	lookup := &company.ServiceAreaLookup{
+		Pincode:  pincode,
+		Prefixes: utils.PincodePrefixes(pincode),
+	}
+	if code, ok := utils.PincodeStateCode(pincode); ok {
+		lookup.StateCode = &code
+	}
+	return lookup, nil
 }
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/C0deNe0/agromart/internal/lib/aws"
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/audit"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const companyImageUploadURLExpiry = 900
+
+var companyImageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// CompanyImageService handles logo and banner uploads. The client puts the
+// file in S3 with a presigned URL and then confirms it, the server reads the
+// object back to check its real type and dimensions before using it.
+type CompanyImageService struct {
+	companyRepo *repository.CompanyRepository
+	imageRepo   *repository.CompanyImageRepository
+	S3Service   *aws.S3Service
+	audit       *AuditService
+	log         *zerolog.Logger
+}
+
+func NewCompanyImageService(companyRepo *repository.CompanyRepository, imageRepo *repository.CompanyImageRepository, s3 *aws.S3Service, audit *AuditService, log *zerolog.Logger) *CompanyImageService {
+	return &CompanyImageService{
+		companyRepo: companyRepo,
+		imageRepo:   imageRepo,
+		S3Service:   s3,
+		audit:       audit,
+		log:         log,
+	}
+}
+
+func (s *CompanyImageService) GenerateUploadURL(ctx context.Context, userID uuid.UUID, req *company.GenerateCompanyImageUploadURLRequest) (*company.CompanyImageUploadURLResponse, error) {
+	comp, err := s.companyRepo.GetByID(ctx, req.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to upload images for this company")
+	}
+
+	// one upload in flight per kind, an unconfirmed earlier one is dropped
+	stale, err := s.imageRepo.ListByCompany(ctx, comp.ID, &req.Kind, []company.ImageStatus{company.ImageStatusUploading})
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range stale {
+		s.discard(ctx, &img)
+	}
+
+	imageID := uuid.New()
+	s3Key := fmt.Sprintf("companies/%s/%s/%s%s",
+		comp.ID.String(),
+		strings.ToLower(string(req.Kind)),
+		imageID.String(),
+		companyImageExtensions[req.ContentType],
+	)
+
+	uploadURL, err := s.S3Service.GeneratePresignedUploadURL(ctx, s3Key, req.ContentType, companyImageUploadURLExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.imageRepo.Create(ctx, &company.CompanyImage{
+		ID:           imageID,
+		CompanyID:    comp.ID,
+		UploadedByID: userID,
+		Kind:         req.Kind,
+		S3Key:        s3Key,
+		ImageURL:     s.S3Service.GetPublicURL(s3Key),
+		ContentType:  req.ContentType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create image record: %w", err)
+	}
+
+	return &company.CompanyImageUploadURLResponse{
+		UploadURL: uploadURL,
+		ImageID:   imageID,
+		S3Key:     s3Key,
+		ExpiresIn: companyImageUploadURLExpiry,
+	}, nil
+}
+
+// Confirm checks the uploaded object and puts it on the company. A new logo
+// for an approved company waits for an admin instead, the old logo stays
+// up until then. A file that fails the checks is deleted.
+func (s *CompanyImageService) Confirm(ctx context.Context, userID uuid.UUID, req *company.ConfirmCompanyImageRequest) (*company.CompanyImage, error) {
+	comp, err := s.companyRepo.GetByID(ctx, req.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to upload images for this company")
+	}
+
+	img, err := s.imageRepo.GetByID(ctx, req.ImageID)
+	if err != nil {
+		return nil, fmt.Errorf("image not found: %w", err)
+	}
+	if img.CompanyID != comp.ID {
+		return nil, errors.New("image does not belong to this company")
+	}
+	if img.Status != company.ImageStatusUploading {
+		return nil, fmt.Errorf("image is already confirmed. Current status: %s", img.Status)
+	}
+
+	spec := company.ImageSpecs[img.Kind]
+	size, _, err := s.S3Service.HeadObject(ctx, img.S3Key)
+	if err != nil {
+		return nil, errors.New("image has not been uploaded yet")
+	}
+	if size > spec.MaxBytes {
+		s.discard(ctx, img)
+		return nil, fmt.Errorf("image must be at most %d KB", spec.MaxBytes>>10)
+	}
+
+	data, err := s.S3Service.GetObject(ctx, img.S3Key, spec.MaxBytes)
+	if err != nil {
+		return nil, err
+	}
+	contentType, width, height, err := utils.ImageInfo(data)
+	if err == nil {
+		err = checkImageSpec(spec, width, height)
+	}
+	if err == nil && contentType != img.ContentType {
+		err = fmt.Errorf("file is %s, expected %s", contentType, img.ContentType)
+	}
+	if err != nil {
+		s.discard(ctx, img)
+		return nil, err
+	}
+
+	img, err = s.imageRepo.SetUploaded(ctx, img.ID, size, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	var replaced []company.CompanyImage
+	if img.Kind == company.ImageKindLogo && comp.IsApproved() {
+		img, replaced, err = s.imageRepo.Queue(ctx, img)
+	} else {
+		img, replaced, err = s.imageRepo.Activate(ctx, img, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.removeObjects(ctx, replaced)
+
+	s.audit.Record(ctx, userID, audit.ActionCreate, audit.EntityCompanyImage, img.ID, nil, img)
+	return img, nil
+}
+
+// List shows the owner the current images, those waiting for review and
+// the rejected ones with their reason.
+func (s *CompanyImageService) List(ctx context.Context, userID uuid.UUID, companyID uuid.UUID) ([]company.CompanyImage, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to view images for this company")
+	}
+
+	return s.imageRepo.ListByCompany(ctx, companyID, nil, []company.ImageStatus{
+		company.ImageStatusActive,
+		company.ImageStatusPending,
+		company.ImageStatusRejected,
+	})
+}
+
+// =============================================
+// LOGO REVIEW
+// =============================================
+
+func (s *CompanyImageService) ListPending(ctx context.Context, page, limit int) (*model.PaginatedResponse[company.PendingCompanyImage], error) {
+	return s.imageRepo.ListPending(ctx, page, limit)
+}
+
+func (s *CompanyImageService) Approve(ctx context.Context, adminID, imageID uuid.UUID) error {
+	img, err := s.imageRepo.GetByID(ctx, imageID)
+	if err != nil {
+		return fmt.Errorf("image not found: %w", err)
+	}
+	if img.Status != company.ImageStatusPending {
+		return fmt.Errorf("only pending images can be approved. Current status: %s", img.Status)
+	}
+
+	active, replaced, err := s.imageRepo.Activate(ctx, img, &adminID)
+	if err != nil {
+		return err
+	}
+	s.removeObjects(ctx, replaced)
+
+	s.audit.Record(ctx, adminID, audit.ActionApprove, audit.EntityCompanyImage, imageID, img, active)
+	return nil
+}
+
+func (s *CompanyImageService) Reject(ctx context.Context, adminID, imageID uuid.UUID, reason string) error {
+	rejected, err := s.imageRepo.Reject(ctx, imageID, adminID, reason)
+	if err != nil {
+		return err
+	}
+	s.removeObjects(ctx, []company.CompanyImage{*rejected})
+
+	s.audit.Record(ctx, adminID, audit.ActionReject, audit.EntityCompanyImage, imageID, nil, rejected)
+	return nil
+}
+
+// discard removes an upload that never made it onto the company.
+func (s *CompanyImageService) discard(ctx context.Context, img *company.CompanyImage) {
+	s.removeObjects(ctx, []company.CompanyImage{*img})
+	if err := s.imageRepo.Delete(ctx, img.ID); err != nil && !errors.Is(err, repository.ErrNotFound) {
+		s.log.Error().Err(err).Str("imageId", img.ID.String()).Msg("failed to delete company image record")
+	}
+}
+
+// removeObjects is best effort, the database already moved on and a left
+// over object only costs storage.
+func (s *CompanyImageService) removeObjects(ctx context.Context, images []company.CompanyImage) {
+	for _, img := range images {
+		if err := s.S3Service.DeleteObject(ctx, img.S3Key); err != nil {
+			s.log.Error().Err(err).Str("imageId", img.ID.String()).Msg("failed to delete company image object")
+		}
+	}
+}
+
+func checkImageSpec(spec company.ImageSpec, width, height int) error {
+	if width < spec.MinWidth || height < spec.MinHeight {
+		return fmt.Errorf("image must be at least %dx%d pixels", spec.MinWidth, spec.MinHeight)
+	}
+	if width > spec.MaxWidth || height > spec.MaxHeight {
+		return fmt.Errorf("image must be at most %dx%d pixels", spec.MaxWidth, spec.MaxHeight)
+	}
+	aspect := float64(width) / float64(height)
+	if aspect < spec.MinAspect || aspect > spec.MaxAspect {
+		return fmt.Errorf("image width to height ratio must be between %.2g and %.2g", spec.MinAspect, spec.MaxAspect)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/C0deNe0/agromart/internal/lib/aws"
+	"github.com/C0deNe0/agromart/internal/model/audit"
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	kycUploadURLExpiry   = 900
+	kycDownloadURLExpiry = 300
+)
+
+// documents every company is expected to upload before review
+var requiredKYCDocuments = []company.KYCDocumentType{
+	company.KYCDocumentGSTCertificate,
+	company.KYCDocumentPANCard,
+}
+
+type CompanyKYCService struct {
+	companyRepo *repository.CompanyRepository
+	kycRepo     *repository.CompanyKYCRepository
+	S3Service   *aws.S3Service
+	audit       *AuditService
+}
+
+func NewCompanyKYCService(companyRepo *repository.CompanyRepository, kycRepo *repository.CompanyKYCRepository, s3 *aws.S3Service, audit *AuditService) *CompanyKYCService {
+	return &CompanyKYCService{
+		companyRepo: companyRepo,
+		kycRepo:     kycRepo,
+		S3Service:   s3,
+		audit:       audit,
+	}
+}
+
+func (s *CompanyKYCService) GenerateUploadURL(ctx context.Context, userID uuid.UUID, req *company.GenerateKYCUploadURLRequest) (*company.KYCUploadURLResponse, error) {
+	comp, err := s.companyRepo.GetByID(ctx, req.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if comp.OwnerID != userID {
+		return nil, errors.New("not authorized to upload documents for this company")
+	}
+	// documents are frozen while approved, same rule as the company itself
+	if !comp.CanBeModified() {
+		return nil, fmt.Errorf("cannot upload documents for company with status: %s", comp.ApprovalStatus)
+	}
+
+	documentID := uuid.New()
+	s3Key := fmt.Sprintf("kyc/companies/%s/%s/%s%s",
+		comp.ID.String(),
+		strings.ToLower(string(req.DocumentType)),
+		documentID.String(),
+		strings.ToLower(path.Ext(req.FileName)),
+	)
+
+	uploadURL, err := s.S3Service.GeneratePresignedUploadURL(ctx, s3Key, req.ContentType, kycUploadURLExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.kycRepo.Create(ctx, &company.KYCDocument{
+		ID:             documentID,
+		CompanyID:      comp.ID,
+		UploadedByID:   userID,
+		DocumentType:   req.DocumentType,
+		DocumentNumber: req.DocumentNumber,
+		S3Key:          s3Key,
+		FileName:       path.Base(req.FileName),
+		ContentType:    req.ContentType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document record: %w", err)
+	}
+
+	return &company.KYCUploadURLResponse{
+		UploadURL:  uploadURL,
+		DocumentID: documentID,
+		S3Key:      s3Key,
+		ExpiresIn:  kycUploadURLExpiry,
+	}, nil
+}
+
+// Confirm checks that the file reached S3. Until then the document is
+// not listed and does not count towards the required ones. A file over the
+// size limit is deleted.
+func (s *CompanyKYCService) Confirm(ctx context.Context, userID uuid.UUID, req *company.ConfirmKYCDocumentRequest) (*company.KYCDocument, error) {
+	comp, err := s.companyRepo.GetByID(ctx, req.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if comp.OwnerID != userID {
+		return nil, errors.New("not authorized to upload documents for this company")
+	}
+	if !comp.CanBeModified() {
+		return nil, fmt.Errorf("cannot upload documents for company with status: %s", comp.ApprovalStatus)
+	}
+
+	doc, err := s.kycRepo.GetByID(ctx, req.DocumentID)
+	if err != nil {
+		return nil, fmt.Errorf("document not found: %w", err)
+	}
+	if doc.CompanyID != comp.ID {
+		return nil, errors.New("document does not belong to this company")
+	}
+	if doc.Status != company.KYCDocumentStatusUploading {
+		return nil, errors.New("document is already confirmed")
+	}
+
+	size, _, err := s.S3Service.HeadObject(ctx, doc.S3Key)
+	if err != nil {
+		return nil, errors.New("document has not been uploaded yet")
+	}
+	if size > company.MaxKYCDocumentBytes {
+		// best effort, the upload is refused either way
+		_ = s.S3Service.DeleteObject(ctx, doc.S3Key)
+		_ = s.kycRepo.Delete(ctx, doc.ID)
+		return nil, fmt.Errorf("document must be at most %d MB", company.MaxKYCDocumentBytes>>20)
+	}
+
+	doc, err = s.kycRepo.SetUploaded(ctx, doc.ID, size)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionCreate, audit.EntityCompanyKYC, doc.ID, nil, doc)
+	return doc, nil
+}
+
+// List is available to the company owner and to admins.
+func (s *CompanyKYCService) List(ctx context.Context, userID uuid.UUID, isAdmin bool, companyID uuid.UUID) ([]company.KYCDocumentResponse, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !isAdmin && comp.OwnerID != userID {
+		return nil, errors.New("not authorized to view documents for this company")
+	}
+
+	return s.listWithURLs(ctx, companyID)
+}
+
+func (s *CompanyKYCService) Delete(ctx context.Context, userID uuid.UUID, companyID, documentID uuid.UUID) error {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return fmt.Errorf("company not found: %w", err)
+	}
+	if comp.OwnerID != userID {
+		return errors.New("not authorized to delete documents for this company")
+	}
+	if !comp.CanBeModified() {
+		return fmt.Errorf("cannot delete documents for company with status: %s", comp.ApprovalStatus)
+	}
+
+	doc, err := s.kycRepo.GetByID(ctx, documentID)
+	if err != nil {
+		return fmt.Errorf("document not found: %w", err)
+	}
+	if doc.CompanyID != companyID {
+		return errors.New("document does not belong to this company")
+	}
+
+	if err := s.S3Service.DeleteObject(ctx, doc.S3Key); err != nil {
+		return fmt.Errorf("failed to delete from S3: %w", err)
+	}
+
+	if err := s.kycRepo.Delete(ctx, documentID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionDelete, audit.EntityCompanyKYC, documentID, doc, nil)
+	return nil
+}
+
+// GetReview builds the admin review payload shown before ApproveCompany.
+func (s *CompanyKYCService) GetReview(ctx context.Context, companyID uuid.UUID) (*company.CompanyReviewResponse, error) {
+	comp, err := s.companyRepo.GetByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+
+	docs, err := s.listWithURLs(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.companyRepo.GetApprovalHistory(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load approval history: %w", err)
+	}
+	if err := diffSubmissions(history); err != nil {
+		return nil, err
+	}
+
+	uploaded := make(map[company.KYCDocumentType]bool, len(docs))
+	for _, d := range docs {
+		uploaded[d.DocumentType] = true
+	}
+	missing := []company.KYCDocumentType{}
+	for _, t := range requiredKYCDocuments {
+		if !uploaded[t] {
+			missing = append(missing, t)
+		}
+	}
+
+	return &company.CompanyReviewResponse{
+		Company:         *company.ToCompanyResponse(comp, nil),
+		ApprovalStatus:  comp.ApprovalStatus,
+		RejectionReason: comp.RejectionReason,
+		TaxIDs:          checkTaxIDs(comp),
+		KYCDocuments:    docs,
+		MissingKYC:      missing,
+		History:         history,
+	}, nil
+}
+
+func (s *CompanyKYCService) listWithURLs(ctx context.Context, companyID uuid.UUID) ([]company.KYCDocumentResponse, error) {
+	docs, err := s.kycRepo.ListByCompany(ctx, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]company.KYCDocumentResponse, 0, len(docs))
+	for _, d := range docs {
+		url, err := s.S3Service.GeneratePresignedDownloadURL(ctx, d.S3Key, kycDownloadURLExpiry)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, company.KYCDocumentResponse{
+			KYCDocument: d,
+			DownloadURL: url,
+		})
+	}
+	return resp, nil
+}
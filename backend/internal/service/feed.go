@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/C0deNe0/agromart/internal/model/feed"
+	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/C0deNe0/agromart/internal/repository/productRepo"
+	"github.com/google/uuid"
+)
+
+type FeedService struct {
+	feedRepo           *repository.FeedRepository
+	productRepo        *productRepo.ProductRepository
+	productImageRepo   *productRepo.ProductImageRepository
+	productVariantRepo *productRepo.ProductVariantRepository
+	companyRepo        *repository.CompanyRepository
+}
+
+func NewFeedService(feedRepo *repository.FeedRepository, productRepo *productRepo.ProductRepository, productImageRepo *productRepo.ProductImageRepository, productVariantRepo *productRepo.ProductVariantRepository, companyRepo *repository.CompanyRepository) *FeedService {
+	return &FeedService{
+		feedRepo:           feedRepo,
+		productRepo:        productRepo,
+		productImageRepo:   productImageRepo,
+		productVariantRepo: productVariantRepo,
+		companyRepo:        companyRepo,
+	}
+}
+
+// Get returns one page of the user's feed with the current state of each
+// product attached.
+func (s *FeedService) Get(ctx context.Context, userID uuid.UUID, req *feed.GetFeedRequest) (*feed.Response, error) {
+	var cursor *feed.Cursor
+	if req.Cursor != "" {
+		c, err := feed.DecodeCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = c
+	}
+
+	// one extra row tells whether there is a next page
+	events, err := s.feedRepo.List(ctx, userID, cursor, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &feed.Response{Data: []feed.Item{}}
+	if len(events) > req.Limit {
+		events = events[:req.Limit]
+		last := events[len(events)-1]
+		resp.NextCursor = feed.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	if len(events) == 0 {
+		return resp, nil
+	}
+
+	productIDs := make([]uuid.UUID, len(events))
+	for i, e := range events {
+		productIDs[i] = e.ProductID
+	}
+	products, err := s.productRepo.ListByIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	images, err := s.productImageRepo.ListByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	variants, err := s.productVariantRepo.ListByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variants: %w", err)
+	}
+
+	var responses []*product.ProductResponse
+	for _, e := range events {
+		item := feed.Item{Event: e}
+		if p, ok := products[e.ProductID]; ok {
+			productImages := images[p.ID]
+			if productImages == nil {
+				productImages = []product.ProductImage{}
+			}
+			productVariants := variants[p.ID]
+			if productVariants == nil {
+				productVariants = []product.ProductVariant{}
+			}
+			item.Product = product.ToProductResponse(&p, productImages, productVariants)
+			responses = append(responses, item.Product)
+		}
+		resp.Data = append(resp.Data, item)
+	}
+	if err := applyVacations(ctx, s.companyRepo, responses...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
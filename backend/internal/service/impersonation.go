@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/auth"
+	"github.com/C0deNe0/agromart/internal/model/user"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultImpersonationTTL = 15 * time.Minute
+	MaxImpersonationTTL     = 30 * time.Minute
+)
+
+type ImpersonationService struct {
+	impersonationRepo *repository.ImpersonationRepository
+	userRepo          *repository.UserRepository
+	tokenManager      *utils.TokenManager
+}
+
+func NewImpersonationService(
+	impersonationRepo *repository.ImpersonationRepository,
+	userRepo *repository.UserRepository,
+	tokenManager *utils.TokenManager,
+) *ImpersonationService {
+	return &ImpersonationService{
+		impersonationRepo: impersonationRepo,
+		userRepo:          userRepo,
+		tokenManager:      tokenManager,
+	}
+}
+
+type StartImpersonationInput struct {
+	AdminID      uuid.UUID
+	TargetUserID uuid.UUID
+	Reason       string
+	AllowWrite   bool
+	TTL          time.Duration
+	IPAddress    string
+	UserAgent    string
+}
+
+// Start opens an audited session and returns the access token for it.
+func (s *ImpersonationService) Start(ctx context.Context, in StartImpersonationInput) (*auth.ImpersonationResponse, error) {
+	if in.AdminID == in.TargetUserID {
+		return nil, errors.New("cannot impersonate yourself")
+	}
+
+	target, err := s.userRepo.GetByID(ctx, in.TargetUserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if !target.IsActive {
+		return nil, errors.New("cannot impersonate an inactive user")
+	}
+	// never hand out admin powers through an impersonation token
+	if target.Role == user.RoleAdmin {
+		return nil, errors.New("cannot impersonate another admin")
+	}
+
+	ttl := in.TTL
+	if ttl <= 0 {
+		ttl = DefaultImpersonationTTL
+	}
+	if ttl > MaxImpersonationTTL {
+		ttl = MaxImpersonationTTL
+	}
+
+	session := &auth.ImpersonationSession{
+		AdminID:      in.AdminID,
+		TargetUserID: target.ID,
+		Reason:       in.Reason,
+		ReadOnly:     !in.AllowWrite,
+		ExpiresAt:    time.Now().Add(ttl),
+	}
+	if in.IPAddress != "" {
+		session.IPAddress = &in.IPAddress
+	}
+	if in.UserAgent != "" {
+		session.UserAgent = &in.UserAgent
+	}
+
+	created, err := s.impersonationRepo.Create(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.tokenManager.GenerateImpersonationToken(
+		target.ID,
+		string(target.Role),
+		utils.ActorClaim{
+			Sub:       in.AdminID,
+			SessionID: created.ID,
+			ReadOnly:  created.ReadOnly,
+		},
+		created.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	return &auth.ImpersonationResponse{
+		Session:     *created,
+		AccessToken: token,
+		ExpiresAt:   created.ExpiresAt,
+	}, nil
+}
+
+// Stop ends a session, endedBy is the admin (or the impersonating admin itself).
+func (s *ImpersonationService) Stop(ctx context.Context, sessionID uuid.UUID, endedBy uuid.UUID) (*auth.ImpersonationSession, error) {
+	ended, err := s.impersonationRepo.End(ctx, sessionID, endedBy)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, errors.New("impersonation session not found or already ended")
+		}
+		return nil, err
+	}
+	return ended, nil
+}
+
+// StopByToken lets the impersonation token end its own session, even when read-only.
+func (s *ImpersonationService) StopByToken(ctx context.Context, rawToken string) (*auth.ImpersonationSession, error) {
+	claims, err := s.tokenManager.ParseAccessToken(rawToken)
+	if err != nil {
+		return nil, errors.New("invalid token")
+	}
+	if claims.Act == nil {
+		return nil, errors.New("token is not an impersonation token")
+	}
+
+	return s.Stop(ctx, claims.Act.SessionID, claims.Act.Sub)
+}
+
+func (s *ImpersonationService) IsImpersonationActive(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	return s.impersonationRepo.IsActive(ctx, sessionID)
+}
+
+func (s *ImpersonationService) List(ctx context.Context, filter repository.ImpersonationFilter) (*model.PaginatedResponse[auth.ImpersonationSession], error) {
+	result, err := s.impersonationRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list impersonation sessions: %w", err)
+	}
+	return result, nil
+}
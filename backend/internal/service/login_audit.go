@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/C0deNe0/agromart/internal/lib/notify"
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/auth"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type LoginAlertKind string
+
+const (
+	LoginAlertNewDevice   LoginAlertKind = "NEW_DEVICE"
+	LoginAlertNewLocation LoginAlertKind = "NEW_LOCATION"
+)
+
+type LoginAlert struct {
+	Kind    LoginAlertKind
+	Message string
+}
+
+// LoginRule inspects a successful login before it is stored and returns an
+// alert when something looks unusual. Rules are only run for users that
+// already have a login history.
+type LoginRule interface {
+	Evaluate(ctx context.Context, event *auth.LoginEvent) (*LoginAlert, error)
+}
+
+// =============================================
+// RULES
+// =============================================
+
+type NewDeviceRule struct {
+	events *repository.LoginEventRepository
+}
+
+func NewNewDeviceRule(events *repository.LoginEventRepository) *NewDeviceRule {
+	return &NewDeviceRule{events: events}
+}
+
+func (r *NewDeviceRule) Evaluate(ctx context.Context, e *auth.LoginEvent) (*LoginAlert, error) {
+	if e.DeviceFingerprint == nil {
+		return nil, nil
+	}
+	seen, err := r.events.HasSeenDevice(ctx, *e.UserID, *e.DeviceFingerprint)
+	if err != nil || seen {
+		return nil, err
+	}
+	return &LoginAlert{
+		Kind:    LoginAlertNewDevice,
+		Message: "New sign-in from a device we have not seen before",
+	}, nil
+}
+
+type NewLocationRule struct {
+	events *repository.LoginEventRepository
+}
+
+func NewNewLocationRule(events *repository.LoginEventRepository) *NewLocationRule {
+	return &NewLocationRule{events: events}
+}
+
+func (r *NewLocationRule) Evaluate(ctx context.Context, e *auth.LoginEvent) (*LoginAlert, error) {
+	// no geo data from the CDN, nothing to compare
+	if e.Country == nil {
+		return nil, nil
+	}
+	region := ""
+	if e.Region != nil {
+		region = *e.Region
+	}
+	seen, err := r.events.HasSeenLocation(ctx, *e.UserID, *e.Country, region)
+	if err != nil || seen {
+		return nil, err
+	}
+	where := *e.Country
+	if region != "" {
+		where = region + ", " + where
+	}
+	return &LoginAlert{
+		Kind:    LoginAlertNewLocation,
+		Message: "New sign-in from " + where,
+	}, nil
+}
+
+// =============================================
+// LOGIN AUDIT SERVICE
+// =============================================
+
+// LoginAuditService writes the login trail. Recording is best effort, a
+// failing insert or notifier never blocks the login itself.
+// Successful logins also keep users.last_login_at current.
+type LoginAuditService struct {
+	loginEventRepo *repository.LoginEventRepository
+	userRepo       *repository.UserRepository
+	rules          []LoginRule
+	notifier       notify.Notifier
+	log            *zerolog.Logger
+}
+
+func NewLoginAuditService(
+	loginEventRepo *repository.LoginEventRepository,
+	userRepo *repository.UserRepository,
+	notifier notify.Notifier,
+	log *zerolog.Logger,
+	rules ...LoginRule,
+) *LoginAuditService {
+	return &LoginAuditService{
+		loginEventRepo: loginEventRepo,
+		userRepo:       userRepo,
+		rules:          rules,
+		notifier:       notifier,
+		log:            log,
+	}
+}
+
+func (s *LoginAuditService) RecordSuccess(ctx context.Context, userID uuid.UUID, email, provider string) {
+	if err := s.userRepo.UpdateLastLogin(ctx, userID); err != nil {
+		s.log.Error().Err(err).Str("userId", userID.String()).Msg("failed to update last login")
+	}
+
+	event := newLoginEvent(ctx, &userID, email, provider)
+	event.Success = true
+
+	alerts := s.evaluate(ctx, event)
+	for _, alert := range alerts {
+		switch alert.Kind {
+		case LoginAlertNewDevice:
+			event.IsNewDevice = true
+		case LoginAlertNewLocation:
+			event.IsNewLocation = true
+		}
+	}
+
+	if _, err := s.loginEventRepo.Create(ctx, event); err != nil {
+		s.log.Error().Err(err).Str("userId", userID.String()).Msg("failed to record login event")
+	}
+
+	for _, alert := range alerts {
+		s.notify(ctx, event, alert)
+	}
+}
+
+func (s *LoginAuditService) RecordFailure(ctx context.Context, userID *uuid.UUID, email, provider, reason string) {
+	event := newLoginEvent(ctx, userID, email, provider)
+	event.Success = false
+	event.FailureReason = &reason
+
+	if _, err := s.loginEventRepo.Create(ctx, event); err != nil {
+		s.log.Error().Err(err).Str("email", email).Msg("failed to record login event")
+	}
+}
+
+func (s *LoginAuditService) List(ctx context.Context, filter repository.LoginEventFilter) (*model.PaginatedResponse[auth.LoginEvent], error) {
+	result, err := s.loginEventRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login events: %w", err)
+	}
+	return result, nil
+}
+
+func (s *LoginAuditService) evaluate(ctx context.Context, event *auth.LoginEvent) []LoginAlert {
+	// the very first login is always "new", don't alert on it
+	count, err := s.loginEventRepo.CountSuccessful(ctx, *event.UserID)
+	if err != nil {
+		s.log.Error().Err(err).Msg("failed to load login history")
+		return nil
+	}
+	if count == 0 {
+		return nil
+	}
+
+	var alerts []LoginAlert
+	for _, rule := range s.rules {
+		alert, err := rule.Evaluate(ctx, event)
+		if err != nil {
+			s.log.Error().Err(err).Msg("login rule failed")
+			continue
+		}
+		if alert != nil {
+			alerts = append(alerts, *alert)
+		}
+	}
+	return alerts
+}
+
+func (s *LoginAuditService) notify(ctx context.Context, event *auth.LoginEvent, alert LoginAlert) {
+	data := map[string]string{
+		"provider": event.AuthProvider,
+	}
+	if event.IPAddress != nil {
+		data["ip"] = *event.IPAddress
+	}
+	if event.UserAgent != nil {
+		data["userAgent"] = *event.UserAgent
+	}
+
+	err := s.notifier.Notify(ctx, notify.Notification{
+		UserID: *event.UserID,
+		Kind:   "SUSPICIOUS_LOGIN_" + string(alert.Kind),
+		Title:  "New sign-in to your AgroMart account",
+		Body:   alert.Message + ". If this wasn't you, change your password and sign out of other devices.",
+		Data:   data,
+	})
+	if err != nil {
+		s.log.Error().Err(err).Str("userId", event.UserID.String()).Msg("failed to send login alert")
+	}
+}
+
+func newLoginEvent(ctx context.Context, userID *uuid.UUID, email, provider string) *auth.LoginEvent {
+	info := utils.ClientInfoFromContext(ctx)
+	return &auth.LoginEvent{
+		UserID:            userID,
+		Email:             optionalString(strings.ToLower(email)),
+		AuthProvider:      provider,
+		IPAddress:         optionalString(info.IPAddress),
+		UserAgent:         optionalString(info.UserAgent),
+		DeviceFingerprint: optionalString(info.DeviceFingerprint()),
+		Country:           optionalString(strings.ToUpper(info.Country)),
+		Region:            optionalString(strings.ToUpper(info.Region)),
+	}
+}
+
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/moderation"
+	"github.com/C0deNe0/agromart/internal/model/user"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/C0deNe0/agromart/internal/repository/productRepo"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultClaimTTL = 30 * time.Minute
+	MaxClaimTTL     = 4 * time.Hour
+
+	// items waiting longer than this are reported as breaching the SLA
+	ModerationSLA = 48 * time.Hour
+)
+
+type ModerationService struct {
+	moderationRepo *repository.ModerationRepository
+	companyRepo    *repository.CompanyRepository
+	productRepo    *productRepo.ProductRepository
+	userRepo       *repository.UserRepository
+}
+
+func NewModerationService(
+	moderationRepo *repository.ModerationRepository,
+	companyRepo *repository.CompanyRepository,
+	productRepo *productRepo.ProductRepository,
+	userRepo *repository.UserRepository,
+) *ModerationService {
+	return &ModerationService{
+		moderationRepo: moderationRepo,
+		companyRepo:    companyRepo,
+		productRepo:    productRepo,
+		userRepo:       userRepo,
+	}
+}
+
+func (s *ModerationService) ListCompanyQueue(ctx context.Context, filter repository.ModerationQueueFilter) (*moderation.QueueResponse[moderation.CompanyQueueItem], error) {
+	filter.SLACutoff = time.Now().Add(-ModerationSLA)
+
+	result, err := s.moderationRepo.ListCompanyQueue(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list company queue: %w", err)
+	}
+	fillSLA(&result.SLA)
+	return result, nil
+}
+
+func (s *ModerationService) ListProductQueue(ctx context.Context, filter repository.ModerationQueueFilter) (*moderation.QueueResponse[moderation.ProductQueueItem], error) {
+	filter.SLACutoff = time.Now().Add(-ModerationSLA)
+
+	result, err := s.moderationRepo.ListProductQueue(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product queue: %w", err)
+	}
+	fillSLA(&result.SLA)
+	return result, nil
+}
+
+func fillSLA(sla *moderation.QueueSLA) {
+	sla.TargetHours = int(ModerationSLA.Hours())
+	if sla.OldestSubmittedAt != nil {
+		sla.OldestWaitingMinutes = int(time.Since(*sla.OldestSubmittedAt).Minutes())
+	}
+}
+
+// Claim locks a pending item for the reviewer. Claiming an item you already
+// hold just extends the lock.
+func (s *ModerationService) Claim(ctx context.Context, entityType moderation.EntityType, entityID, reviewerID uuid.UUID, ttl time.Duration) (*moderation.Claim, error) {
+	if err := s.ensurePending(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	claim, err := s.moderationRepo.Claim(ctx, &moderation.Claim{
+		EntityType: entityType,
+		EntityID:   entityID,
+		ReviewerID: reviewerID,
+		ExpiresAt:  time.Now().Add(claimTTL(ttl)),
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, s.claimedError(ctx, entityType, entityID)
+		}
+		return nil, err
+	}
+	return claim, nil
+}
+
+// Assign hands a pending item to another admin, taking it away from
+// whoever holds it at the moment.
+func (s *ModerationService) Assign(ctx context.Context, entityType moderation.EntityType, entityID, reviewerID, assignedByID uuid.UUID, ttl time.Duration) (*moderation.Claim, error) {
+	if err := s.ensurePending(ctx, entityType, entityID); err != nil {
+		return nil, err
+	}
+
+	reviewer, err := s.userRepo.GetByID(ctx, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("reviewer not found: %w", err)
+	}
+	if reviewer.Role != user.RoleAdmin || !reviewer.IsActive {
+		return nil, errors.New("items can only be assigned to active admins")
+	}
+
+	return s.moderationRepo.Assign(ctx, &moderation.Claim{
+		EntityType:   entityType,
+		EntityID:     entityID,
+		ReviewerID:   reviewerID,
+		AssignedByID: &assignedByID,
+		ExpiresAt:    time.Now().Add(claimTTL(ttl)),
+	})
+}
+
+func (s *ModerationService) Release(ctx context.Context, entityType moderation.EntityType, entityID, reviewerID uuid.UUID) error {
+	err := s.moderationRepo.Release(ctx, entityType, entityID, reviewerID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return errors.New("you do not hold a claim on this item")
+	}
+	return err
+}
+
+func (s *ModerationService) ensurePending(ctx context.Context, entityType moderation.EntityType, entityID uuid.UUID) error {
+	switch entityType {
+	case moderation.EntityCompany:
+		c, err := s.companyRepo.GetByID(ctx, entityID)
+		if err != nil {
+			return fmt.Errorf("company not found: %w", err)
+		}
+		if !c.IsPending() || !c.IsActive {
+			return fmt.Errorf("only pending companies can be reviewed. Current status: %s", c.ApprovalStatus)
+		}
+	case moderation.EntityProduct:
+		p, err := s.productRepo.GetByID(ctx, entityID)
+		if err != nil {
+			return fmt.Errorf("product not found: %w", err)
+		}
+		if !p.IsPending() || !p.IsActive {
+			return fmt.Errorf("only pending products can be reviewed. Current status: %s", p.ApprovalStatus)
+		}
+	default:
+		return fmt.Errorf("unknown moderation entity: %s", entityType)
+	}
+	return nil
+}
+
+func (s *ModerationService) claimedError(ctx context.Context, entityType moderation.EntityType, entityID uuid.UUID) error {
+	claim, err := s.moderationRepo.GetActiveClaim(ctx, entityType, entityID)
+	if err != nil {
+		return errors.New("item is already claimed by another reviewer")
+	}
+	return fmt.Errorf("item is already claimed by reviewer %s until %s", claim.ReviewerID, claim.ExpiresAt.Format(time.RFC3339))
+}
+
+func claimTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return DefaultClaimTTL
+	}
+	if ttl > MaxClaimTTL {
+		return MaxClaimTTL
+	}
+	return ttl
+}
+
+// ensureReviewer is checked before approve/reject: an item claimed by
+// another admin cannot be decided until the claim is released or expires.
+func ensureReviewer(ctx context.Context, moderationRepo *repository.ModerationRepository, entityType moderation.EntityType, entityID, adminID uuid.UUID) error {
+	claim, err := moderationRepo.GetActiveClaim(ctx, entityType, entityID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if claim.ReviewerID != adminID {
+		return fmt.Errorf("item is claimed by reviewer %s until %s", claim.ReviewerID, claim.ExpiresAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// bulkResult reports every id in request order, failed holds the reason for
+// the ones that were not processed.
+func bulkResult(ids []uuid.UUID, failed map[uuid.UUID]error) *moderation.BulkResult {
+	result := &moderation.BulkResult{Results: make([]moderation.BulkItemResult, 0, len(ids))}
+	for _, id := range ids {
+		item := moderation.BulkItemResult{ID: id, Success: true}
+		if err, ok := failed[id]; ok {
+			item.Success = false
+			item.Error = err.Error()
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		result.Results = append(result.Results, item)
+	}
+	return result
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	out := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out
+}
@@ -4,55 +4,78 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 
 	"github.com/C0deNe0/agromart/internal/lib/aws"
+	"github.com/C0deNe0/agromart/internal/lib/utils"
 	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/audit"
 	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/model/moderation"
 	"github.com/C0deNe0/agromart/internal/model/product"
 	"github.com/C0deNe0/agromart/internal/repository"
 	"github.com/C0deNe0/agromart/internal/repository/productRepo"
 	"github.com/google/uuid"
+	"github.com/rs/zerolog"
 )
 
 type ProductService struct {
 	productRepo        *productRepo.ProductRepository
 	productImageRepo   *productRepo.ProductImageRepository
 	productVariantRepo *productRepo.ProductVariantRepository
+	revisionRepo       *productRepo.ProductRevisionRepository
 	companyRepo        *repository.CompanyRepository
+	followerRepo       *repository.CompanyFollowerRepository
+	serviceAreaRepo    *repository.CompanyServiceAreaRepository
 	categoryRepo       *repository.CategoryRepository
+	moderationRepo     *repository.ModerationRepository
 	S3Service          *aws.S3Service
+	audit              *AuditService
+	views              *ProductViewRecorder
+	rules              []ProductRule
+	log                *zerolog.Logger
 }
 
 func NewProductService(
 	productRepo *productRepo.ProductRepository,
 	productImageRepo *productRepo.ProductImageRepository,
 	productVariantRepo *productRepo.ProductVariantRepository,
+	revisionRepo *productRepo.ProductRevisionRepository,
 	companyRepo *repository.CompanyRepository,
+	followerRepo *repository.CompanyFollowerRepository,
+	serviceAreaRepo *repository.CompanyServiceAreaRepository,
+	categoryRepo *repository.CategoryRepository,
+	moderationRepo *repository.ModerationRepository,
 
 	s3 *aws.S3Service,
+	audit *AuditService,
+	views *ProductViewRecorder,
+	log *zerolog.Logger,
+	rules ...ProductRule,
 ) *ProductService {
 	return &ProductService{
 		productRepo:        productRepo,
 		productImageRepo:   productImageRepo,
 		productVariantRepo: productVariantRepo,
+		revisionRepo:       revisionRepo,
 		companyRepo:        companyRepo,
+		followerRepo:       followerRepo,
+		serviceAreaRepo:    serviceAreaRepo,
+		categoryRepo:       categoryRepo,
+		moderationRepo:     moderationRepo,
 		S3Service:          s3,
+		audit:              audit,
+		views:              views,
+		rules:              rules,
+		log:                log,
 	}
 }
 
 func (s *ProductService) Create(ctx context.Context, userID uuid.UUID, req *product.CreateProductRequest) (*product.ProductResponse, error) {
 
-	approvedCompany, err := s.companyRepo.GetApprovedCompanyByOwner(ctx, userID)
+	approvedCompany, err := approvedCompanyFor(ctx, s.companyRepo, userID, req.CompanyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check company approval: %w", err)
-	}
-
-	if approvedCompany == nil {
-		return nil, errors.New("you must have an approved company before creating products. Please create a company and wait for admin approval")
-	}
-
-	if req.CompanyID != approvedCompany.ID {
-		return nil, errors.New("you can only create products for your own approved company")
+		return nil, err
 	}
 
 	if req.CategoryID != nil {
@@ -63,7 +86,7 @@ func (s *ProductService) Create(ctx context.Context, userID uuid.UUID, req *prod
 	}
 
 	p := &product.Product{
-		CompanyID:      req.CompanyID,
+		CompanyID:      approvedCompany.ID,
 		CategoryID:     req.CategoryID,
 		Name:           req.Name,
 		Description:    req.Description,
@@ -72,6 +95,7 @@ func (s *ProductService) Create(ctx context.Context, userID uuid.UUID, req *prod
 		BasePrice:      req.BasePrice,
 		ApprovalStatus: company.ApprovalStatusPending,
 		IsActive:       true,
+		Visibility:     req.Visibility,
 	}
 
 	created, err := s.productRepo.Create(ctx, p)
@@ -100,6 +124,15 @@ func (s *ProductService) Create(ctx context.Context, userID uuid.UUID, req *prod
 		variants = append(variants, *createdVariant)
 	}
 
+	s.audit.Record(ctx, userID, audit.ActionCreate, audit.EntityProduct, created.ID, nil, product.ToProductResponse(created, nil, variants))
+
+	verdict := s.preModerate(ctx, &RuleSubject{Trigger: RuleTriggerCreate, Product: created, Company: approvedCompany})
+	if verdict.IsRejected() || verdict.IsApproved() {
+		if decided, err := s.productRepo.GetByID(ctx, created.ID); err == nil {
+			created = decided
+		}
+	}
+
 	return product.ToProductResponse(created, []product.ProductImage{}, variants), nil
 }
 
@@ -109,6 +142,27 @@ func (s *ProductService) List(ctx context.Context, userID *uuid.UUID, filter pro
 		filter.ApprovalStatus = &approvalStatus
 	}
 
+	// owners keep seeing their own listings while reports are reviewed
+	filter.ExcludeHidden = true
+	if userID != nil && filter.CompanyID != nil {
+		comp, err := s.companyRepo.GetByID(ctx, *filter.CompanyID)
+		if err == nil && canManageCompany(ctx, comp, *userID) {
+			filter.ExcludeHidden = false
+		}
+	}
+
+	// product visibility overrides the company default, owners see all
+	filter.ApplyVisibility = true
+	filter.ViewerID = userID
+
+	if filter.DeliversTo != nil {
+		lookup, err := newServiceAreaLookup(filter.DeliversTo.Pincode)
+		if err != nil {
+			return nil, err
+		}
+		filter.DeliversTo = lookup
+	}
+
 	//get the products
 	products, err := s.productRepo.List(ctx, filter)
 	if err != nil {
@@ -127,6 +181,9 @@ func (s *ProductService) List(ctx context.Context, userID *uuid.UUID, filter pro
 	productIDs := make([]uuid.UUID, len(products.Data))
 	for i, p := range products.Data {
 		productIDs[i] = p.ID
+		if filter.ExcludeHidden {
+			s.views.RecordImpression(p.ID, p.CompanyID)
+		}
 	}
 	//get the images
 	images, err := s.productImageRepo.ListByProductIDs(ctx, productIDs)
@@ -140,7 +197,36 @@ func (s *ProductService) List(ctx context.Context, userID *uuid.UUID, filter pro
 		return nil, fmt.Errorf("failed to list variants: %w", err)
 	}
 
-	return product.MapProductPage(products, images, variants), nil
+	page := product.MapProductPage(products, images, variants)
+	responses := make([]*product.ProductResponse, len(page.Data))
+	for i := range page.Data {
+		responses[i] = &page.Data[i]
+	}
+	if err := applyVacations(ctx, s.companyRepo, responses...); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// CategoryBreakdown counts the company's approved products per category,
+// limited to what the viewer is allowed to see.
+func (s *ProductService) CategoryBreakdown(ctx context.Context, userID *uuid.UUID, companyID uuid.UUID) ([]product.CategoryCount, error) {
+	approved := company.ApprovalStatusApproved
+	active := true
+	filter := productRepo.ProductFilter{
+		CompanyID:       &companyID,
+		ApprovalStatus:  &approved,
+		IsActive:        &active,
+		ExcludeHidden:   true,
+		ApplyVisibility: true,
+		ViewerID:        userID,
+	}
+
+	counts, err := s.productRepo.CountByCategory(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
 }
 
 func (s *ProductService) Update(ctx context.Context, userID uuid.UUID, productID uuid.UUID, updates *product.UpdateProductRequest) (*product.Product, error) {
@@ -155,23 +241,35 @@ func (s *ProductService) Update(ctx context.Context, userID uuid.UUID, productID
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
 
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return nil, errors.New("not authorized to update this product")
 	}
 
+	if updates.CategoryID != nil && *updates.CategoryID != uuid.Nil {
+		_, err := s.categoryRepo.GetByID(ctx, *updates.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category: %w", err)
+		}
+	}
+
+	// approved products stay live, the edit waits as a revision for review
+	if existing.IsApproved() {
+		rev, err := s.submitRevision(ctx, userID, existing, updates)
+		if err != nil {
+			return nil, err
+		}
+		s.audit.Record(ctx, userID, audit.ActionCreate, audit.EntityProductRevision, rev.ID, nil, rev)
+		return existing, nil
+	}
+
 	// ✅ CRITICAL: Can only update PENDING or REJECTED products
 	if !existing.CanBeModified() {
 		return nil, fmt.Errorf("cannot modify product with status: %s. Only PENDING or REJECTED products can be modified", existing.ApprovalStatus)
 	}
+	before := *existing
 
 	// Apply updates
 	if updates.CategoryID != nil {
-		if *updates.CategoryID != uuid.Nil {
-			_, err := s.categoryRepo.GetByID(ctx, *updates.CategoryID)
-			if err != nil {
-				return nil, fmt.Errorf("invalid category: %w", err)
-			}
-		}
 		existing.CategoryID = updates.CategoryID
 	}
 
@@ -191,7 +289,12 @@ func (s *ProductService) Update(ctx context.Context, userID uuid.UUID, productID
 		existing.BasePrice = *updates.BasePrice
 	}
 
-	return s.productRepo.Update(ctx, existing)
+	updated, err := s.productRepo.Update(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityProduct, productID, before, updated)
+	return updated, nil
 }
 
 func (s *ProductService) GetByID(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*product.ProductResponse, error) {
@@ -207,9 +310,31 @@ func (s *ProductService) GetByID(ctx context.Context, id uuid.UUID, userID *uuid
 		if err != nil {
 			return nil, fmt.Errorf("company not found")
 		}
-		if comp.OwnerID != *userID {
+		if !canManageCompany(ctx, comp, *userID) {
 			return nil, fmt.Errorf("not authorized to get product")
 		}
+	} else {
+		comp, err := s.companyRepo.GetByID(ctx, p.CompanyID)
+		if err != nil {
+			return nil, fmt.Errorf("company not found")
+		}
+		isOwner := userID != nil && canManageCompany(ctx, comp, *userID)
+		if (p.IsSuspended() || comp.IsSuspended()) && !isOwner {
+			return nil, fmt.Errorf("product is suspended")
+		}
+		if (p.IsHidden() || comp.IsHidden()) && !isOwner {
+			return nil, fmt.Errorf("product is under review")
+		}
+		if !isOwner {
+			if err := s.checkVisibility(ctx, p, comp, userID); err != nil {
+				return nil, err
+			}
+		}
+		// sellers looking at their own listing or syncing it over an API
+		// key are not counted
+		if _, viaKey := utils.APIKeyCompanyFromContext(ctx); !isOwner && !viaKey {
+			s.views.RecordView(ctx, p.ID, p.CompanyID, userID)
+		}
 	}
 	//getting the images
 	images, err := s.productImageRepo.ListByProductID(ctx, id)
@@ -222,7 +347,113 @@ func (s *ProductService) GetByID(ctx context.Context, id uuid.UUID, userID *uuid
 	if err != nil {
 		return nil, fmt.Errorf("failed to get the variants: %w", err)
 	}
-	return product.ToProductResponse(p, images, variants), nil
+
+	resp := product.ToProductResponse(p, images, variants)
+	if err := applyVacations(ctx, s.companyRepo, resp); err != nil {
+		return nil, err
+	}
+	if userID != nil && p.IsApproved() {
+		resp.PendingRevision, err = s.ownerPendingRevision(ctx, p, *userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// checkVisibility applies the product visibility, or the company default
+// when the product has none, to a viewer who is not the owner.
+func (s *ProductService) checkVisibility(ctx context.Context, p *product.Product, comp *company.Company, userID *uuid.UUID) error {
+	switch p.EffectiveVisibility(comp.ProductVisibility) {
+	case company.ProductVisibilityPublic:
+		return nil
+	case company.ProductVisibilityFollowersOnly:
+		if userID == nil {
+			return errors.New("only followers of this company can view this product")
+		}
+		following, err := s.followerRepo.IsFollowing(ctx, comp.ID, *userID)
+		if err != nil {
+			return err
+		}
+		if !following {
+			return errors.New("only followers of this company can view this product")
+		}
+		blocked, err := s.followerRepo.IsBlocked(ctx, comp.ID, *userID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return errors.New("only followers of this company can view this product")
+		}
+		return nil
+	}
+	return errors.New("product is private")
+}
+
+// CheckServiceability tells a buyer whether the product's company delivers
+// to the pincode, and which of its service areas covers it.
+func (s *ProductService) CheckServiceability(ctx context.Context, userID *uuid.UUID, req *product.CheckServiceabilityRequest) (*product.ServiceabilityResponse, error) {
+	lookup, err := newServiceAreaLookup(req.Pincode)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.productRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("product not found")
+	}
+	comp, err := s.companyRepo.GetByID(ctx, p.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found")
+	}
+	if userID == nil || !canManageCompany(ctx, comp, *userID) {
+		if !p.IsApproved() || p.IsHidden() || p.IsSuspended() || comp.IsHidden() || comp.IsSuspended() {
+			return nil, fmt.Errorf("product not found")
+		}
+		if err := s.checkVisibility(ctx, p, comp, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	area, hasAreas, err := s.serviceAreaRepo.Match(ctx, comp.ID, *lookup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &product.ServiceabilityResponse{
+		ProductID:   p.ID,
+		CompanyID:   comp.ID,
+		Pincode:     req.Pincode,
+		Serviceable: area != nil || !hasAreas,
+		MatchedArea: area,
+	}, nil
+}
+
+// SetVisibility sets or clears the product's visibility override. It is
+// not an edit of the listing, so approved products stay live.
+func (s *ProductService) SetVisibility(ctx context.Context, userID uuid.UUID, req *product.UpdateProductVisibilityRequest) (*product.Product, error) {
+	existing, err := s.productRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("product not found: %w", err)
+	}
+
+	comp, err := s.companyRepo.GetByID(ctx, existing.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to update this product")
+	}
+
+	updated, err := s.productRepo.SetVisibility(ctx, req.ID, req.Visibility)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityProduct, req.ID,
+		map[string]any{"visibility": existing.Visibility},
+		map[string]any{"visibility": updated.Visibility},
+	)
+	return updated, nil
 }
 
 func (s *ProductService) Delete(ctx context.Context, userID uuid.UUID, productID uuid.UUID) error {
@@ -236,7 +467,7 @@ func (s *ProductService) Delete(ctx context.Context, userID uuid.UUID, productID
 		return fmt.Errorf("failed to get company: %w", err)
 	}
 
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return errors.New("not authorized to delete this product")
 	}
 
@@ -245,7 +476,11 @@ func (s *ProductService) Delete(ctx context.Context, userID uuid.UUID, productID
 		return fmt.Errorf("cannot delete product with status: %s", existing.ApprovalStatus)
 	}
 
-	return s.productRepo.Delete(ctx, productID)
+	if err := s.productRepo.Delete(ctx, productID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionDelete, audit.EntityProduct, productID, existing, nil)
+	return nil
 }
 
 func (s *ProductService) Resubmit(ctx context.Context, userID uuid.UUID, productID uuid.UUID) error {
@@ -259,7 +494,7 @@ func (s *ProductService) Resubmit(ctx context.Context, userID uuid.UUID, product
 		return fmt.Errorf("failed to get company: %w", err)
 	}
 
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return errors.New("not authorized to resubmit this product")
 	}
 
@@ -267,37 +502,303 @@ func (s *ProductService) Resubmit(ctx context.Context, userID uuid.UUID, product
 		return errors.New("only rejected products can be resubmitted")
 	}
 
-	return s.productRepo.Resubmit(ctx, productID, userID)
+	if err := s.productRepo.Resubmit(ctx, productID, userID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionResubmit, audit.EntityProduct, productID,
+		auditDecision{ApprovalStatus: existing.ApprovalStatus, Reason: existing.RejectionReason},
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending})
+
+	resubmitted, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("failed to reload product: %w", err)
+	}
+	s.preModerate(ctx, &RuleSubject{Trigger: RuleTriggerResubmit, Product: resubmitted, Company: comp})
+	return nil
+}
+
+// preModerate runs the rules and records their decisions. It is best effort:
+// a failing rule is skipped and the product simply waits for a reviewer.
+// Variant checks never change the product status, the caller decides.
+func (s *ProductService) preModerate(ctx context.Context, subject *RuleSubject) *product.RuleVerdict {
+	verdict := &product.RuleVerdict{}
+	for _, rule := range s.rules {
+		decision, err := rule.Evaluate(ctx, subject)
+		if err != nil {
+			s.log.Error().Err(err).Str("productId", subject.Product.ID.String()).Msg("product rule failed")
+			continue
+		}
+		if decision != nil {
+			if subject.Variant != nil {
+				decision.Reason = fmt.Sprintf("Variant %q: %s", subject.Variant.Label, decision.Reason)
+			}
+			verdict.Add(*decision)
+		}
+	}
+
+	// an approval only stands when nothing else fired, drop it otherwise so
+	// the history does not claim an approval that never happened
+	if !verdict.IsApproved() {
+		kept := verdict.Decisions[:0]
+		for _, d := range verdict.Decisions {
+			if d.Outcome != product.RuleOutcomeApprove {
+				kept = append(kept, d)
+			}
+		}
+		verdict.Decisions = kept
+	}
+
+	decide := subject.Trigger != RuleTriggerVariant
+	if err := s.productRepo.RecordRuleVerdict(ctx, subject.Product.ID, verdict, decide); err != nil {
+		s.log.Error().Err(err).Str("productId", subject.Product.ID.String()).Msg("failed to record rule verdict")
+		return &product.RuleVerdict{}
+	}
+
+	// decided by the rules, the actor is the system
+	switch {
+	case decide && verdict.IsRejected():
+		reason := verdict.RejectionReason()
+		s.audit.Record(ctx, uuid.Nil, audit.ActionReject, audit.EntityProduct, subject.Product.ID,
+			auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+			auditDecision{ApprovalStatus: company.ApprovalStatusRejected, Reason: &reason})
+	case decide && verdict.IsApproved():
+		s.audit.Record(ctx, uuid.Nil, audit.ActionApprove, audit.EntityProduct, subject.Product.ID,
+			auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+			auditDecision{ApprovalStatus: company.ApprovalStatusApproved})
+	}
+	return verdict
 }
 
 // ADMINT APPROVE PRODUCTS
 func (s *ProductService) Approve(ctx context.Context, productID, adminID uuid.UUID, notes *string) error {
+	if err := s.checkReviewable(ctx, productID, adminID, "approved"); err != nil {
+		return err
+	}
+	if err := s.productRepo.Approve(ctx, productID, adminID, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionApprove, audit.EntityProduct, productID,
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+		auditDecision{ApprovalStatus: company.ApprovalStatusApproved, Notes: notes})
+	return nil
+}
+func (s *ProductService) Reject(ctx context.Context, productID, adminID uuid.UUID, reason string, notes *string) error {
+	if err := s.checkReviewable(ctx, productID, adminID, "rejected"); err != nil {
+		return err
+	}
+	if err := s.productRepo.Reject(ctx, productID, adminID, reason, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionReject, audit.EntityProduct, productID,
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+		auditDecision{ApprovalStatus: company.ApprovalStatusRejected, Reason: &reason, Notes: notes})
+	return nil
+}
+
+func (s *ProductService) BulkApprove(ctx context.Context, productIDs []uuid.UUID, adminID uuid.UUID, notes *string) (*moderation.BulkResult, error) {
+	productIDs = uniqueIDs(productIDs)
+	failed := make(map[uuid.UUID]error)
+
+	valid := make([]uuid.UUID, 0, len(productIDs))
+	for _, id := range productIDs {
+		if err := s.checkReviewable(ctx, id, adminID, "approved"); err != nil {
+			failed[id] = err
+			continue
+		}
+		valid = append(valid, id)
+	}
+
+	if len(valid) > 0 {
+		repoFailed, err := s.productRepo.BulkApprove(ctx, valid, adminID, notes)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(failed, repoFailed)
+	}
+
+	s.audit.RecordBulk(ctx, adminID, audit.ActionApprove, audit.EntityProduct, valid, failed,
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+		auditDecision{ApprovalStatus: company.ApprovalStatusApproved, Notes: notes})
+
+	return bulkResult(productIDs, failed), nil
+}
+
+func (s *ProductService) BulkReject(ctx context.Context, productIDs []uuid.UUID, adminID uuid.UUID, reason string, notes *string) (*moderation.BulkResult, error) {
+	productIDs = uniqueIDs(productIDs)
+	failed := make(map[uuid.UUID]error)
+
+	valid := make([]uuid.UUID, 0, len(productIDs))
+	for _, id := range productIDs {
+		if err := s.checkReviewable(ctx, id, adminID, "rejected"); err != nil {
+			failed[id] = err
+			continue
+		}
+		valid = append(valid, id)
+	}
+
+	if len(valid) > 0 {
+		repoFailed, err := s.productRepo.BulkReject(ctx, valid, adminID, reason, notes)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(failed, repoFailed)
+	}
+
+	s.audit.RecordBulk(ctx, adminID, audit.ActionReject, audit.EntityProduct, valid, failed,
+		auditDecision{ApprovalStatus: company.ApprovalStatusPending},
+		auditDecision{ApprovalStatus: company.ApprovalStatusRejected, Reason: &reason, Notes: notes})
+
+	return bulkResult(productIDs, failed), nil
+}
+
+// checkReviewable is shared by approve and reject, verb only shapes the error.
+func (s *ProductService) checkReviewable(ctx context.Context, productID, adminID uuid.UUID, verb string) error {
 	existing, err := s.productRepo.GetByID(ctx, productID)
 	if err != nil {
 		return fmt.Errorf("product not found: %w", err)
 	}
 
 	if !existing.IsPending() {
-		return fmt.Errorf("only pending products can be approved. Current status: %s", existing.ApprovalStatus)
+		return fmt.Errorf("only pending products can be %s. Current status: %s", verb, existing.ApprovalStatus)
 	}
 
-	return s.productRepo.Approve(ctx, productID, adminID, notes)
+	return ensureReviewer(ctx, s.moderationRepo, moderation.EntityProduct, productID, adminID)
 }
-func (s *ProductService) Reject(ctx context.Context, productID, adminID uuid.UUID, reason string, notes *string) error {
-	existing, err := s.productRepo.GetByID(ctx, productID)
+
+// =============================================
+// REVISIONS
+// =============================================
+
+// submitRevision stacks the update on top of the open draft (or the live
+// version when there is none) and stores the diff against the live version.
+func (s *ProductService) submitRevision(ctx context.Context, userID uuid.UUID, live *product.Product, updates *product.UpdateProductRequest) (*product.ProductRevision, error) {
+	draft := live.Content()
+
+	pending, err := s.revisionRepo.GetPendingByProductID(ctx, live.ID)
 	if err != nil {
-		return fmt.Errorf("product not found: %w", err)
+		return nil, err
+	}
+	if pending != nil {
+		draft = pending.ProductContent
 	}
+	draft.ApplyUpdate(updates)
 
-	if !existing.IsPending() {
-		return fmt.Errorf("only pending products can be rejected. Current status: %s", existing.ApprovalStatus)
+	changes, err := model.Diff(live.Content(), draft)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff revision: %w", err)
+	}
+	if len(changes) == 0 {
+		return nil, errors.New("no changes compared to the approved version")
 	}
 
-	return s.productRepo.Reject(ctx, productID, adminID, reason, notes)
+	return s.revisionRepo.SubmitDraft(ctx, &product.ProductRevision{
+		ProductID:      live.ID,
+		SubmittedByID:  userID,
+		ProductContent: draft,
+		Changes:        changes,
+	})
 }
 
-func (s *ProductService) GetApprovalHistory(ctx context.Context, productID uuid.UUID) ([]product.ProductApprovalHistory, error) {
-	return s.productRepo.GetApprovalHistory(ctx, productID)
+func (s *ProductService) ownerPendingRevision(ctx context.Context, p *product.Product, userID uuid.UUID) (*product.ProductRevision, error) {
+	pending, err := s.revisionRepo.GetPendingByProductID(ctx, p.ID)
+	if err != nil || pending == nil {
+		return nil, err
+	}
+
+	comp, err := s.companyRepo.GetByID(ctx, p.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, nil
+	}
+	return pending, nil
+}
+
+func (s *ProductService) GetPendingRevision(ctx context.Context, userID, productID uuid.UUID) (*product.ProductRevision, error) {
+	p, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("product not found: %w", err)
+	}
+
+	comp, err := s.companyRepo.GetByID(ctx, p.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	if !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to view revisions of this product")
+	}
+
+	pending, err := s.revisionRepo.GetPendingByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if pending == nil {
+		return nil, errors.New("product has no pending revision")
+	}
+	return pending, nil
+}
+
+func (s *ProductService) ListPendingRevisions(ctx context.Context, page, limit int) (*model.PaginatedResponse[product.ProductRevision], error) {
+	return s.revisionRepo.ListPending(ctx, page, limit)
+}
+
+func (s *ProductService) ApproveRevision(ctx context.Context, revisionID, adminID uuid.UUID, notes *string) error {
+	rev, err := s.revisionRepo.GetByID(ctx, revisionID)
+	if err != nil {
+		return fmt.Errorf("revision not found: %w", err)
+	}
+	if !rev.IsPending() {
+		return fmt.Errorf("only pending revisions can be approved. Current status: %s", rev.Status)
+	}
+	if err := s.revisionRepo.Approve(ctx, revisionID, adminID, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionApprove, audit.EntityProductRevision, revisionID, nil, rev.Changes)
+	return nil
+}
+
+func (s *ProductService) RejectRevision(ctx context.Context, revisionID, adminID uuid.UUID, reason string, notes *string) error {
+	rev, err := s.revisionRepo.GetByID(ctx, revisionID)
+	if err != nil {
+		return fmt.Errorf("revision not found: %w", err)
+	}
+	if !rev.IsPending() {
+		return fmt.Errorf("only pending revisions can be rejected. Current status: %s", rev.Status)
+	}
+	if err := s.revisionRepo.Reject(ctx, revisionID, adminID, reason, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionReject, audit.EntityProductRevision, revisionID, nil,
+		auditDecision{ApprovalStatus: company.ApprovalStatusRejected, Reason: &reason, Notes: notes})
+	return nil
+}
+
+// GetApprovalHistory is available to the owner and to admins, it holds
+// snapshots of unpublished listings and the moderation reasons.
+func (s *ProductService) GetApprovalHistory(ctx context.Context, userID uuid.UUID, isAdmin bool, productID uuid.UUID) ([]product.ProductApprovalHistory, error) {
+	p, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("product not found: %w", err)
+	}
+	comp, err := s.companyRepo.GetByID(ctx, p.CompanyID)
+	if err != nil {
+		return nil, fmt.Errorf("company not found: %w", err)
+	}
+	if !isAdmin && !canManageCompany(ctx, comp, userID) {
+		return nil, errors.New("not authorized to view the history of this product")
+	}
+
+	history, err := s.productRepo.GetApprovalHistory(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	// revision rows already carry their own diff
+	if err := diffSubmissions(history); err != nil {
+		return nil, err
+	}
+	return history, nil
 }
 
 func (s *ProductService) CountPendingApprovals(ctx context.Context) (int, error) {
@@ -316,12 +817,12 @@ func (s *ProductService) GenerateImageUploadURL(ctx context.Context, userID uuid
 	if err != nil {
 		return nil, fmt.Errorf("company not found: %w", err)
 	}
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return nil, errors.New("not authorized to upload images for this product")
 	}
 
 	imageID := uuid.New()
-	s3Key := fmt.Sprintf("products/$s/images/%s", req.ProductID.String(), imageID.String())
+	s3Key := fmt.Sprintf("products/%s/images/%s", req.ProductID.String(), imageID.String())
 
 	//presin url
 	uploadURL := s.S3Service.GetPublicURL(s3Key)
@@ -358,7 +859,7 @@ func (s *ProductService) DeleteImage(ctx context.Context, userID uuid.UUID, prod
 		return fmt.Errorf("failed to get company: %w", err)
 	}
 
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return errors.New("not authorized to delete images for this product")
 	}
 
@@ -384,6 +885,7 @@ func (s *ProductService) DeleteImage(ctx context.Context, userID uuid.UUID, prod
 		return fmt.Errorf("failed to delete image record: %w", err)
 	}
 
+	s.audit.Record(ctx, userID, audit.ActionDelete, audit.EntityProductImage, imageID, img, nil)
 	return nil
 }
 
@@ -399,11 +901,15 @@ func (s *ProductService) SetPrimaryImage(ctx context.Context, userID uuid.UUID,
 		return fmt.Errorf("failed to get company: %w", err)
 	}
 
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return errors.New("not authorized to manage images for this product")
 	}
 
-	return s.productImageRepo.SetPrimary(ctx, productID, imageID)
+	if err := s.productImageRepo.SetPrimary(ctx, productID, imageID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityProductImage, imageID, nil, map[string]bool{"isPrimary": true})
+	return nil
 }
 
 //VARIANT MANAGEMENT
@@ -420,7 +926,7 @@ func (s *ProductService) CreateVariant(ctx context.Context, userID uuid.UUID, re
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
 
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return nil, errors.New("not authorized to create variants for this product")
 	}
 
@@ -441,7 +947,17 @@ func (s *ProductService) CreateVariant(ctx context.Context, userID uuid.UUID, re
 		IsAvailable:       true,
 	}
 
-	return s.productVariantRepo.Create(ctx, variant)
+	verdict := s.preModerate(ctx, &RuleSubject{Trigger: RuleTriggerVariant, Product: p, Company: comp, Variant: variant})
+	if verdict.IsRejected() {
+		return nil, fmt.Errorf("cannot add variant: %s", verdict.RejectionReason())
+	}
+
+	created, err := s.productVariantRepo.Create(ctx, variant)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionCreate, audit.EntityProductVariant, created.ID, nil, created)
+	return created, nil
 }
 
 func (s *ProductService) UpdateVariant(ctx context.Context, userID uuid.UUID, productID, variantID uuid.UUID, updates *product.UpdateVariantRequest) (*product.ProductVariant, error) {
@@ -456,7 +972,7 @@ func (s *ProductService) UpdateVariant(ctx context.Context, userID uuid.UUID, pr
 		return nil, fmt.Errorf("failed to get company: %w", err)
 	}
 
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return nil, errors.New("not authorized to update variants for this product")
 	}
 
@@ -469,6 +985,7 @@ func (s *ProductService) UpdateVariant(ctx context.Context, userID uuid.UUID, pr
 	if existing.ProductID != productID {
 		return nil, errors.New("variant does not belong to this product")
 	}
+	before := *existing
 
 	// Apply updates
 
@@ -494,7 +1011,20 @@ func (s *ProductService) UpdateVariant(ctx context.Context, userID uuid.UUID, pr
 		existing.IsAvailable = *updates.IsAvailable
 	}
 
-	return s.productVariantRepo.Update(ctx, existing)
+	updated, err := s.productVariantRepo.Update(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionUpdate, audit.EntityProductVariant, variantID, before, updated)
+	return updated, nil
+}
+
+func (s *ProductService) UpdateVariantStock(ctx context.Context, userID uuid.UUID, req *product.UpdateVariantStockRequest) (*product.ProductVariant, error) {
+	return s.UpdateVariant(ctx, userID, req.ProductID, req.VariantID, &product.UpdateVariantRequest{
+		StockQuantity:     req.StockQuantity,
+		LowStockThreshold: req.LowStockThreshold,
+		IsAvailable:       req.IsAvailable,
+	})
 }
 
 func (s *ProductService) DeleteVariant(ctx context.Context, userID uuid.UUID, productID, variantID uuid.UUID) error {
@@ -509,7 +1039,7 @@ func (s *ProductService) DeleteVariant(ctx context.Context, userID uuid.UUID, pr
 		return fmt.Errorf("failed to get company: %w", err)
 	}
 
-	if comp.OwnerID != userID {
+	if !canManageCompany(ctx, comp, userID) {
 		return errors.New("not authorized to delete variants for this product")
 	}
 
@@ -533,5 +1063,25 @@ func (s *ProductService) DeleteVariant(ctx context.Context, userID uuid.UUID, pr
 		return errors.New("cannot delete the last variant. Products must have at least one variant")
 	}
 
-	return s.productVariantRepo.Delete(ctx, variantID)
+	if err := s.productVariantRepo.Delete(ctx, variantID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, userID, audit.ActionDelete, audit.EntityProductVariant, variantID, existing, nil)
+	return nil
+}
+
+// canManageCompany checks ownership. Requests made with an API key are
+// additionally pinned to the company the key was issued for, and requests
+// that name an active company to that company.
+func canManageCompany(ctx context.Context, comp *company.Company, userID uuid.UUID) bool {
+	if comp.OwnerID != userID {
+		return false
+	}
+	if keyCompanyID, ok := utils.APIKeyCompanyFromContext(ctx); ok && keyCompanyID != comp.ID {
+		return false
+	}
+	if activeCompanyID, ok := utils.ActiveCompanyFromContext(ctx); ok && activeCompanyID != comp.ID {
+		return false
+	}
+	return true
 }
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/C0deNe0/agromart/internal/repository/productRepo"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	DefaultPriceOutlierFactor = 3.0
+	// a category median taken over fewer approved products is not trusted
+	MinPriceSample = 5
+	// rejections older than this no longer count against a trusted seller
+	TrustedSellerWindow = 90 * 24 * time.Hour
+)
+
+type RuleTrigger string
+
+const (
+	RuleTriggerCreate   RuleTrigger = "CREATE"
+	RuleTriggerResubmit RuleTrigger = "RESUBMIT"
+	RuleTriggerVariant  RuleTrigger = "VARIANT"
+)
+
+// RuleSubject is what the pre-moderation rules look at. Variant is only set
+// for RuleTriggerVariant and holds the variant about to be added.
+type RuleSubject struct {
+	Trigger RuleTrigger
+	Product *product.Product
+	Company *company.Company
+	Variant *product.ProductVariant
+}
+
+// ProductRule inspects a product before it reaches the manual queue and
+// returns a decision when it has something to say, nil otherwise.
+type ProductRule interface {
+	Evaluate(ctx context.Context, s *RuleSubject) (*product.RuleDecision, error)
+}
+
+// ModerationConfig is filled from the moderation section of the config.
+type ModerationConfig struct {
+	BannedKeywords     []string
+	PriceOutlierFactor float64
+	// 0 turns auto approval off
+	TrustedSellerMinApproved int
+	// 0 falls back to DefaultReportHideThreshold
+	ReportHideThreshold int
+}
+
+// NewProductRules builds the default rule set in the order they are recorded.
+func NewProductRules(products *productRepo.ProductRepository, images *productRepo.ProductImageRepository, cfg ModerationConfig) []ProductRule {
+	return []ProductRule{
+		NewBannedKeywordRule(cfg.BannedKeywords),
+		NewContactInfoRule(),
+		NewDuplicateNameRule(products),
+		NewPrimaryImageRule(images),
+		NewPriceOutlierRule(products, cfg.PriceOutlierFactor),
+		NewTrustedSellerRule(products, cfg.TrustedSellerMinApproved),
+	}
+}
+
+// =============================================
+// RULES
+// =============================================
+
+type BannedKeywordRule struct {
+	keywords []string
+}
+
+func NewBannedKeywordRule(keywords []string) *BannedKeywordRule {
+	r := &BannedKeywordRule{}
+	for _, k := range keywords {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			r.keywords = append(r.keywords, k)
+		}
+	}
+	return r
+}
+
+func (r *BannedKeywordRule) Evaluate(ctx context.Context, s *RuleSubject) (*product.RuleDecision, error) {
+	text := strings.ToLower(subjectText(s))
+	for _, k := range r.keywords {
+		if strings.Contains(text, k) {
+			return &product.RuleDecision{
+				Rule:    "BANNED_KEYWORD",
+				Outcome: product.RuleOutcomeReject,
+				Reason:  fmt.Sprintf("Contains the banned keyword %q", k),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+var contactPatterns = []struct {
+	what    string
+	pattern *regexp.Regexp
+}{
+	{"an email address", regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	{"a phone number", regexp.MustCompile(`(?:\+91[\s-]?|\b0?)[6-9]\d{4}[\s-]?\d{5}\b`)},
+	{"a link", regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)},
+	{"a messenger handle", regexp.MustCompile(`(?i)\b(?:whats\s?app|telegram)\b`)},
+}
+
+// ContactInfoRule flags listings that try to move buyers off the platform.
+type ContactInfoRule struct{}
+
+func NewContactInfoRule() *ContactInfoRule {
+	return &ContactInfoRule{}
+}
+
+func (r *ContactInfoRule) Evaluate(ctx context.Context, s *RuleSubject) (*product.RuleDecision, error) {
+	text := subjectText(s)
+	for _, c := range contactPatterns {
+		if c.pattern.MatchString(text) {
+			return &product.RuleDecision{
+				Rule:    "CONTACT_INFO",
+				Outcome: product.RuleOutcomeFlag,
+				Reason:  "Listing contains " + c.what,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+type DuplicateNameRule struct {
+	products *productRepo.ProductRepository
+}
+
+func NewDuplicateNameRule(products *productRepo.ProductRepository) *DuplicateNameRule {
+	return &DuplicateNameRule{products: products}
+}
+
+func (r *DuplicateNameRule) Evaluate(ctx context.Context, s *RuleSubject) (*product.RuleDecision, error) {
+	if s.Trigger == RuleTriggerVariant {
+		return nil, nil
+	}
+	dup, err := r.products.FindByCompanyAndName(ctx, s.Product.CompanyID, s.Product.Name, s.Product.ID)
+	if err != nil || dup == nil {
+		return nil, err
+	}
+	return &product.RuleDecision{
+		Rule:    "DUPLICATE_NAME",
+		Outcome: product.RuleOutcomeFlag,
+		Reason:  fmt.Sprintf("Company already lists a product named %q (%s)", dup.Name, dup.ID),
+	}, nil
+}
+
+// PrimaryImageRule only runs on resubmission, images are uploaded after the
+// product is created so a new product never has one yet.
+type PrimaryImageRule struct {
+	images *productRepo.ProductImageRepository
+}
+
+func NewPrimaryImageRule(images *productRepo.ProductImageRepository) *PrimaryImageRule {
+	return &PrimaryImageRule{images: images}
+}
+
+func (r *PrimaryImageRule) Evaluate(ctx context.Context, s *RuleSubject) (*product.RuleDecision, error) {
+	if s.Trigger != RuleTriggerResubmit {
+		return nil, nil
+	}
+	images, err := r.images.ListByProductID(ctx, s.Product.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, img := range images {
+		if img.IsPrimary {
+			return nil, nil
+		}
+	}
+	return &product.RuleDecision{
+		Rule:    "MISSING_PRIMARY_IMAGE",
+		Outcome: product.RuleOutcomeFlag,
+		Reason:  "Product has no primary image",
+	}, nil
+}
+
+// PriceOutlierRule compares the base price with the median of the approved
+// products in the same category.
+type PriceOutlierRule struct {
+	products *productRepo.ProductRepository
+	factor   decimal.Decimal
+}
+
+func NewPriceOutlierRule(products *productRepo.ProductRepository, factor float64) *PriceOutlierRule {
+	if factor <= 1 {
+		factor = DefaultPriceOutlierFactor
+	}
+	return &PriceOutlierRule{products: products, factor: decimal.NewFromFloat(factor)}
+}
+
+func (r *PriceOutlierRule) Evaluate(ctx context.Context, s *RuleSubject) (*product.RuleDecision, error) {
+	if s.Trigger == RuleTriggerVariant || s.Product.CategoryID == nil {
+		return nil, nil
+	}
+	median, sample, err := r.products.CategoryMedianPrice(ctx, *s.Product.CategoryID, s.Product.ID)
+	if err != nil || !median.Valid || sample < MinPriceSample || !median.Decimal.IsPositive() {
+		return nil, err
+	}
+
+	price := s.Product.BasePrice
+	direction := priceOutlierDirection(price, median.Decimal, r.factor)
+	if direction == "" {
+		return nil, nil
+	}
+	return &product.RuleDecision{
+		Rule:    "PRICE_OUTLIER",
+		Outcome: product.RuleOutcomeFlag,
+		Reason: fmt.Sprintf("Price %s is more than %sx %s the category median of %s",
+			price.StringFixed(2), r.factor.String(), direction, median.Decimal.StringFixed(2)),
+	}, nil
+}
+
+// priceOutlierDirection is "above" or "below" when price is more than factor
+// times off the median, empty otherwise.
+func priceOutlierDirection(price, median, factor decimal.Decimal) string {
+	switch {
+	case price.GreaterThan(median.Mul(factor)):
+		return "above"
+	case price.Mul(factor).LessThan(median):
+		return "below"
+	default:
+		return ""
+	}
+}
+
+// TrustedSellerRule approves products of companies with enough approved
+// products and no rejections within TrustedSellerWindow.
+type TrustedSellerRule struct {
+	products    *productRepo.ProductRepository
+	minApproved int
+}
+
+func NewTrustedSellerRule(products *productRepo.ProductRepository, minApproved int) *TrustedSellerRule {
+	return &TrustedSellerRule{products: products, minApproved: minApproved}
+}
+
+func (r *TrustedSellerRule) Evaluate(ctx context.Context, s *RuleSubject) (*product.RuleDecision, error) {
+	if r.minApproved <= 0 || s.Trigger == RuleTriggerVariant {
+		return nil, nil
+	}
+	approved, rejected, err := r.products.SellerTrackRecord(ctx, s.Product.CompanyID, time.Now().Add(-TrustedSellerWindow))
+	if err != nil || approved < r.minApproved || rejected > 0 {
+		return nil, err
+	}
+	return &product.RuleDecision{
+		Rule:    "TRUSTED_SELLER",
+		Outcome: product.RuleOutcomeApprove,
+		Reason:  fmt.Sprintf("Trusted seller with %d approved products and no recent rejections", approved),
+	}, nil
+}
+
+// subjectText is the free text a rule should scan: the variant label for a
+// variant check, the name and description otherwise.
+func subjectText(s *RuleSubject) string {
+	if s.Trigger == RuleTriggerVariant {
+		return s.Variant.Label
+	}
+	text := s.Product.Name
+	if s.Product.Description != nil {
+		text += "\n" + *s.Product.Description
+	}
+	return text
+}
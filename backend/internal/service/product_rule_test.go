@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/shopspring/decimal"
+)
+
+func TestContactInfoRule(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		description string
+		// empty when the listing should pass
+		wantReason string
+	}{
+		{name: "plain listing", text: "Organic wheat, 50 kg bags"},
+		{name: "price and quantity", text: "Basmati rice 1121, Rs 9800 per quintal, min 25 qtl"},
+		{name: "at sign without an address", text: "Grade A @ best price"},
+		{name: "five digit number", text: "Lot 98765 of cotton"},
+		{name: "number not starting 6 to 9", text: "Batch 5876543210"},
+		{name: "email", text: "Mail sales@greenvalley.in for rates", wantReason: "Listing contains an email address"},
+		{name: "bare mobile", text: "Call 9876543210", wantReason: "Listing contains a phone number"},
+		{name: "country code and space", text: "Call +91 98765 43210", wantReason: "Listing contains a phone number"},
+		{name: "leading zero and hyphen", text: "Call 098765-43210", wantReason: "Listing contains a phone number"},
+		{name: "https link", text: "See https://greenvalley.in/wheat", wantReason: "Listing contains a link"},
+		{name: "www link", text: "See WWW.greenvalley.in", wantReason: "Listing contains a link"},
+		{name: "whatsapp", text: "Ping us on Whats App", wantReason: "Listing contains a messenger handle"},
+		{name: "telegram", text: "Join our TELEGRAM channel", wantReason: "Listing contains a messenger handle"},
+		{name: "description is scanned", text: "Organic wheat", description: "WhatsApp for bulk orders", wantReason: "Listing contains a messenger handle"},
+	}
+
+	rule := NewContactInfoRule()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &product.Product{Name: tt.text}
+			if tt.description != "" {
+				p.Description = &tt.description
+			}
+			decision, err := rule.Evaluate(context.Background(), &RuleSubject{Trigger: RuleTriggerCreate, Product: p})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantReason == "" {
+				if decision != nil {
+					t.Fatalf("expected no decision, got %+v", decision)
+				}
+				return
+			}
+			if decision == nil || decision.Outcome != product.RuleOutcomeFlag || decision.Reason != tt.wantReason {
+				t.Fatalf("expected a flag with reason %q, got %+v", tt.wantReason, decision)
+			}
+		})
+	}
+}
+
+func TestContactInfoRuleScansVariantLabel(t *testing.T) {
+	s := &RuleSubject{
+		Trigger: RuleTriggerVariant,
+		Product: &product.Product{Name: "Organic wheat"},
+		Variant: &product.ProductVariant{Label: "50 kg, call 9876543210"},
+	}
+	decision, err := NewContactInfoRule().Evaluate(context.Background(), s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision == nil {
+		t.Fatal("expected the phone number in the variant label to be flagged")
+	}
+}
+
+func TestPriceOutlierDirection(t *testing.T) {
+	median := decimal.NewFromInt(100)
+
+	tests := []struct {
+		name   string
+		price  string
+		factor float64
+		want   string
+	}{
+		{name: "at the median", price: "100", factor: 3},
+		{name: "exactly factor above", price: "300", factor: 3},
+		{name: "just above", price: "300.01", factor: 3, want: "above"},
+		{name: "exactly factor below", price: "33.34", factor: 3},
+		{name: "just below", price: "33.33", factor: 3, want: "below"},
+		{name: "fractional factor above", price: "151", factor: 1.5, want: "above"},
+		{name: "fractional factor inside", price: "70", factor: 1.5},
+		{name: "fractional factor below", price: "66", factor: 1.5, want: "below"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := priceOutlierDirection(decimal.RequireFromString(tt.price), median, decimal.NewFromFloat(tt.factor))
+			if got != tt.want {
+				t.Fatalf("priceOutlierDirection(%s, %s, %v) = %q, want %q", tt.price, median, tt.factor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPriceOutlierRuleDefaultFactor(t *testing.T) {
+	for _, factor := range []float64{0, 1, -2} {
+		if got := NewPriceOutlierRule(nil, factor).factor; !got.Equal(decimal.NewFromFloat(DefaultPriceOutlierFactor)) {
+			t.Fatalf("factor %v: expected the default factor, got %s", factor, got)
+		}
+	}
+}
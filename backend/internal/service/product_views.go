@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/model/analytics"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// how often buffered views are written to the database
+	ProductViewFlushInterval = 30 * time.Second
+	// events beyond this many unflushed ones are dropped rather than
+	// slowing down product reads
+	ProductViewBufferSize = 10000
+)
+
+// ProductViewRecorder counts product detail views and list impressions in
+// memory and writes them in batches, so reading a product never waits on
+// an analytics insert.
+type ProductViewRecorder struct {
+	analyticsRepo *repository.AnalyticsRepository
+	events        chan analytics.ViewEvent
+	log           *zerolog.Logger
+}
+
+func NewProductViewRecorder(analyticsRepo *repository.AnalyticsRepository, bufferSize int, log *zerolog.Logger) *ProductViewRecorder {
+	return &ProductViewRecorder{
+		analyticsRepo: analyticsRepo,
+		events:        make(chan analytics.ViewEvent, bufferSize),
+		log:           log,
+	}
+}
+
+// RecordView counts a detail view of the product by the viewer.
+func (r *ProductViewRecorder) RecordView(ctx context.Context, productID, companyID uuid.UUID, userID *uuid.UUID) {
+	r.enqueue(analytics.ViewEvent{
+		Kind:      analytics.ViewKindDetail,
+		ProductID: productID,
+		CompanyID: companyID,
+		ViewerKey: viewerKey(ctx, userID),
+		At:        time.Now(),
+	})
+}
+
+// RecordImpression counts the product showing up in a list.
+func (r *ProductViewRecorder) RecordImpression(productID, companyID uuid.UUID) {
+	r.enqueue(analytics.ViewEvent{
+		Kind:      analytics.ViewKindImpression,
+		ProductID: productID,
+		CompanyID: companyID,
+		At:        time.Now(),
+	})
+}
+
+func (r *ProductViewRecorder) enqueue(e analytics.ViewEvent) {
+	select {
+	case r.events <- e:
+	default:
+		r.log.Warn().Str("productId", e.ProductID.String()).Msg("product view buffer full, dropping event")
+	}
+}
+
+// Run aggregates events and flushes them every interval until ctx is done,
+// then flushes what is left.
+func (r *ProductViewRecorder) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	counts := make(map[analytics.DailyKey]analytics.DailyCounts)
+	viewers := make(map[analytics.DailyViewer]struct{})
+
+	flush := func(ctx context.Context) {
+		if len(counts) == 0 && len(viewers) == 0 {
+			return
+		}
+		list := make([]analytics.DailyViewer, 0, len(viewers))
+		for v := range viewers {
+			list = append(list, v)
+		}
+		if err := r.analyticsRepo.Flush(ctx, counts, list); err != nil {
+			// counts are kept and retried on the next tick
+			r.log.Error().Err(err).Int("rows", len(counts)).Msg("failed to flush product views")
+			return
+		}
+		clear(counts)
+		clear(viewers)
+	}
+
+	add := func(e analytics.ViewEvent) {
+		key := analytics.DailyKey{
+			ProductID: e.ProductID,
+			CompanyID: e.CompanyID,
+			Day:       e.At.UTC().Truncate(24 * time.Hour),
+		}
+		c := counts[key]
+		if e.Kind == analytics.ViewKindDetail {
+			c.Views++
+			viewers[analytics.DailyViewer{DailyKey: key, ViewerKey: e.ViewerKey}] = struct{}{}
+		} else {
+			c.Impressions++
+		}
+		counts[key] = c
+	}
+
+	for {
+		select {
+		case e := <-r.events:
+			add(e)
+		case <-ticker.C:
+			flush(ctx)
+		case <-ctx.Done():
+			// take what was queued before shutdown
+			for len(r.events) > 0 {
+				add(<-r.events)
+			}
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			flush(shutdownCtx)
+			cancel()
+			return
+		}
+	}
+}
+
+// viewerKey identifies a viewer for unique counts without storing client
+// details: the user id when signed in, a hash of IP and user agent otherwise.
+func viewerKey(ctx context.Context, userID *uuid.UUID) string {
+	if userID != nil && *userID != uuid.Nil {
+		return "u:" + userID.String()
+	}
+	info := utils.ClientInfoFromContext(ctx)
+	sum := sha256.Sum256([]byte(info.IPAddress + "|" + info.UserAgent))
+	return "c:" + hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/C0deNe0/agromart/internal/lib/notify"
+	"github.com/C0deNe0/agromart/internal/model"
+	"github.com/C0deNe0/agromart/internal/model/audit"
+	"github.com/C0deNe0/agromart/internal/model/report"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/C0deNe0/agromart/internal/repository/productRepo"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const DefaultReportHideThreshold = 3
+
+// ReportService handles user reports against products and companies. Once
+// an entity collects hideThreshold open reports it is hidden from everyone
+// but its owner until an admin resolves or dismisses the reports.
+type ReportService struct {
+	reportRepo    *repository.ReportRepository
+	productRepo   *productRepo.ProductRepository
+	companyRepo   *repository.CompanyRepository
+	notifier      notify.Notifier
+	audit         *AuditService
+	hideThreshold int
+	log           *zerolog.Logger
+}
+
+func NewReportService(reportRepo *repository.ReportRepository, productRepo *productRepo.ProductRepository, companyRepo *repository.CompanyRepository, notifier notify.Notifier, audit *AuditService, hideThreshold int, log *zerolog.Logger) *ReportService {
+	if hideThreshold <= 0 {
+		hideThreshold = DefaultReportHideThreshold
+	}
+	return &ReportService{
+		reportRepo:    reportRepo,
+		productRepo:   productRepo,
+		companyRepo:   companyRepo,
+		notifier:      notifier,
+		audit:         audit,
+		hideThreshold: hideThreshold,
+		log:           log,
+	}
+}
+
+// reportTarget is what the service needs to know about a reported entity.
+type reportTarget struct {
+	Name     string
+	OwnerID  uuid.UUID
+	Approved bool
+}
+
+func (s *ReportService) Create(ctx context.Context, userID uuid.UUID, req *report.CreateReportRequest) (*report.Report, error) {
+	target, err := s.target(ctx, req.EntityType, req.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	if !target.Approved {
+		return nil, errors.New("only approved listings can be reported")
+	}
+	if target.OwnerID == userID {
+		return nil, errors.New("cannot report your own listing")
+	}
+
+	created, err := s.reportRepo.Create(ctx, &report.Report{
+		ReporterID: userID,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		Reason:     req.Reason,
+		Comment:    req.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, userID, audit.ActionReport, auditEntityType(req.EntityType), req.EntityID, nil, created)
+
+	open, err := s.reportRepo.CountOpen(ctx, req.EntityType, req.EntityID)
+	if err != nil {
+		return nil, err
+	}
+	if open >= s.hideThreshold {
+		hidden, err := s.reportRepo.Hide(ctx, req.EntityType, req.EntityID)
+		if err != nil {
+			return nil, err
+		}
+		if hidden {
+			s.audit.Record(ctx, uuid.Nil, audit.ActionHide, auditEntityType(req.EntityType), req.EntityID, nil, map[string]int{"openReports": open})
+			s.notify(ctx, notify.Notification{
+				UserID: target.OwnerID,
+				Kind:   "REPORT_HIDDEN",
+				Title:  "Your listing is under review",
+				Body:   fmt.Sprintf("%q received several reports and is hidden until our team reviews it.", target.Name),
+				Data:   reportData(req.EntityType, req.EntityID, map[string]string{"openReports": strconv.Itoa(open)}),
+			})
+		}
+	}
+
+	return created, nil
+}
+
+func (s *ReportService) ListMine(ctx context.Context, userID uuid.UUID, page, limit int) (*model.PaginatedResponse[report.Report], error) {
+	return s.reportRepo.ListByReporter(ctx, userID, page, limit)
+}
+
+func (s *ReportService) Queue(ctx context.Context, filter repository.ReportQueueFilter) (*model.PaginatedResponse[report.QueueItem], error) {
+	return s.reportRepo.ListQueue(ctx, filter)
+}
+
+func (s *ReportService) ListForEntity(ctx context.Context, entityType report.EntityType, entityID uuid.UUID, status *report.Status) ([]report.Report, error) {
+	return s.reportRepo.ListByEntity(ctx, entityType, entityID, status)
+}
+
+// Resolve upholds the open reports. The entity stays hidden unless restore
+// is set, so the admin can follow up with a rejection or deactivation.
+func (s *ReportService) Resolve(ctx context.Context, adminID uuid.UUID, entityType report.EntityType, entityID uuid.UUID, note *string, restore bool) (*report.DecideReportsResponse, error) {
+	return s.close(ctx, adminID, entityType, entityID, report.StatusResolved, note, restore)
+}
+
+// Dismiss rejects the open reports and restores the entity.
+func (s *ReportService) Dismiss(ctx context.Context, adminID uuid.UUID, entityType report.EntityType, entityID uuid.UUID, note *string) (*report.DecideReportsResponse, error) {
+	return s.close(ctx, adminID, entityType, entityID, report.StatusDismissed, note, true)
+}
+
+func (s *ReportService) close(ctx context.Context, adminID uuid.UUID, entityType report.EntityType, entityID uuid.UUID, status report.Status, note *string, restore bool) (*report.DecideReportsResponse, error) {
+	target, err := s.target(ctx, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := s.reportRepo.Close(ctx, entityType, entityID, status, adminID, note)
+	if err != nil {
+		return nil, err
+	}
+	if len(closed) == 0 {
+		return nil, errors.New("no open reports for this listing")
+	}
+
+	resp := &report.DecideReportsResponse{Closed: len(closed)}
+	if restore {
+		if resp.Restored, err = s.reportRepo.Unhide(ctx, entityType, entityID); err != nil {
+			return nil, err
+		}
+	}
+
+	action := audit.ActionResolve
+	if status == report.StatusDismissed {
+		action = audit.ActionDismiss
+	}
+	s.audit.Record(ctx, adminID, action, auditEntityType(entityType), entityID, nil, map[string]any{
+		"closed": resp.Closed,
+		"note":   note,
+	})
+	if resp.Restored {
+		s.audit.Record(ctx, adminID, audit.ActionRestore, auditEntityType(entityType), entityID, nil, nil)
+		s.notify(ctx, notify.Notification{
+			UserID: target.OwnerID,
+			Kind:   "REPORT_RESTORED",
+			Title:  "Your listing is visible again",
+			Body:   fmt.Sprintf("Our review of %q is complete and it is visible again.", target.Name),
+			Data:   reportData(entityType, entityID, nil),
+		})
+	}
+
+	title, body := "Thanks for your report", fmt.Sprintf("We reviewed your report about %q and took action.", target.Name)
+	if status == report.StatusDismissed {
+		body = fmt.Sprintf("We reviewed your report about %q and found no violation.", target.Name)
+	}
+	for _, r := range closed {
+		s.notify(ctx, notify.Notification{
+			UserID: r.ReporterID,
+			Kind:   "REPORT_" + string(status),
+			Title:  title,
+			Body:   body,
+			Data:   reportData(entityType, entityID, map[string]string{"reportId": r.ID.String()}),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *ReportService) target(ctx context.Context, entityType report.EntityType, entityID uuid.UUID) (*reportTarget, error) {
+	switch entityType {
+	case report.EntityProduct:
+		p, err := s.productRepo.GetByID(ctx, entityID)
+		if err != nil {
+			return nil, fmt.Errorf("product not found: %w", err)
+		}
+		comp, err := s.companyRepo.GetByID(ctx, p.CompanyID)
+		if err != nil {
+			return nil, fmt.Errorf("company not found: %w", err)
+		}
+		return &reportTarget{Name: p.Name, OwnerID: comp.OwnerID, Approved: p.IsApproved()}, nil
+	case report.EntityCompany:
+		comp, err := s.companyRepo.GetByID(ctx, entityID)
+		if err != nil {
+			return nil, fmt.Errorf("company not found: %w", err)
+		}
+		return &reportTarget{Name: comp.Name, OwnerID: comp.OwnerID, Approved: comp.IsApproved()}, nil
+	}
+	return nil, fmt.Errorf("unsupported entity type: %s", entityType)
+}
+
+func (s *ReportService) notify(ctx context.Context, n notify.Notification) {
+	if err := s.notifier.Notify(ctx, n); err != nil {
+		s.log.Error().Err(err).Str("userId", n.UserID.String()).Str("kind", n.Kind).Msg("failed to send report notification")
+	}
+}
+
+func reportData(entityType report.EntityType, entityID uuid.UUID, extra map[string]string) map[string]string {
+	data := map[string]string{
+		"entityType": string(entityType),
+		"entityId":   entityID.String(),
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	return data
+}
+
+func auditEntityType(entityType report.EntityType) audit.EntityType {
+	if entityType == report.EntityCompany {
+		return audit.EntityCompany
+	}
+	return audit.EntityProduct
+}
@@ -2,32 +2,79 @@ package service
 
 import (
 	"github.com/C0deNe0/agromart/internal/lib/aws"
+	"github.com/C0deNe0/agromart/internal/lib/notify"
 	"github.com/C0deNe0/agromart/internal/lib/utils"
 	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/rs/zerolog"
 )
 
 type Services struct {
-	User         *UserService
-	Company      *CompanyService
-	Product      *ProductService
-	Auth         *AuthService
-	RefreshToken *repository.RefreshTokenRepository
+	User          *UserService
+	Company       *CompanyService
+	Product       *ProductService
+	Auth          *AuthService
+	Impersonation *ImpersonationService
+	APIKey        *APIKeyService
+	CompanyKYC    *CompanyKYCService
+	CompanyImage  *CompanyImageService
+	Availability  *AvailabilityService
+	LoginAudit    *LoginAuditService
+	Moderation    *ModerationService
+	Audit         *AuditService
+	Report        *ReportService
+	Suspension    *SuspensionService
+	Stats         *StatsService
+	ProductViews  *ProductViewRecorder
+	Analytics     *AnalyticsService
+	Feed          *FeedService
+	Storefront    *StorefrontService
+	RefreshToken  *repository.RefreshTokenRepository
 }
 
 //later we can add the aws client directly here to the services which requires it
 
-func NewServices(repo *repository.Repositories, tokenManager *utils.TokenManager, refreshTokenRepo *repository.RefreshTokenRepository, s3Client *aws.S3Service) *Services {
+func NewServices(repo *repository.Repositories, tokenManager *utils.TokenManager, refreshTokenRepo *repository.RefreshTokenRepository, s3Client *aws.S3Service, oidc *utils.OIDCRegistry, googleWeb *utils.OAuthWebClient, moderation ModerationConfig, notifier notify.Notifier, log *zerolog.Logger) *Services {
 
-	CompanyService := NewCompanyService(repo.Company, repo.CompanyFollower)
+	auditService := NewAuditService(repo.AuditLog, log)
 
-	productService := NewProductService(repo.Product, repo.ProductImage, repo.ProductVariant, CompanyService.companyRepo, s3Client)
+	CompanyService := NewCompanyService(repo.Company, repo.CompanyFollower, repo.Moderation, repo.CompanyServiceArea, auditService)
+
+	productViews := NewProductViewRecorder(repo.Analytics, ProductViewBufferSize, log)
+
+	productService := NewProductService(repo.Product, repo.ProductImage, repo.ProductVariant, repo.ProductRevision, CompanyService.companyRepo, repo.CompanyFollower, repo.CompanyServiceArea, repo.Category, repo.Moderation, s3Client, auditService, productViews, log,
+		NewProductRules(repo.Product, repo.ProductImage, moderation)...,
+	)
+
+	loginAuditService := NewLoginAuditService(
+		repo.LoginEvent,
+		repo.User,
+		notifier,
+		log,
+		NewNewDeviceRule(repo.LoginEvent),
+		NewNewLocationRule(repo.LoginEvent),
+	)
 
 	return &Services{
-		User:         NewUserService(repo.User),
-		Company:      CompanyService,
-		Product:      productService,
-		Auth:         NewAuthService(repo.User, repo.UserAuthMethod, tokenManager, refreshTokenRepo),
-		RefreshToken: refreshTokenRepo,
+		User:          NewUserService(repo.User),
+		Company:       CompanyService,
+		Product:       productService,
+		Auth:          NewAuthService(repo.User, repo.UserAuthMethod, tokenManager, refreshTokenRepo, oidc, googleWeb, loginAuditService),
+		RefreshToken:  refreshTokenRepo,
+		Impersonation: NewImpersonationService(repo.Impersonation, repo.User, tokenManager),
+		APIKey:        NewAPIKeyService(repo.CompanyAPIKey, repo.Company, auditService),
+		LoginAudit:    loginAuditService,
+		CompanyKYC:    NewCompanyKYCService(repo.Company, repo.CompanyKYC, s3Client, auditService),
+		CompanyImage:  NewCompanyImageService(repo.Company, repo.CompanyImage, s3Client, auditService, log),
+		Availability:  NewAvailabilityService(repo.Company, repo.CompanyHoliday, auditService, log),
+		Moderation:    NewModerationService(repo.Moderation, repo.Company, repo.Product, repo.User),
+		Audit:         auditService,
+		Stats:         NewStatsService(repo.Stats),
+		ProductViews:  productViews,
+		Analytics:     NewAnalyticsService(repo.Analytics, repo.Company, repo.Product),
+		Feed:          NewFeedService(repo.Feed, repo.Product, repo.ProductImage, repo.ProductVariant, repo.Company),
+		Storefront:    NewStorefrontService(CompanyService, productService),
+		Suspension:    NewSuspensionService(repo.Company, repo.Product, auditService, log),
+		Report:        NewReportService(repo.Report, repo.Product, repo.Company, notifier, auditService, moderation.ReportHideThreshold, log),
 	}
 }
 
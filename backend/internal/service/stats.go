@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/stats"
+	"github.com/C0deNe0/agromart/internal/repository"
+)
+
+const (
+	DefaultStatsRange = 30 * 24 * time.Hour
+	// keeps a single request from generating an unbounded series
+	MaxStatsBuckets = 400
+)
+
+type StatsService struct {
+	statsRepo *repository.StatsRepository
+}
+
+func NewStatsService(statsRepo *repository.StatsRepository) *StatsService {
+	return &StatsService{statsRepo: statsRepo}
+}
+
+// Get builds the admin dashboard for [from, to). A missing to defaults to
+// now, a missing from to DefaultStatsRange before to.
+func (s *StatsService) Get(ctx context.Context, from, to *time.Time, groupBy stats.GroupBy) (*stats.StatsResponse, error) {
+	end := time.Now().UTC()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-DefaultStatsRange)
+	if from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("from must be before to")
+	}
+	if buckets := approxBuckets(start, end, groupBy); buckets > MaxStatsBuckets {
+		return nil, fmt.Errorf("range spans %d %s buckets, at most %d are allowed", buckets, groupBy, MaxStatsBuckets)
+	}
+
+	resp := &stats.StatsResponse{From: start, To: end, GroupBy: groupBy}
+	var err error
+
+	if resp.Signups, err = s.statsRepo.Signups(ctx, start, end, groupBy); err != nil {
+		return nil, err
+	}
+	if resp.Companies, err = s.statsRepo.Companies(ctx, start, end, groupBy); err != nil {
+		return nil, err
+	}
+	if resp.Followers, err = s.statsRepo.Followers(ctx, start, end, groupBy); err != nil {
+		return nil, err
+	}
+	if resp.ApprovalTime, err = s.statsRepo.ApprovalTime(ctx, start, end, groupBy); err != nil {
+		return nil, err
+	}
+	if resp.ProductsPerCategory, err = s.statsRepo.ProductsPerCategory(ctx); err != nil {
+		return nil, err
+	}
+	subscriptions, err := s.statsRepo.Subscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.Subscriptions = *subscriptions
+
+	return resp, nil
+}
+
+func approxBuckets(from, to time.Time, groupBy stats.GroupBy) int {
+	days := int(to.Sub(from).Hours()/24) + 1
+	switch groupBy {
+	case stats.GroupByWeek:
+		return days/7 + 1
+	case stats.GroupByMonth:
+		return days/28 + 1
+	}
+	return days
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+
+	"github.com/C0deNe0/agromart/internal/model/company"
+	"github.com/C0deNe0/agromart/internal/model/product"
+	"github.com/C0deNe0/agromart/internal/model/storefront"
+	"github.com/C0deNe0/agromart/internal/repository/productRepo"
+	"github.com/google/uuid"
+)
+
+// newest products shown on the storefront
+const StorefrontFeaturedProducts = 8
+
+type StorefrontService struct {
+	companyService *CompanyService
+	productService *ProductService
+}
+
+func NewStorefrontService(companyService *CompanyService, productService *ProductService) *StorefrontService {
+	return &StorefrontService{
+		companyService: companyService,
+		productService: productService,
+	}
+}
+
+// Get builds the storefront for a slug. moved is true when the slug is an
+// earlier one, the caller redirects to resp.Company.Slug. There is no
+// rating summary yet, companies cannot be reviewed or rated.
+func (s *StorefrontService) Get(ctx context.Context, slug string, userID *uuid.UUID) (*storefront.Response, bool, error) {
+	comp, moved, err := s.companyService.GetBySlug(ctx, slug, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if moved {
+		return &storefront.Response{Company: comp}, true, nil
+	}
+
+	approved := company.ApprovalStatusApproved
+	active := true
+	featured, err := s.productService.List(ctx, userID, productRepo.ProductFilter{
+		CompanyID:      &comp.ID,
+		ApprovalStatus: &approved,
+		IsActive:       &active,
+		Page:           1,
+		Limit:          StorefrontFeaturedProducts,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	categories, err := s.productService.CategoryBreakdown(ctx, userID, comp.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	total := 0
+	for _, c := range categories {
+		total += c.Count
+	}
+	if categories == nil {
+		categories = []product.CategoryCount{}
+	}
+
+	return &storefront.Response{
+		Company:          comp,
+		FeaturedProducts: featured.Data,
+		Categories:       categories,
+		ProductCount:     total,
+	}, false, nil
+}
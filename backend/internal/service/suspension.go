@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/model/audit"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/C0deNe0/agromart/internal/repository/productRepo"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// how often Run looks for suspensions whose end date has passed
+const SuspensionSweepInterval = time.Minute
+
+// SuspensionService lets admins take approved companies and products
+// offline. A suspended company keeps its products but none of them are
+// shown to buyers, and it cannot be followed while is_active is false.
+type SuspensionService struct {
+	companyRepo *repository.CompanyRepository
+	productRepo *productRepo.ProductRepository
+	audit       *AuditService
+	log         *zerolog.Logger
+}
+
+func NewSuspensionService(companyRepo *repository.CompanyRepository, productRepo *productRepo.ProductRepository, audit *AuditService, log *zerolog.Logger) *SuspensionService {
+	return &SuspensionService{
+		companyRepo: companyRepo,
+		productRepo: productRepo,
+		audit:       audit,
+		log:         log,
+	}
+}
+
+// auditSuspension is the state stored around suspensions.
+type auditSuspension struct {
+	IsActive bool       `json:"isActive"`
+	Reason   *string    `json:"reason,omitempty"`
+	Until    *time.Time `json:"until,omitempty"`
+}
+
+func (s *SuspensionService) SuspendCompany(ctx context.Context, companyID, adminID uuid.UUID, reason string, until *time.Time, notes *string) error {
+	if err := checkSuspensionEnd(until); err != nil {
+		return err
+	}
+	if err := s.companyRepo.Suspend(ctx, companyID, adminID, reason, until, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionSuspend, audit.EntityCompany, companyID,
+		auditSuspension{IsActive: true},
+		auditSuspension{Reason: &reason, Until: until})
+	return nil
+}
+
+func (s *SuspensionService) ReinstateCompany(ctx context.Context, companyID, adminID uuid.UUID, notes *string) error {
+	if err := s.companyRepo.Reinstate(ctx, companyID, &adminID, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionReinstate, audit.EntityCompany, companyID,
+		nil, auditSuspension{IsActive: true})
+	return nil
+}
+
+func (s *SuspensionService) SuspendProduct(ctx context.Context, productID, adminID uuid.UUID, reason string, until *time.Time, notes *string) error {
+	if err := checkSuspensionEnd(until); err != nil {
+		return err
+	}
+	if err := s.productRepo.Suspend(ctx, productID, adminID, reason, until, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionSuspend, audit.EntityProduct, productID,
+		auditSuspension{IsActive: true},
+		auditSuspension{Reason: &reason, Until: until})
+	return nil
+}
+
+func (s *SuspensionService) ReinstateProduct(ctx context.Context, productID, adminID uuid.UUID, notes *string) error {
+	if err := s.productRepo.Reinstate(ctx, productID, &adminID, notes); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, adminID, audit.ActionReinstate, audit.EntityProduct, productID,
+		nil, auditSuspension{IsActive: true})
+	return nil
+}
+
+// ReinstateExpired lifts every suspension whose end date has passed.
+func (s *SuspensionService) ReinstateExpired(ctx context.Context) error {
+	companies, err := s.companyRepo.ReinstateExpired(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range companies {
+		s.audit.Record(ctx, uuid.Nil, audit.ActionReinstate, audit.EntityCompany, id, nil, auditSuspension{IsActive: true})
+	}
+
+	products, err := s.productRepo.ReinstateExpired(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range products {
+		s.audit.Record(ctx, uuid.Nil, audit.ActionReinstate, audit.EntityProduct, id, nil, auditSuspension{IsActive: true})
+	}
+
+	if len(companies)+len(products) > 0 {
+		s.log.Info().Int("companies", len(companies)).Int("products", len(products)).Msg("reinstated expired suspensions")
+	}
+	return nil
+}
+
+// Run sweeps expired suspensions every interval until ctx is done.
+func (s *SuspensionService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ReinstateExpired(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			s.log.Error().Err(err).Msg("failed to reinstate expired suspensions")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func checkSuspensionEnd(until *time.Time) error {
+	if until != nil && !until.After(time.Now()) {
+		return fmt.Errorf("suspension end must be in the future")
+	}
+	return nil
+}
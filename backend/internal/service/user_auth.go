@@ -2,45 +2,65 @@ package service
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	apperr "github.com/C0deNe0/agromart/internal/err"
 	"github.com/C0deNe0/agromart/internal/lib/utils"
 	"github.com/C0deNe0/agromart/internal/model/auth"
 	"github.com/C0deNe0/agromart/internal/model/user"
 	"github.com/C0deNe0/agromart/internal/repository"
 	"github.com/google/uuid"
+	"golang.org/x/oauth2"
 )
 
+// AuthMethodStore is what the auth service needs from
+// UserAuthMethodRepository, so linking can be tested without a database.
+type AuthMethodStore interface {
+	Create(ctx context.Context, m *repository.UserAuthMethod) (*repository.UserAuthMethod, error)
+	GetLocalByEmail(ctx context.Context, email string) (*repository.UserAuthMethod, error)
+	EnsureOAuth(ctx context.Context, userID uuid.UUID, provider string, sub string) (*repository.UserAuthMethod, error)
+	GetByProviderSub(ctx context.Context, provider string, sub string) (*repository.UserAuthMethod, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]repository.UserAuthMethod, error)
+	Delete(ctx context.Context, userID uuid.UUID, provider string) error
+}
+
 type AuthService struct {
 	userRepo         *repository.UserRepository
-	authMethodRepo   *repository.UserAuthMethodRepository
+	authMethodRepo   AuthMethodStore
 	TokenManager     *utils.TokenManager
 	refreshTokenRepo *repository.RefreshTokenRepository
+	oidc             *utils.OIDCRegistry
+	googleWeb        *utils.OAuthWebClient
+	loginAudit       *LoginAuditService
 }
 
 func NewAuthService(
 	userRepo *repository.UserRepository,
-	authMethodRepo *repository.UserAuthMethodRepository,
+	authMethodRepo AuthMethodStore,
 	tokenManager *utils.TokenManager,
 	refreshTokenRepo *repository.RefreshTokenRepository,
+	oidc *utils.OIDCRegistry,
+	googleWeb *utils.OAuthWebClient,
+	loginAudit *LoginAuditService,
 ) *AuthService {
 	return &AuthService{
 		userRepo:         userRepo,
 		authMethodRepo:   authMethodRepo,
 		TokenManager:     tokenManager,
 		refreshTokenRepo: refreshTokenRepo,
+		oidc:             oidc,
+		googleWeb:        googleWeb,
+		loginAudit:       loginAudit,
 	}
 }
 
-type ctxKey string
-
 const (
 	AuthProviderLocal  = "LOCAL"
 	AuthProviderGoogle = "GOOGLE"
-	ctxUserAgent       = ctxKey("user_agent")
-	ctxIPAddress       = ctxKey("ip")
 )
 
 // register with email
@@ -88,6 +108,7 @@ func (s *AuthService) RegisterWithEmail(ctx context.Context, email string, passw
 	if err != nil {
 		return nil, err
 	}
+	s.loginAudit.RecordSuccess(ctx, createdUser.ID, createdUser.Email, AuthProviderLocal)
 
 	return &user.AuthResponse{
 		User: user.UserResponse{
@@ -106,14 +127,17 @@ func (s *AuthService) LoginWithEmail(ctx context.Context, email string, password
 	//Get user by email
 	method, err := s.authMethodRepo.GetLocalByEmail(ctx, email)
 	if err != nil || method.PasswordHash == nil {
+		s.loginAudit.RecordFailure(ctx, nil, email, AuthProviderLocal, auth.LoginFailureInvalidCredentials)
 		return nil, fmt.Errorf("getlocal email failed %v", err)
 	}
 	if err := utils.VerifyPassword(*method.PasswordHash, password); err != nil {
+		s.loginAudit.RecordFailure(ctx, &method.UserId, email, AuthProviderLocal, auth.LoginFailureInvalidCredentials)
 		return nil, fmt.Errorf("verify password failed %v", err)
 	}
 
 	u, err := s.userRepo.GetByID(ctx, method.UserId)
 	if err != nil || !u.IsActive {
+		s.loginAudit.RecordFailure(ctx, &method.UserId, email, AuthProviderLocal, auth.LoginFailureUserInactive)
 		return nil, fmt.Errorf("user not allowed %v", err)
 	}
 
@@ -122,6 +146,7 @@ func (s *AuthService) LoginWithEmail(ctx context.Context, email string, password
 	if err != nil {
 		return nil, err
 	}
+	s.loginAudit.RecordSuccess(ctx, u.ID, u.Email, AuthProviderLocal)
 
 	return &user.AuthResponse{
 		User: user.UserResponse{
@@ -135,33 +160,81 @@ func (s *AuthService) LoginWithEmail(ctx context.Context, email string, password
 	}, nil
 }
 
-func (s *AuthService) LoginWithGoogle(ctx context.Context, googleSub string, email, name string, profileURL *string) (*user.AuthResponse, error) {
-	//Get user by email
-	u, err := s.userRepo.GetByEmail(ctx, email)
-	if errors.Is(err, repository.ErrNotFound) {
-		u, err = s.userRepo.Create(ctx, &user.User{
-			Email:           email,
-			Name:            name,
-			Role:            user.RoleUser,
-			EmailVerified:   true,
-			IsActive:        true,
-			ProfileImageURL: profileURL,
-		})
+// LoginWithOIDC verifies an ID token from any configured provider and logs the
+// user in, creating the account on first sign in.
+func (s *AuthService) LoginWithOIDC(ctx context.Context, provider string, idToken string) (*user.AuthResponse, error) {
+	verifier, ok := s.oidc.Get(provider)
+	if !ok {
+		return nil, fmt.Errorf("unsupported login provider: %s", provider)
+	}
+
+	claims, err := verifier.Verify(ctx, idToken)
+	if err != nil {
+		s.loginAudit.RecordFailure(ctx, nil, "", verifier.Provider().Name, auth.LoginFailureInvalidToken)
+		return nil, err
+	}
+
+	return s.loginWithOIDCClaims(ctx, verifier.Provider().Name, claims)
+}
+
+func (s *AuthService) loginWithOIDCClaims(ctx context.Context, provider string, claims *utils.OIDCUserClaims) (*user.AuthResponse, error) {
+	var u *user.User
+
+	// 1. already linked provider account
+	method, err := s.authMethodRepo.GetByProviderSub(ctx, provider, claims.Sub)
+	switch {
+	case err == nil:
+		u, err = s.userRepo.GetByID(ctx, method.UserId)
 		if err != nil {
 			return nil, err
 		}
-	} else if err != nil {
+	case errors.Is(err, repository.ErrNotFound):
+		if claims.Email == "" {
+			return nil, errors.New("email claim is missing or invalid")
+		}
+		// 2. existing account with same email, only trusted when the provider verified it
+		u, err = s.userRepo.GetByEmail(ctx, claims.Email)
+		if errors.Is(err, repository.ErrNotFound) {
+			var profileURL *string
+			if claims.Picture != "" {
+				profileURL = &claims.Picture
+			}
+			// 3. first sign in
+			u, err = s.userRepo.Create(ctx, &user.User{
+				Email:           claims.Email,
+				Name:            claims.Name,
+				Role:            user.RoleUser,
+				EmailVerified:   claims.EmailVerified,
+				IsActive:        true,
+				ProfileImageURL: profileURL,
+			})
+			if err != nil {
+				return nil, err
+			}
+		} else if err != nil {
+			return nil, err
+		} else if !claims.EmailVerified {
+			s.loginAudit.RecordFailure(ctx, &u.ID, claims.Email, provider, auth.LoginFailureEmailNotVerified)
+			return nil, errors.New("email is not verified by provider, sign in and link the account from your profile")
+		}
+
+		if _, err := s.authMethodRepo.EnsureOAuth(ctx, u.ID, provider, claims.Sub); err != nil {
+			return nil, err
+		}
+	default:
 		return nil, err
 	}
 
-	if _, err := s.authMethodRepo.EnsureOAuth(ctx, u.ID, AuthProviderGoogle, googleSub); err != nil {
-		return nil, err
+	if !u.IsActive {
+		s.loginAudit.RecordFailure(ctx, &u.ID, u.Email, provider, auth.LoginFailureUserInactive)
+		return nil, apperr.ErrUserNotAllowed
 	}
 
 	access, refresh, err := s.issueTokens(ctx, u.ID, string(u.Role))
 	if err != nil {
 		return nil, err
 	}
+	s.loginAudit.RecordSuccess(ctx, u.ID, u.Email, provider)
 
 	return &user.AuthResponse{
 		User: user.UserResponse{
@@ -176,6 +249,129 @@ func (s *AuthService) LoginWithGoogle(ctx context.Context, googleSub string, ema
 	}, nil
 }
 
+// WEB AUTHORIZATION CODE FLOW (PKCE)
+
+type WebLoginSession struct {
+	State    string
+	Nonce    string
+	Verifier string
+	AuthURL  string
+}
+
+func (s *AuthService) StartGoogleWebLogin() (*WebLoginSession, error) {
+	state, err := utils.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := utils.GenerateRandomString(32)
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	return &WebLoginSession{
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+		AuthURL:  s.googleWeb.AuthURL(state, nonce, verifier),
+	}, nil
+}
+
+func (s *AuthService) CompleteGoogleWebLogin(ctx context.Context, code, verifier, expectedNonce string) (*user.AuthResponse, error) {
+	idToken, err := s.googleWeb.Exchange(ctx, code, verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	verifierOIDC, ok := s.oidc.Get(AuthProviderGoogle)
+	if !ok {
+		return nil, errors.New("google login is not configured")
+	}
+
+	claims, err := verifierOIDC.Verify(ctx, idToken)
+	if err != nil {
+		s.loginAudit.RecordFailure(ctx, nil, "", AuthProviderGoogle, auth.LoginFailureInvalidToken)
+		return nil, err
+	}
+
+	if claims.Nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(expectedNonce)) != 1 {
+		s.loginAudit.RecordFailure(ctx, nil, claims.Email, AuthProviderGoogle, auth.LoginFailureInvalidToken)
+		return nil, errors.New("nonce mismatch")
+	}
+
+	return s.loginWithOIDCClaims(ctx, AuthProviderGoogle, claims)
+}
+
+func (s *AuthService) WebPostLoginRedirect() string {
+	return s.googleWeb.PostLoginRedirect
+}
+
+// ACCOUNT LINKING
+
+func (s *AuthService) ListAuthMethods(ctx context.Context, userID uuid.UUID) ([]auth.AuthMethodResponse, error) {
+	methods, err := s.authMethodRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auth methods: %w", err)
+	}
+
+	resp := make([]auth.AuthMethodResponse, 0, len(methods))
+	for _, m := range methods {
+		resp = append(resp, auth.AuthMethodResponse{
+			Provider:    m.AuthProvider,
+			HasPassword: m.PasswordHash != nil,
+			LinkedAt:    m.CreatedAt,
+		})
+	}
+	return resp, nil
+}
+
+func (s *AuthService) LinkOIDC(ctx context.Context, userID uuid.UUID, provider string, idToken string) error {
+	verifier, ok := s.oidc.Get(provider)
+	if !ok {
+		return fmt.Errorf("unsupported login provider: %s", provider)
+	}
+
+	claims, err := verifier.Verify(ctx, idToken)
+	if err != nil {
+		return err
+	}
+	providerName := verifier.Provider().Name
+
+	existing, err := s.authMethodRepo.GetByProviderSub(ctx, providerName, claims.Sub)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	if existing != nil && existing.UserId != userID {
+		return errors.New("this account is already linked to another user")
+	}
+
+	if _, err := s.authMethodRepo.EnsureOAuth(ctx, userID, providerName, claims.Sub); err != nil {
+		return fmt.Errorf("failed to link account: %w", err)
+	}
+	return nil
+}
+
+func (s *AuthService) UnlinkProvider(ctx context.Context, userID uuid.UUID, provider string) error {
+	provider = strings.ToUpper(provider)
+
+	methods, err := s.authMethodRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list auth methods: %w", err)
+	}
+
+	if len(methods) <= 1 {
+		return errors.New("cannot unlink the only sign in method on the account")
+	}
+
+	if err := s.authMethodRepo.Delete(ctx, userID, provider); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%s is not linked to this account", provider)
+		}
+		return err
+	}
+	return nil
+}
+
 func (s *AuthService) Refresh(ctx context.Context, rawRefreshToken string) (*user.AuthResponse, error) {
 
 	// 1. Hash incoming refresh token
@@ -248,22 +444,14 @@ func (s *AuthService) issueTokens(ctx context.Context, userID uuid.UUID, role st
 		return
 	}
 
+	client := utils.ClientInfoFromContext(ctx)
 	_, err = s.refreshTokenRepo.Create(ctx, &auth.RefreshToken{
 		UserID:    userID,
 		TokenHash: utils.HashToken(refresh),
-		UserAgent: getCtxString(ctx, "user_agent"),
-		IPAddress: getCtxString(ctx, "ip"),
+		UserAgent: client.UserAgent,
+		IPAddress: client.IPAddress,
 		ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
 	})
 
 	return
 }
-
-func getCtxString(ctx context.Context, key ctxKey) string {
-	if v := ctx.Value(key); v != nil {
-		if s, ok := v.(string); ok {
-			return s
-		}
-	}
-	return ""
-}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/C0deNe0/agromart/internal/lib/utils"
+	"github.com/C0deNe0/agromart/internal/repository"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	testProvider = "STUB"
+	testAudience = "agromart-test-client"
+	testKid      = "k1"
+)
+
+// memAuthMethods keeps auth methods in memory, keyed by user and provider
+// like the unique index on user_auth_methods.
+type memAuthMethods struct {
+	methods []repository.UserAuthMethod
+}
+
+func (m *memAuthMethods) Create(_ context.Context, method *repository.UserAuthMethod) (*repository.UserAuthMethod, error) {
+	m.methods = append(m.methods, *method)
+	return method, nil
+}
+
+func (m *memAuthMethods) GetLocalByEmail(context.Context, string) (*repository.UserAuthMethod, error) {
+	return nil, repository.ErrNotFound
+}
+
+func (m *memAuthMethods) EnsureOAuth(_ context.Context, userID uuid.UUID, provider string, sub string) (*repository.UserAuthMethod, error) {
+	for i := range m.methods {
+		if m.methods[i].UserId == userID && m.methods[i].AuthProvider == provider {
+			m.methods[i].OAuthSub = &sub
+			return &m.methods[i], nil
+		}
+	}
+	m.methods = append(m.methods, repository.UserAuthMethod{UserId: userID, AuthProvider: provider, OAuthSub: &sub})
+	return &m.methods[len(m.methods)-1], nil
+}
+
+func (m *memAuthMethods) GetByProviderSub(_ context.Context, provider string, sub string) (*repository.UserAuthMethod, error) {
+	for i := range m.methods {
+		if m.methods[i].AuthProvider == provider && m.methods[i].OAuthSub != nil && *m.methods[i].OAuthSub == sub {
+			return &m.methods[i], nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (m *memAuthMethods) ListByUserID(_ context.Context, userID uuid.UUID) ([]repository.UserAuthMethod, error) {
+	var methods []repository.UserAuthMethod
+	for _, method := range m.methods {
+		if method.UserId == userID {
+			methods = append(methods, method)
+		}
+	}
+	return methods, nil
+}
+
+func (m *memAuthMethods) Delete(_ context.Context, userID uuid.UUID, provider string) error {
+	for i, method := range m.methods {
+		if method.UserId == userID && method.AuthProvider == provider {
+			m.methods = append(m.methods[:i], m.methods[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (m *memAuthMethods) has(userID uuid.UUID, provider string) bool {
+	for _, method := range m.methods {
+		if method.UserId == userID && method.AuthProvider == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// newStubOIDC starts a local issuer with one RSA key and returns a
+// registry for it and a function signing ID tokens for a subject.
+func newStubOIDC(t *testing.T) (*utils.OIDCRegistry, func(sub string) string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": testKid,
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	}))
+	t.Cleanup(srv.Close)
+
+	registry := utils.NewOIDCRegistry(utils.OIDCProvider{
+		Name:      testProvider,
+		Issuers:   []string{srv.URL},
+		Audiences: []string{testAudience},
+		JWKSURL:   srv.URL,
+	})
+
+	sign := func(sub string) string {
+		t.Helper()
+		now := time.Now()
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss": srv.URL,
+			"aud": testAudience,
+			"sub": sub,
+			"iat": now.Unix(),
+			"exp": now.Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = testKid
+		raw, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return raw
+	}
+	return registry, sign
+}
+
+func TestAuthServiceLinkOIDC(t *testing.T) {
+	registry, sign := newStubOIDC(t)
+	userID := uuid.New()
+	otherUserID := uuid.New()
+
+	tests := []struct {
+		name string
+		// whether the user already linked sub-mine
+		linked   bool
+		provider string
+		sub      string
+		wantErr  string
+	}{
+		{name: "links a new account", provider: "stub", sub: "sub-new"},
+		{name: "relinking the same account is a no-op", linked: true, provider: testProvider, sub: "sub-mine"},
+		{name: "account linked to another user", provider: testProvider, sub: "sub-taken", wantErr: "already linked to another user"},
+		{name: "unknown provider", provider: "apple", sub: "sub-new", wantErr: "unsupported login provider"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mine, taken := "sub-mine", "sub-taken"
+			store := &memAuthMethods{methods: []repository.UserAuthMethod{
+				{UserId: userID, AuthProvider: AuthProviderLocal},
+				{UserId: otherUserID, AuthProvider: testProvider, OAuthSub: &taken},
+			}}
+			if tt.linked {
+				store.methods = append(store.methods, repository.UserAuthMethod{UserId: userID, AuthProvider: testProvider, OAuthSub: &mine})
+			}
+			s := NewAuthService(nil, store, nil, nil, registry, nil, nil)
+
+			err := s.LinkOIDC(context.Background(), userID, tt.provider, sign(tt.sub))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			linked, err := store.GetByProviderSub(context.Background(), testProvider, tt.sub)
+			if err != nil || linked.UserId != userID {
+				t.Fatalf("expected %s to be linked to the user, got %+v (%v)", tt.sub, linked, err)
+			}
+		})
+	}
+}
+
+func TestAuthServiceUnlinkProvider(t *testing.T) {
+	userID := uuid.New()
+	sub := "sub-1"
+
+	tests := []struct {
+		name     string
+		methods  []repository.UserAuthMethod
+		provider string
+		wantErr  string
+	}{
+		{
+			name: "unlinks one of several methods",
+			methods: []repository.UserAuthMethod{
+				{UserId: userID, AuthProvider: AuthProviderLocal},
+				{UserId: userID, AuthProvider: testProvider, OAuthSub: &sub},
+			},
+			provider: "stub",
+		},
+		{
+			name: "refuses to unlink the last sign in method",
+			methods: []repository.UserAuthMethod{
+				{UserId: userID, AuthProvider: testProvider, OAuthSub: &sub},
+			},
+			provider: testProvider,
+			wantErr:  "cannot unlink the only sign in method",
+		},
+		{
+			name: "provider not linked",
+			methods: []repository.UserAuthMethod{
+				{UserId: userID, AuthProvider: AuthProviderLocal},
+				{UserId: userID, AuthProvider: AuthProviderGoogle, OAuthSub: &sub},
+			},
+			provider: testProvider,
+			wantErr:  "is not linked to this account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memAuthMethods{methods: slices.Clone(tt.methods)}
+			s := NewAuthService(nil, store, nil, nil, utils.NewOIDCRegistry(), nil, nil)
+
+			err := s.UnlinkProvider(context.Background(), userID, tt.provider)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				if len(store.methods) != len(tt.methods) {
+					t.Fatalf("expected no method to be removed, %d left", len(store.methods))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if store.has(userID, strings.ToUpper(tt.provider)) {
+				t.Fatalf("expected %s to be unlinked", tt.provider)
+			}
+		})
+	}
+}